@@ -1,24 +1,292 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/JhonesBR/go-clob/internal/api"
+	"github.com/JhonesBR/go-clob/internal/api/funding"
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/config"
+	"github.com/JhonesBR/go-clob/internal/custody"
 	"github.com/JhonesBR/go-clob/internal/db"
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/fix"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/idempotency"
+	"github.com/JhonesBR/go-clob/internal/logging"
+	"github.com/JhonesBR/go-clob/internal/marketdata"
+	"github.com/JhonesBR/go-clob/internal/ratelimit"
+	"github.com/JhonesBR/go-clob/internal/reconcile"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/JhonesBR/go-clob/internal/rpc"
+	"github.com/JhonesBR/go-clob/internal/shutdown"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/storage/memory"
+	"github.com/JhonesBR/go-clob/internal/storage/postgres"
+	"github.com/JhonesBR/go-clob/internal/tracing"
 	"github.com/gofiber/fiber/v3"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			runMigrate(os.Args[2:])
+			return
+		case "reconcile":
+			runReconcile()
+			return
+		}
+	}
+
+	// Configuration from the file, environment and flags
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		fatal("Invalid configuration", err)
+	}
+	if err := logging.Setup(cfg.Log.Level, cfg.Log.Format); err != nil {
+		fatal("Invalid configuration", err)
+	}
+	slog.Info("Effective configuration", "config", cfg)
+	stopTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+	helper.ConfigurePagination(cfg.Pagination.DefaultSize, cfg.Pagination.MaxSize)
+	helper.SetAdminToken(cfg.Admin.Token)
+
 	// Initialize a new Fiber app
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		ErrorHandler: helper.ErrorHandler,
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
+	})
+
+	// Storage, in memory when storage is memory for local runs without a database
+	var store storage.Store
+	if cfg.Storage == "memory" {
+		store = memory.New()
+	} else {
+		pool, err := db.NewConnection(cfg.Database)
+		if err != nil {
+			fatal("Failed to connect to the database", err)
+		}
+		if cfg.Features.AutoMigrate {
+			applied, err := db.MigrateUp(context.Background(), pool)
+			if err != nil {
+				fatal("Failed to apply migrations", err)
+			}
+			slog.Info("Applied migrations", "count", len(applied))
+		}
+		prometheus.MustRegister(db.NewPoolCollector(pool))
+		store = postgres.New(pool)
+	}
+
+	// Background work runs until the requests are drained on shutdown
+	background, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	// Replay the event log and check it agrees with the tables the engine
+	// works from, then snapshot it periodically
+	state, err := eventlog.Recover(context.Background(), store)
+	if err != nil {
+		fatal("Failed to recover from the event log", err)
+	}
+	slog.Info("Replayed the event log, it agrees with the tables", "sequence", state.Sequence, "working_orders", len(state.Orders))
+	snapshotsDone := eventlog.StartSnapshots(background, store, cfg.EventLog.SnapshotInterval)
+
+	// Rate limiting per account and ip
+	limiterConfig := ratelimit.Config{
+		Orders:  ratelimit.Budget(cfg.RateLimit.Orders),
+		Cancels: ratelimit.Budget(cfg.RateLimit.Cancels),
+		Reads:   ratelimit.Budget(cfg.RateLimit.Reads),
+	}
+	if cfg.Features.OrderToTradeMonitor {
+		orderToTrade := ratelimit.OrderToTradeConfig(cfg.RateLimit.OrderToTrade)
+		limiterConfig.OrderToTrade = &orderToTrade
+	}
+	limiter := ratelimit.New(limiterConfig)
 
-	// DB connection
-	pool := db.NewConnection()
-	defer pool.Close()
+	// Pre-trade risk checks
+	riskConfig := risk.DefaultConfig()
+	if path := cfg.Matching.RiskConfig; path != "" {
+		if riskConfig, err = risk.LoadConfig(path); err != nil {
+			fatal("Failed to load risk config", err)
+		}
+	}
+	riskEngine := risk.NewEngine(riskConfig)
+
+	// Volatility halts per instrument
+	breaker := circuitbreaker.New(circuitbreaker.Config{
+		ThresholdPercent:  cfg.Matching.CircuitBreaker.ThresholdPercent,
+		Window:            cfg.Matching.CircuitBreaker.Window,
+		CoolOff:           cfg.Matching.CircuitBreaker.CoolOff,
+		ResumeWithAuction: cfg.Features.ResumeWithAuction,
+	})
+
+	// Balance conservation checks
+	reconciler := reconcile.New(store)
+	reconcilerDone := reconciler.Start(background, cfg.Reconciliation.Interval)
+
+	// Order placement shared by every transport, final orders are moved out
+	// of the live book periodically
+	orders := orderbook.NewService(store, limiter.OrderToTrade, riskEngine, breaker)
+	archiverDone := orderbook.StartArchiver(background, store, cfg.OrderHistory.ArchiveInterval, cfg.OrderHistory.BatchSize)
+
+	// Deposits and withdrawals go through approval and the custody rail
+	rail, err := custody.New(cfg.Funding.Rail)
+	if err != nil {
+		fatal("Failed to set up the custody rail", err)
+	}
+	thresholds, err := cfg.Funding.Thresholds()
+	if err != nil {
+		fatal("Invalid configuration", err)
+	}
+	fundings := funding.NewService(store, rail, thresholds)
+	fundingsDone := fundings.Start(background, cfg.Funding.ProcessInterval)
+
+	// Market data streams of the REST and gRPC APIs share one tail of the
+	// event log, stopped first on shutdown so the streams end
+	feedCtx, stopFeed := context.WithCancel(context.Background())
+	defer stopFeed()
+	feed, err := marketdata.New(feedCtx, store)
+	if err != nil {
+		fatal("Failed to start the market data feed", err)
+	}
+	feedDone := make(chan struct{})
+	go func() {
+		feed.Run(feedCtx)
+		close(feedDone)
+	}()
 
 	// Initialize the API routes
-	api.InitializeRoutes(app, pool)
+	drainer := shutdown.NewDrainer()
+	api.InitializeRoutes(app, store, drainer, limiter, orders, fundings, breaker, reconciler, feed, idempotency.New(cfg.HTTP.IdempotencyTTL), cfg.HTTP.SigningSecret)
+
+	// Start the server on the configured address until SIGINT or SIGTERM
+	signals, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	slog.Info("Listening", "address", cfg.HTTP.Address)
+	listenErr := make(chan error, 3)
+	go func() {
+		listenErr <- app.Listen(cfg.HTTP.Address, fiber.ListenConfig{DisableStartupMessage: true})
+	}()
+
+	// FIX order entry, only when an address is configured
+	var gateway *fix.Gateway
+	if cfg.Fix.Address != "" {
+		gateway = fix.New(cfg.Fix, store, orders)
+		slog.Info("Accepting FIX sessions", "address", cfg.Fix.Address, "comp_id", cfg.Fix.CompId)
+		go func() {
+			if err := gateway.Listen(cfg.Fix.Address); err != nil {
+				listenErr <- err
+			}
+		}()
+	}
+
+	// gRPC API, only when an address is configured
+	var grpcServer *rpc.Server
+	if cfg.GRPC.Address != "" {
+		grpcServer = rpc.New(store, orders, breaker, feed)
+		slog.Info("Listening for gRPC", "address", cfg.GRPC.Address)
+		go func() {
+			if err := grpcServer.Listen(cfg.GRPC.Address); err != nil {
+				listenErr <- err
+			}
+		}()
+	}
+	select {
+	case err := <-listenErr:
+		store.Close()
+		fatal("Failed to start server", err)
+	case <-signals.Done():
+	}
+	// A second signal kills the process right away
+	stopSignals()
+
+	// Refuse new requests, wait for the in-flight ones and their matching,
+	// end the market data streams, then stop listening and log the FIX
+	// sessions out
+	slog.Info("Shutting down, draining in-flight requests", "timeout", cfg.HTTP.ShutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := drainer.Drain(ctx); errors.Is(err, context.DeadlineExceeded) {
+		slog.Warn("Drain deadline exceeded, the remaining requests were canceled")
+	}
+	stopFeed()
+	<-feedDone
+	if err := app.ShutdownWithContext(ctx); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		slog.Error("Failed to stop server", "error", err)
+	}
+	if grpcServer != nil {
+		if err := grpcServer.Shutdown(ctx); err != nil {
+			slog.Error("Failed to stop the gRPC server", "error", err)
+		}
+	}
+	if gateway != nil {
+		if err := gateway.Shutdown(ctx); err != nil {
+			slog.Error("Failed to stop the FIX gateway", "error", err)
+		}
+	}
+
+	// Stop the background work and snapshot the event log so the next
+	// start replays as little as possible
+	stopBackground()
+	<-snapshotsDone
+	<-reconcilerDone
+	<-archiverDone
+	<-fundingsDone
+	snapshotCtx, cancelSnapshot := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelSnapshot()
+	if state, err := eventlog.TakeSnapshot(snapshotCtx, store); err != nil {
+		slog.Error("Failed to take event log snapshot", "error", err)
+	} else {
+		slog.Info("Took event log snapshot", "sequence", state.Sequence)
+	}
+
+	store.Close()
+
+	// Export the spans still buffered
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelTracing()
+	if err := stopTracing(tracingCtx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+	slog.Info("Shutdown complete")
+}
+
+// fatal logs the error and exits with status 1
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// loadCommandConfig loads the configuration of a subcommand from the file
+// and environment, subcommands take no configuration flags
+func loadCommandConfig() config.Config {
+	cfg, err := config.Load(nil)
+	if err != nil {
+		fatal("Invalid configuration", err)
+	}
+	if err := logging.Setup(cfg.Log.Level, cfg.Log.Format); err != nil {
+		fatal("Invalid configuration", err)
+	}
+	return cfg
+}
 
-	// Start the server on port 8000
-	log.Fatal(app.Listen(":8000"))
+// usage prints how to run a subcommand and exits with status 2
+func usage(text string) {
+	fmt.Fprintln(os.Stderr, text)
+	os.Exit(2)
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/JhonesBR/go-clob/internal/db"
+)
+
+const migrateUsage = "usage: main migrate up | down [steps] | status"
+
+// runMigrate handles the migrate subcommand against the configured database
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		usage(migrateUsage)
+	}
+
+	cfg := loadCommandConfig()
+	pool, err := db.NewConnection(cfg.Database)
+	if err != nil {
+		fatal("Failed to connect to the database", err)
+	}
+	defer pool.Close()
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := db.MigrateUp(ctx, pool)
+		for _, migration := range applied {
+			slog.Info("Applied migration", "version", migration.Version, "name", migration.Name)
+		}
+		if err != nil {
+			fatal("Failed to apply migrations", err)
+		}
+		if len(applied) == 0 {
+			slog.Info("Schema is up to date")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				usage(migrateUsage)
+			}
+		}
+		reverted, err := db.MigrateDown(ctx, pool, steps)
+		for _, migration := range reverted {
+			slog.Info("Reverted migration", "version", migration.Version, "name", migration.Name)
+		}
+		if err != nil {
+			fatal("Failed to revert migrations", err)
+		}
+	case "status":
+		statuses, err := db.MigrationStatuses(ctx, pool)
+		if err != nil {
+			fatal("Failed to read migrations", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = "applied at " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		usage(migrateUsage)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/JhonesBR/go-clob/internal/db"
+	"github.com/JhonesBR/go-clob/internal/reconcile"
+	"github.com/JhonesBR/go-clob/internal/storage/postgres"
+)
+
+// runReconcile handles the reconcile subcommand against the configured
+// database, printing the report and exiting with status 1 when it is not balanced
+func runReconcile() {
+	cfg := loadCommandConfig()
+	pool, err := db.NewConnection(cfg.Database)
+	if err != nil {
+		fatal("Failed to connect to the database", err)
+	}
+	store := postgres.New(pool)
+	defer store.Close()
+
+	report, err := reconcile.Run(context.Background(), store)
+	if err != nil {
+		fatal("Failed to reconcile balances", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		fatal("Failed to write the report", err)
+	}
+
+	if !report.Balanced {
+		store.Close()
+		os.Exit(1)
+	}
+}
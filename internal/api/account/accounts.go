@@ -0,0 +1,172 @@
+package account
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JhonesBR/go-clob/internal/audit"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Service manages accounts and their balances whichever transport the
+// request comes from, refusals are helper.Error
+type Service struct {
+	store storage.Store
+}
+
+func NewService(store storage.Store) *Service {
+	return &Service{store: store}
+}
+
+func (s *Service) Create(ctx context.Context, account CreateAccountSchema) (AccountShowSchema, error) {
+	if err := helper.ValidateInput(&account); err != nil {
+		return AccountShowSchema{}, helper.Invalid(err)
+	}
+
+	var created Account
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		created, err = tx.Accounts().Create(ctx, account.Name)
+		if err != nil {
+			return err
+		}
+		return audit.Record(ctx, tx, audit.Entry{
+			Actor:      audit.Account(created.Id),
+			Action:     audit.AccountCreated,
+			EntityType: "account",
+			EntityId:   created.Id.String(),
+			After:      created,
+		})
+	})
+	if err != nil {
+		return AccountShowSchema{}, err
+	}
+
+	return AccountShowSchema{
+		Id:       created.Id.String(),
+		Name:     created.Name,
+		Balances: []AccountBalanceSchema{},
+	}, nil
+}
+
+// AccountSorts are the sorts of the account list, by id by default
+var AccountSorts = []string{"id", "-id", "name", "-name"}
+
+// List returns a page of accounts with their balances, see helper.NewPage
+func (s *Service) List(ctx context.Context, page storage.Page) (helper.Pagination[AccountShowSchema], error) {
+	var pagination helper.Pagination[AccountShowSchema]
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		pagination, err = helper.Paginate(page, func(page storage.Page) ([]Account, error) {
+			return tx.Accounts().List(ctx, page)
+		}, func(account Account) (AccountShowSchema, error) {
+			return getAccountShow(ctx, tx, account)
+		})
+		return err
+	})
+	return pagination, err
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (AccountShowSchema, error) {
+	var accountShow AccountShowSchema
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		account, err := tx.Accounts().Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		accountShow, err = getAccountShow(ctx, tx, account)
+		return err
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		return AccountShowSchema{}, helper.NotFound("Account")
+	}
+	return accountShow, err
+}
+
+// Charge deposits an amount of an asset to the account
+func (s *Service) Charge(ctx context.Context, id uuid.UUID, charge UpdateBalanceSchema) (UpdateBalanceResponseSchema, error) {
+	return s.updateBalance(ctx, id, storage.Deposit, charge)
+}
+
+// Remove withdraws an amount of an asset from the account
+func (s *Service) Remove(ctx context.Context, id uuid.UUID, charge UpdateBalanceSchema) (UpdateBalanceResponseSchema, error) {
+	return s.updateBalance(ctx, id, storage.Withdrawal, charge)
+}
+
+func (s *Service) updateBalance(ctx context.Context, id uuid.UUID, kind storage.MovementKind, charge UpdateBalanceSchema) (UpdateBalanceResponseSchema, error) {
+	if err := helper.ValidateInput(&charge); err != nil {
+		return UpdateBalanceResponseSchema{}, helper.Invalid(err)
+	}
+	if !charge.Amount.IsPositive() {
+		return UpdateBalanceResponseSchema{}, helper.Invalid(errors.New("amount must be positive"))
+	}
+
+	// Transaction to ensure correct update on race conditions
+	var balance *decimal.Decimal
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		if _, err := tx.Accounts().Get(ctx, id); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return helper.NotFound("Account")
+			}
+			return err
+		}
+
+		// Get account balance
+		var assetId *uuid.UUID
+		var err error
+		balance, assetId, err = GetAccountBalance(ctx, tx, id, charge.AssetCode, nil)
+		if err != nil {
+			return err
+		}
+		if balance == nil {
+			created, err := CreateAccountBalanceForAccount(ctx, tx, id, *assetId)
+			if err != nil {
+				return err
+			}
+			balance = &created
+		}
+
+		previous := *balance
+		action := audit.BalanceCharged
+		if kind == storage.Deposit {
+			*balance = balance.Add(*charge.Amount)
+		} else {
+			*balance = balance.Sub(*charge.Amount)
+			action = audit.BalanceRemoved
+		}
+
+		// Record the movement so reconciliation knows what entered the exchange
+		_, err = tx.Movements().Create(ctx, storage.Movement{
+			AccountId: id,
+			AssetId:   *assetId,
+			Kind:      kind,
+			Amount:    *charge.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := UpdateAccountBalance(ctx, tx, id, *balance, *assetId); err != nil {
+			return err
+		}
+		return audit.Record(ctx, tx, audit.Entry{
+			Actor:      audit.Account(id),
+			Action:     action,
+			EntityType: "balance",
+			EntityId:   id.String() + "/" + *charge.AssetCode,
+			Before:     map[string]any{"asset_code": charge.AssetCode, "balance": previous},
+			After:      map[string]any{"asset_code": charge.AssetCode, "balance": *balance},
+		})
+	})
+	if err != nil {
+		return UpdateBalanceResponseSchema{}, err
+	}
+
+	return UpdateBalanceResponseSchema{
+		Balance:   balance,
+		AssetCode: charge.AssetCode,
+	}, nil
+}
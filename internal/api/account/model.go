@@ -1,26 +1,11 @@
 package account
 
-import (
-	"github.com/google/uuid"
-	"github.com/shopspring/decimal"
-)
+import "github.com/JhonesBR/go-clob/internal/storage"
 
 // Representative (schemas will be used for validation and documentation)
 
-type Asset struct {
-	Id   uuid.UUID `json:"id"`
-	Code string    `json:"code"`
-	Name string    `json:"name"`
-}
+type Asset = storage.Asset
 
-type Account struct {
-	Id   uuid.UUID `json:"id"`
-	Name string    `json:"name"`
-}
+type Account = storage.Account
 
-type AccountBalance struct {
-	Id        uuid.UUID       `json:"id"`
-	AccountId uuid.UUID       `json:"account_id"`
-	AssetId   uuid.UUID       `json:"asset_id"`
-	Balance   decimal.Decimal `json:"balance"`
-}
+type AccountBalance = storage.AccountBalance
@@ -1,16 +1,56 @@
 package account
 
 import (
-	"context"
-
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/openapi"
+	"github.com/JhonesBR/go-clob/internal/storage"
 	"github.com/gofiber/fiber/v3"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func InitializeRoutes(app *fiber.App, db *pgxpool.Pool) {
-	app.Get("/v1/accounts", GetAccountsHandler(db))
-	app.Post("/v1/accounts", CreateNewAccountHandler(db))
-	app.Get("/v1/accounts/:id", GetAccountByIDHandler(db))
-	app.Post("/v1/accounts/:id/charge", UpdateAccountBalanceHandler(context.Background(), db, "charge"))
-	app.Post("/v1/accounts/:id/remove", UpdateAccountBalanceHandler(context.Background(), db, "remove"))
+func InitializeRoutes(app *fiber.App, store storage.Store) {
+	service := NewService(store)
+	app.Get("/v1/accounts", GetAccountsHandler(service))
+	app.Post("/v1/accounts", CreateNewAccountHandler(service))
+	app.Get("/v1/accounts/:id", GetAccountByIDHandler(service))
+	app.Post("/v1/accounts/:id/charge", UpdateAccountBalanceHandler(service, "charge"))
+	app.Post("/v1/accounts/:id/remove", UpdateAccountBalanceHandler(service, "remove"))
+}
+
+// Operations documents the routes in the OpenAPI document
+var Operations = []openapi.Operation{
+	{
+		Method: fiber.MethodGet, Path: "/v1/accounts", Tag: "Accounts",
+		Summary:  "List accounts with their balances",
+		Query:    openapi.PageParameters(AccountSorts...),
+		Response: helper.Pagination[AccountShowSchema]{},
+		Errors:   []int{fiber.StatusBadRequest},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/accounts", Tag: "Accounts",
+		Summary:  "Create an account",
+		Body:     CreateAccountSchema{},
+		Status:   fiber.StatusCreated,
+		Response: CreateAccountResponseSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnprocessableEntity},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/accounts/:id", Tag: "Accounts",
+		Summary:  "Get an account with its balances",
+		Response: AccountShowSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/accounts/:id/charge", Tag: "Accounts",
+		Summary:  "Deposit an amount of an asset right away, deprecated in favor of /v1/accounts/:id/deposits",
+		Body:     UpdateBalanceSchema{},
+		Response: UpdateBalanceResponseSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusUnprocessableEntity},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/accounts/:id/remove", Tag: "Accounts",
+		Summary:  "Withdraw an amount of an asset right away, deprecated in favor of /v1/accounts/:id/withdrawals",
+		Body:     UpdateBalanceSchema{},
+		Response: UpdateBalanceResponseSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusUnprocessableEntity},
+	},
 }
@@ -2,244 +2,163 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/JhonesBR/go-clob/internal/eventlog"
 	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/tracing"
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 )
 
-func CreateNewAccountHandler(db *pgxpool.Pool) fiber.Handler {
+func CreateNewAccountHandler(service *Service) fiber.Handler {
 	return func(c fiber.Ctx) error {
 		// Parse create account schema
 		var account = CreateAccountSchema{}
 		if err := c.Bind().Body(&account); err != nil {
-			return fiber.ErrBadRequest
-		}
-		if err := helper.ValidateInput(&account); err != nil {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
-				"error": err.Error(),
-			})
+			return helper.InvalidRequest("Malformed request body")
 		}
 
-		// Create a new account at database
-		var accountId uuid.UUID
-		err := db.QueryRow(context.Background(), "INSERT INTO accounts (name) VALUES ($1) RETURNING id", account.Name).Scan(&accountId)
+		created, err := service.Create(helper.Context(c), account)
 		if err != nil {
 			return err
 		}
 
-		return c.Status(fiber.StatusCreated).JSON(CreateAccountResponseSchema{
-			Id:       accountId.String(),
-			Name:     account.Name,
-			Balances: []AccountBalanceSchema{},
-		})
+		return c.Status(fiber.StatusCreated).JSON(created)
 	}
 }
 
-func GetAccountsHandler(db *pgxpool.Pool) fiber.Handler {
+func GetAccountsHandler(service *Service) fiber.Handler {
 	return func(c fiber.Ctx) error {
-		// Get pagination
-		pagination := helper.GetPagination[AccountShowSchema](c)
-
-		// Get total
-		countQuery := "SELECT COUNT(*) FROM accounts"
-		var total int
-		if err := db.QueryRow(context.Background(), countQuery).Scan(&total); err != nil {
-			return err
-		}
-		pagination.Total = &total
-
-		// Retrieve accounts
-		query := fmt.Sprintf(
-			`SELECT acc.id, acc.name, ab.balance, assets.code, assets.id
-			 FROM accounts acc
-			 LEFT JOIN account_balances ab ON acc.id = ab.account_id
-			 LEFT JOIN assets ON ab.asset_id = assets.id
-			 LIMIT %d OFFSET %d`,
-			pagination.Size,
-			(pagination.Page-1)*pagination.Size,
-		)
-		rows, err := db.Query(context.Background(), query)
+		page, err := helper.GetPage(c, AccountSorts...)
 		if err != nil {
 			return err
 		}
-		defer rows.Close()
-
-		var accounts = make(map[string]AccountShowSchema)
-		for rows.Next() {
-			var account AccountShowSchema
-			var balance *decimal.Decimal
-			var assetCode *string
-			var assetId *uuid.UUID
-			if err := rows.Scan(&account.Id, &account.Name, &balance, &assetCode, &assetId); err != nil {
-				return err
-			}
 
-			if _, ok := accounts[account.Id]; !ok {
-				accounts[account.Id] = AccountShowSchema{
-					Id:       account.Id,
-					Name:     account.Name,
-					Balances: make([]AccountBalanceSchema, 0),
-				}
-			}
-
-			if balance != nil && assetCode != nil {
-				accountData := accounts[account.Id]
-				accountData.Balances = append(accountData.Balances, AccountBalanceSchema{
-					AssetId:   assetId,
-					Balance:   balance,
-					AssetCode: assetCode,
-				})
-				accounts[account.Id] = accountData
-			}
+		pagination, err := service.List(helper.Context(c), page)
+		if err != nil {
+			return err
 		}
 
-		pagination.Items = helper.MapToSlice(accounts)
-
 		return c.JSON(pagination)
 	}
 }
 
-func GetAccountByIDHandler(db *pgxpool.Pool) fiber.Handler {
+func GetAccountByIDHandler(service *Service) fiber.Handler {
 	return func(c fiber.Ctx) error {
-		id := c.Params("id")
-		if id == "" {
-			return fiber.ErrBadRequest
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
 		}
 
-		// Get account
-		var account AccountShowSchema
-		query := fmt.Sprintf(`
-			SELECT acc.id, acc.name, ab.balance, assets.code, assets.id
-			 FROM accounts acc
-			 LEFT JOIN account_balances ab ON acc.id = ab.account_id
-			 LEFT JOIN assets ON ab.asset_id = assets.id
-			 WHERE acc.id = '%s'
-		`, id)
-
-		rows, err := db.Query(context.Background(), query)
+		accountShow, err := service.Get(helper.Context(c), id)
 		if err != nil {
 			return err
 		}
-		defer rows.Close()
-
-		account.Balances = make([]AccountBalanceSchema, 0)
-		for rows.Next() {
-			var balance AccountBalanceSchema
-			if err := rows.Scan(&account.Id, &account.Name, &balance.Balance, &balance.AssetCode, &balance.AssetId); err != nil {
-				return err
-			}
 
-			if balance.Balance != nil {
-				account.Balances = append(account.Balances, balance)
-			}
-		}
-
-		return c.JSON(account)
+		return c.JSON(accountShow)
 	}
 }
 
-func UpdateAccountBalanceHandler(ctx context.Context, db *pgxpool.Pool, operation string) fiber.Handler {
+func UpdateAccountBalanceHandler(service *Service, operation string) fiber.Handler {
+	update := service.Charge
+	if operation == "remove" {
+		update = service.Remove
+	}
 	return func(c fiber.Ctx) error {
-		id := c.Params("id")
-		if id == "" {
-			return fiber.ErrBadRequest
-		}
-		uuidId := uuid.MustParse(id)
-
-		// Transaction to ensure correct update on race conditions
-		tx, err := db.BeginTx(ctx, pgx.TxOptions{})
+		id, err := uuid.Parse(c.Params("id"))
 		if err != nil {
-			return err
+			return helper.InvalidId("id")
 		}
-		defer tx.Rollback(ctx)
 
 		// Charge or remove balance
 		var charge UpdateBalanceSchema
 		if err := c.Bind().Body(&charge); err != nil {
-			return fiber.ErrBadRequest
-		}
-		if err := helper.ValidateInput(&charge); err != nil {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
-				"error": err.Error(),
-			})
+			return helper.InvalidRequest("Malformed request body")
 		}
 
-		// Get account balance
-		balance, assetId, err := GetAccountBalance(ctx, tx, uuidId, charge.AssetCode, nil)
-		if err != nil {
-			if err == pgx.ErrNoRows {
-				CreateAccountBalanceForAccount(ctx, tx, uuidId, *assetId)
-				balance = new(decimal.Decimal)
-				*balance = decimal.NewFromInt(0)
-			} else {
-				return err
-			}
-		} else if balance == nil {
-			CreateAccountBalanceForAccount(ctx, tx, uuidId, *assetId)
-			balance = new(decimal.Decimal)
-			*balance = decimal.NewFromInt(0)
-		}
-
-		switch operation {
-		case "charge":
-			*balance = balance.Add(*charge.Amount)
-		case "remove":
-			*balance = balance.Sub(*charge.Amount)
-		}
-
-		err = UpdateAccountBalance(ctx, tx, uuidId, *balance, *assetId)
+		balance, err := update(helper.Context(c), id, charge)
 		if err != nil {
 			return err
 		}
 
-		// Commit transaction
-		if err := tx.Commit(ctx); err != nil {
-			return err
-		}
+		return c.JSON(balance)
+	}
+}
 
-		return c.JSON(UpdateBalanceResponseSchema{
-			Balance:   balance,
-			AssetCode: charge.AssetCode,
+func getAccountShow(ctx context.Context, tx storage.Tx, account Account) (AccountShowSchema, error) {
+	balances, err := tx.Balances().ListByAccount(ctx, account.Id)
+	if err != nil {
+		return AccountShowSchema{}, err
+	}
+
+	accountShow := AccountShowSchema{
+		Id:       account.Id.String(),
+		Name:     account.Name,
+		Balances: make([]AccountBalanceSchema, 0, len(balances)),
+	}
+	for _, balance := range balances {
+		accountShow.Balances = append(accountShow.Balances, AccountBalanceSchema{
+			AssetId:   &balance.AssetId,
+			Balance:   &balance.Balance,
+			AssetCode: &balance.AssetCode,
 		})
 	}
+	return accountShow, nil
 }
 
-func GetAccountBalance(ctx context.Context, tx pgx.Tx, accountId uuid.UUID, assetCode *string, assetId *uuid.UUID) (*decimal.Decimal, *uuid.UUID, error) {
+// GetAccountBalance returns a nil balance when the account has no balance of
+// the asset, found by id or else by code
+func GetAccountBalance(ctx context.Context, tx storage.Tx, accountId uuid.UUID, assetCode *string, assetId *uuid.UUID) (_ *decimal.Decimal, _ *uuid.UUID, err error) {
+	ctx, span := tracing.Start(ctx, "account.GetAccountBalance")
+	defer func() { tracing.End(span, err) }()
+
 	if assetId == nil {
-		err := tx.QueryRow(ctx, "SELECT id FROM assets WHERE code = $1", assetCode).Scan(&assetId)
+		asset, err := tx.Assets().GetByCode(ctx, *assetCode)
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				return &decimal.Decimal{}, nil, fmt.Errorf("asset not found")
+			if errors.Is(err, storage.ErrNotFound) {
+				return &decimal.Decimal{}, nil, helper.NotFound("Asset")
 			}
 			return &decimal.Decimal{}, nil, err
 		}
+		assetId = &asset.Id
 	}
 
-	var balance *decimal.Decimal
-	query := `
-		SELECT ab.balance
-		FROM assets
-		LEFT OUTER JOIN account_balances ab ON ab.asset_id = assets.id AND ab.account_id = $1
-		WHERE assets.id = $2
-	`
-	err := tx.QueryRow(ctx, query, accountId, assetId).Scan(&balance)
+	balance, err := tx.Balances().Get(ctx, accountId, *assetId)
 	if err != nil {
 		return &decimal.Decimal{}, assetId, err
 	}
 	return balance, assetId, nil
 }
 
-func UpdateAccountBalance(ctx context.Context, tx pgx.Tx, id uuid.UUID, newBalance decimal.Decimal, assetId uuid.UUID) error {
-	_, err := tx.Exec(ctx, "UPDATE account_balances SET balance = $1 WHERE account_id = $2 AND asset_id = $3", newBalance, id, assetId)
-	return err
+func UpdateAccountBalance(ctx context.Context, tx storage.Tx, id uuid.UUID, newBalance decimal.Decimal, assetId uuid.UUID) error {
+	if err := tx.Balances().Update(ctx, id, assetId, newBalance); err != nil {
+		return err
+	}
+
+	return eventlog.Append(ctx, tx, eventlog.BalanceChanged, id, eventlog.BalanceChangedPayload{
+		AccountId: id,
+		AssetId:   assetId,
+		Balance:   newBalance,
+	})
 }
 
-func CreateAccountBalanceForAccount(ctx context.Context, tx pgx.Tx, accountId, assetId uuid.UUID) error {
-	_, err := tx.Exec(ctx, "INSERT INTO account_balances (account_id, asset_id, balance) VALUES ($1, $2, 0)", accountId, assetId)
-	return err
+// CreateAccountBalanceForAccount adds a zero balance of the asset to the
+// account and returns its balance read again, locked: a unit of work creating
+// it at the same time may have credited it first
+func CreateAccountBalanceForAccount(ctx context.Context, tx storage.Tx, accountId, assetId uuid.UUID) (decimal.Decimal, error) {
+	if err := tx.Balances().Create(ctx, accountId, assetId); err != nil {
+		return decimal.Decimal{}, err
+	}
+	balance, err := tx.Balances().Get(ctx, accountId, assetId)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	if balance == nil {
+		return decimal.Decimal{}, fmt.Errorf("balance of asset %s for account %s was not created", assetId, accountId)
+	}
+	return *balance, nil
 }
@@ -0,0 +1,28 @@
+package auditlog
+
+import (
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/openapi"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+)
+
+func InitializeRoutes(app *fiber.App, store storage.Store) {
+	app.Get("/v1/admin/audit", helper.AdminAuth(), GetAuditLogHandler(store))
+}
+
+// Operations documents the routes in the OpenAPI document
+var Operations = []openapi.Operation{
+	{
+		Method: fiber.MethodGet, Path: "/v1/admin/audit", Tag: "Admin", Admin: true,
+		Summary: "List the audit log, newest first by default",
+		Query: append([]openapi.Parameter{
+			{Name: "actor", Type: ""},
+			{Name: "action", Type: ""},
+			{Name: "entity_type", Type: ""},
+			{Name: "entity_id", Type: ""},
+		}, openapi.PageParameters(AuditSorts...)...),
+		Response: helper.Pagination[AuditEntryShowSchema]{},
+		Errors:   []int{fiber.StatusBadRequest},
+	},
+}
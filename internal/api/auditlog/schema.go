@@ -0,0 +1,18 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEntryShowSchema struct {
+	Id         int64           `json:"id"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityId   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	RequestId  string          `json:"request_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
@@ -0,0 +1,74 @@
+package auditlog
+
+import (
+	"encoding/json"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+)
+
+// AuditSorts are the sorts of the audit log, newest first by default
+var AuditSorts = []string{"-id", "id"}
+
+// GetAuditLogHandler lists the audit log, newest first by default, filtered by actor,
+// action and entity
+func GetAuditLogHandler(store storage.Store) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		ctx := helper.Context(c)
+
+		page, err := helper.GetPage(c, AuditSorts...)
+		if err != nil {
+			return err
+		}
+
+		// Retrieve filters
+		filter := storage.AuditFilter{
+			Actor:      query(c, "actor"),
+			Action:     query(c, "action"),
+			EntityType: query(c, "entity_type"),
+			EntityId:   query(c, "entity_id"),
+		}
+
+		var pagination helper.Pagination[AuditEntryShowSchema]
+		err = store.WithTx(ctx, func(tx storage.Tx) error {
+			pagination, err = helper.Paginate(page, func(page storage.Page) ([]storage.AuditEntry, error) {
+				return tx.Audit().List(ctx, filter, page)
+			}, func(entry storage.AuditEntry) (AuditEntryShowSchema, error) {
+				return AuditEntryShowSchema{
+					Id:         entry.Id,
+					Actor:      entry.Actor,
+					Action:     entry.Action,
+					EntityType: entry.EntityType,
+					EntityId:   entry.EntityId,
+					Before:     raw(entry.Before),
+					After:      raw(entry.After),
+					RequestId:  entry.RequestId,
+					CreatedAt:  entry.CreatedAt,
+				}, nil
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(pagination)
+	}
+}
+
+func query(c fiber.Ctx, key string) *string {
+	value := c.Query(key)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// raw renders a stored value as is, null when there is none
+func raw(value []byte) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+	return value
+}
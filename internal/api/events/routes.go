@@ -0,0 +1,44 @@
+package events
+
+import (
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/openapi"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+)
+
+func InitializeRoutes(app *fiber.App, store storage.Store) {
+	app.Get("/v1/admin/events", helper.AdminAuth(), GetEventsHandler(store))
+	app.Get("/v1/admin/events/state", helper.AdminAuth(), GetStateHandler(store))
+	app.Post("/v1/admin/events/snapshots", helper.AdminAuth(), TakeSnapshotHandler(store))
+}
+
+// Operations documents the routes in the OpenAPI document
+var Operations = []openapi.Operation{
+	{
+		Method: fiber.MethodGet, Path: "/v1/admin/events", Tag: "Admin", Admin: true,
+		Summary: "List the events of the log after a sequence",
+		Query: []openapi.Parameter{
+			{Name: "after", Description: "Sequence to list the events after, 0 by default", Type: int64(0)},
+			{Name: "limit", Description: "Number of events, 100 by default and at most 1000", Type: 0},
+		},
+		Response: EventListSchema{},
+		Errors:   []int{fiber.StatusBadRequest},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/admin/events/state", Tag: "Admin", Admin: true,
+		Summary: "Rebuild the book and balances from the event log",
+		Query: []openapi.Parameter{
+			{Name: "sequence", Description: "Sequence to rebuild the state at, the last one by default", Type: int64(0)},
+		},
+		Response: eventlog.State{},
+		Errors:   []int{fiber.StatusBadRequest},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/admin/events/snapshots", Tag: "Admin", Admin: true,
+		Summary:  "Snapshot the state rebuilt from the event log",
+		Status:   fiber.StatusCreated,
+		Response: SnapshotSchema{},
+	},
+}
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/JhonesBR/go-clob/internal/eventlog"
+
+type EventListSchema struct {
+	After int64            `json:"after"`
+	Limit int              `json:"limit"`
+	Items []eventlog.Event `json:"items"`
+}
+
+type SnapshotSchema struct {
+	Sequence int64 `json:"sequence"`
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"strconv"
+
+	"github.com/JhonesBR/go-clob/internal/audit"
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+)
+
+func GetEventsHandler(store storage.Store) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		ctx := helper.Context(c)
+
+		after, err := strconv.ParseInt(c.Query("after", "0"), 10, 64)
+		if err != nil {
+			return helper.InvalidRequest("after must be an integer")
+		}
+
+		limit, _ := strconv.Atoi(c.Query("limit", "100"))
+		if limit < 1 {
+			limit = 1
+		} else if limit > 1000 {
+			limit = 1000
+		}
+
+		events, err := eventlog.ListEvents(ctx, store, after, limit)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(EventListSchema{
+			After: after,
+			Limit: limit,
+			Items: events,
+		})
+	}
+}
+
+// GetStateHandler rebuilds the book and balances at the given sequence, or the
+// current state when no sequence is given
+func GetStateHandler(store storage.Store) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		ctx := helper.Context(c)
+
+		sequence, err := strconv.ParseInt(c.Query("sequence", "-1"), 10, 64)
+		if err != nil {
+			return helper.InvalidRequest("sequence must be an integer")
+		}
+
+		state, err := eventlog.StateAt(ctx, store, sequence)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(state)
+	}
+}
+
+func TakeSnapshotHandler(store storage.Store) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		ctx := helper.Context(c)
+
+		state, err := eventlog.TakeSnapshot(ctx, store)
+		if err != nil {
+			return err
+		}
+		err = audit.RecordCommitted(ctx, store, audit.Entry{
+			Actor:      audit.Admin,
+			Action:     audit.SnapshotTaken,
+			EntityType: "snapshot",
+			EntityId:   strconv.FormatInt(state.Sequence, 10),
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(SnapshotSchema{
+			Sequence: state.Sequence,
+		})
+	}
+}
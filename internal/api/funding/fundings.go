@@ -0,0 +1,237 @@
+package funding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/audit"
+	"github.com/JhonesBR/go-clob/internal/custody"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// FundingSorts are the sorts of the funding list, oldest first by default
+var FundingSorts = []string{"created_at", "-created_at"}
+
+// FundingStatuses are every status, in lifecycle order
+var FundingStatuses = []FundingStatus{Requested, PendingApproval, Approved, Processing, Completed, Rejected, Failed}
+
+// Service takes deposits and withdrawals through screening, approval and the
+// custody rail, refusals are helper.Error. Fundings of an asset at or above
+// its threshold wait for an operator to approve them.
+type Service struct {
+	store      storage.Store
+	rail       custody.Rail
+	thresholds map[string]decimal.Decimal
+}
+
+func NewService(store storage.Store, rail custody.Rail, thresholds map[string]decimal.Decimal) *Service {
+	return &Service{store: store, rail: rail, thresholds: thresholds}
+}
+
+// Deposit requests a deposit to the account, credited once the rail collects it
+func (s *Service) Deposit(ctx context.Context, accountId uuid.UUID, request RequestFundingSchema) (FundingShowSchema, error) {
+	return s.request(ctx, accountId, Deposit, request)
+}
+
+// Withdraw requests a withdrawal from the account, its amount is held from
+// the balance until it is paid out, rejected or fails
+func (s *Service) Withdraw(ctx context.Context, accountId uuid.UUID, request RequestFundingSchema) (FundingShowSchema, error) {
+	return s.request(ctx, accountId, Withdrawal, request)
+}
+
+func (s *Service) request(ctx context.Context, accountId uuid.UUID, kind FundingKind, request RequestFundingSchema) (FundingShowSchema, error) {
+	if err := helper.ValidateInput(&request); err != nil {
+		return FundingShowSchema{}, helper.Invalid(err)
+	}
+	if !request.Amount.IsPositive() {
+		return FundingShowSchema{}, helper.Invalid(errors.New("amount must be positive"))
+	}
+
+	var created Funding
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		if _, err := tx.Accounts().Get(ctx, accountId); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return helper.NotFound("Account")
+			}
+			return err
+		}
+		asset, err := tx.Assets().GetByCode(ctx, *request.AssetCode)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return helper.NotFound("Asset")
+			}
+			return err
+		}
+
+		// Hold the withdrawn amount so it cannot be traded while the withdrawal is in flight
+		if kind == Withdrawal {
+			balance, _, err := account.GetAccountBalance(ctx, tx, accountId, nil, &asset.Id)
+			if err != nil {
+				return err
+			}
+			if balance == nil || balance.LessThan(*request.Amount) {
+				return helper.Error{Status: fiber.StatusPaymentRequired, Code: helper.CodeInsufficientFunds, Message: "Insufficient funds"}
+			}
+			if err := account.UpdateAccountBalance(ctx, tx, accountId, balance.Sub(*request.Amount), asset.Id); err != nil {
+				return err
+			}
+		}
+
+		created, err = tx.Fundings().Create(ctx, Funding{
+			AccountId: accountId,
+			AssetId:   asset.Id,
+			Kind:      kind,
+			Status:    Requested,
+			Amount:    *request.Amount,
+		})
+		if err != nil {
+			return err
+		}
+		// A hold moves funds from the balance to the withdrawal, reconciliation
+		// must see both sides at once
+		if kind == Withdrawal {
+			if err := tx.Events().Lock(ctx); err != nil {
+				return err
+			}
+		}
+		return audit.Record(ctx, tx, audit.Entry{
+			Actor:      audit.Account(accountId),
+			Action:     audit.FundingRequested,
+			EntityType: "funding",
+			EntityId:   created.Id.String(),
+			After:      created,
+		})
+	})
+	if err != nil {
+		return FundingShowSchema{}, err
+	}
+	return NewFundingShowSchema(created), nil
+}
+
+// List returns a page of the fundings matching the filter, see helper.NewPage
+func (s *Service) List(ctx context.Context, filter storage.FundingFilter, page storage.Page) (helper.Pagination[FundingShowSchema], error) {
+	var pagination helper.Pagination[FundingShowSchema]
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		pagination, err = helper.Paginate(page, func(page storage.Page) ([]Funding, error) {
+			return tx.Fundings().List(ctx, filter, page)
+		}, func(funding Funding) (FundingShowSchema, error) {
+			return NewFundingShowSchema(funding), nil
+		})
+		return err
+	})
+	return pagination, err
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (FundingShowSchema, error) {
+	var funding Funding
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		funding, err = tx.Fundings().Get(ctx, id)
+		return err
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		return FundingShowSchema{}, helper.NotFound("Funding")
+	}
+	if err != nil {
+		return FundingShowSchema{}, err
+	}
+	return NewFundingShowSchema(funding), nil
+}
+
+// Approve lets a funding waiting for an operator go to the rail
+func (s *Service) Approve(ctx context.Context, id uuid.UUID, approval ApproveFundingSchema) (FundingShowSchema, error) {
+	if err := helper.ValidateInput(&approval); err != nil {
+		return FundingShowSchema{}, helper.Invalid(err)
+	}
+	return s.review(ctx, id, audit.FundingApproved, func(funding *Funding) {
+		funding.Status = Approved
+		funding.ReviewedBy = approval.Operator
+	})
+}
+
+// Reject refuses a funding waiting for an operator, a withdrawal gets its
+// amount back on the balance
+func (s *Service) Reject(ctx context.Context, id uuid.UUID, rejection RejectFundingSchema) (FundingShowSchema, error) {
+	if err := helper.ValidateInput(&rejection); err != nil {
+		return FundingShowSchema{}, helper.Invalid(err)
+	}
+	return s.review(ctx, id, audit.FundingRejected, func(funding *Funding) {
+		funding.Status = Rejected
+		funding.ReviewedBy = rejection.Operator
+		funding.Reason = rejection.Reason
+	})
+}
+
+// review applies the decision of an operator to a funding pending approval
+func (s *Service) review(ctx context.Context, id uuid.UUID, action string, decide func(*Funding)) (FundingShowSchema, error) {
+	var reviewed Funding
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		funding, err := tx.Fundings().Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return helper.NotFound("Funding")
+			}
+			return err
+		}
+		if funding.Status != PendingApproval {
+			return helper.Error{
+				Status:  fiber.StatusConflict,
+				Code:    helper.CodeInvalidStatusChange,
+				Message: fmt.Sprintf("only fundings pending approval can be reviewed, this one is %s", funding.Status),
+			}
+		}
+
+		before := funding
+		decide(&funding)
+		if reviewed, err = tx.Fundings().Update(ctx, funding); err != nil {
+			return err
+		}
+		if reviewed.Status == Rejected && reviewed.Kind == Withdrawal {
+			if err := credit(ctx, tx, reviewed); err != nil {
+				return err
+			}
+			if err := tx.Events().Lock(ctx); err != nil {
+				return err
+			}
+		}
+		return audit.Record(ctx, tx, audit.Entry{
+			Actor:      audit.Admin,
+			Action:     action,
+			EntityType: "funding",
+			EntityId:   reviewed.Id.String(),
+			Before:     before,
+			After:      reviewed,
+		})
+	})
+	if err != nil {
+		return FundingShowSchema{}, err
+	}
+	if reviewed.Status == Rejected {
+		fundingsFinished.WithLabelValues(string(reviewed.Kind), string(reviewed.Status)).Inc()
+	}
+	return NewFundingShowSchema(reviewed), nil
+}
+
+// credit adds the amount of the funding to the balance of its account, the
+// deposit collected or the withdrawal released
+func credit(ctx context.Context, tx storage.Tx, funding Funding) error {
+	balance, _, err := account.GetAccountBalance(ctx, tx, funding.AccountId, nil, &funding.AssetId)
+	if err != nil {
+		return err
+	}
+	if balance == nil {
+		created, err := account.CreateAccountBalanceForAccount(ctx, tx, funding.AccountId, funding.AssetId)
+		if err != nil {
+			return err
+		}
+		balance = &created
+	}
+	return account.UpdateAccountBalance(ctx, tx, funding.AccountId, balance.Add(funding.Amount), funding.AssetId)
+}
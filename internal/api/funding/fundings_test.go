@@ -0,0 +1,149 @@
+package funding_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/api/funding"
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/custody"
+	"github.com/JhonesBR/go-clob/internal/reconcile"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/storage/postgres/postgrestest"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TestWithdrawalRacingOrder requests a withdrawal and places a sell order
+// at the same time, each needing most of the balance: only one of them may
+// take it
+func TestWithdrawalRacingOrder(t *testing.T) {
+	store := postgrestest.Store(t)
+	ctx := context.Background()
+	accounts := account.NewService(store)
+	fundings := funding.NewService(store, custody.NewFake(), nil)
+	orders := orderbook.NewService(store, nil, risk.NewEngine(risk.Config{}), circuitbreaker.New(circuitbreaker.DefaultConfig()))
+
+	btc := "BTC"
+	amount := decimal.NewFromInt(60)
+	for range 20 {
+		var accountId uuid.UUID
+		err := store.WithTx(ctx, func(tx storage.Tx) error {
+			created, err := tx.Accounts().Create(ctx, "race-"+uuid.NewString())
+			accountId = created.Id
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		hundred := decimal.NewFromInt(100)
+		if _, err := accounts.Charge(ctx, accountId, account.UpdateBalanceSchema{AssetCode: &btc, Amount: &hundred}); err != nil {
+			t.Fatal(err)
+		}
+
+		var wg sync.WaitGroup
+		var withdrawErr, placeErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, withdrawErr = fundings.Withdraw(ctx, accountId, funding.RequestFundingSchema{AssetCode: &btc, Amount: &amount})
+		}()
+		go func() {
+			defer wg.Done()
+			_, placeErr = orders.Place(ctx, orderbook.PlaceOrderSchema{
+				AccountId: accountId,
+				AssetCode: btc,
+				Quantity:  amount,
+				Price:     decimal.NewFromInt(1_000_000_000),
+				OrderType: orderbook.Sell,
+			}, nil)
+		}()
+		wg.Wait()
+
+		if (withdrawErr == nil) == (placeErr == nil) {
+			t.Fatalf("withdrawal got %v and order %v, want exactly one to succeed", withdrawErr, placeErr)
+		}
+		var balance *decimal.Decimal
+		err = store.WithTx(ctx, func(tx storage.Tx) error {
+			var err error
+			balance, _, err = account.GetAccountBalance(ctx, tx, accountId, &btc, nil)
+			return err
+		})
+		if err != nil || balance == nil || !balance.Equal(decimal.NewFromInt(40)) {
+			t.Fatalf("BTC balance is %v, %v, want 40", balance, err)
+		}
+	}
+}
+
+// TestReconcileDuringWithdrawals reconciles while withdrawals are held,
+// completed, failed and rejected: every report must be balanced
+func TestReconcileDuringWithdrawals(t *testing.T) {
+	store := postgrestest.Store(t)
+	ctx := context.Background()
+	accounts := account.NewService(store)
+	rail := custody.NewFake()
+	btc := "BTC"
+	fundings := funding.NewService(store, rail, map[string]decimal.Decimal{btc: decimal.NewFromInt(5)})
+
+	var accountId uuid.UUID
+	err := store.WithTx(ctx, func(tx storage.Tx) error {
+		created, err := tx.Accounts().Create(ctx, "reconcile-"+uuid.NewString())
+		accountId = created.Id
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	thousand := decimal.NewFromInt(1000)
+	if _, err := accounts.Charge(ctx, accountId, account.UpdateBalanceSchema{AssetCode: &btc, Amount: &thousand}); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			report, err := reconcile.Run(ctx, store)
+			if err != nil || !report.Balanced {
+				t.Errorf("reconciliation got %+v, %v", report.Discrepancies, err)
+				return
+			}
+		}
+	}()
+
+	for i := range 20 {
+		// Large withdrawals wait for an operator, who rejects every other one
+		amount := decimal.NewFromInt(int64(1 + i%2*5))
+		created, err := fundings.Withdraw(ctx, accountId, funding.RequestFundingSchema{AssetCode: &btc, Amount: &amount})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i%4 == 0 {
+			rail.Fail(created.Id, "rail down")
+		}
+		if err := fundings.Process(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if i%4 == 1 {
+			if _, err := fundings.Reject(ctx, created.Id, funding.RejectFundingSchema{Operator: "ops", Reason: "test"}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := fundings.Process(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
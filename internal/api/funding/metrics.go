@@ -0,0 +1,17 @@
+package funding
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	fundingsFinished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "clob_fundings_finished_total",
+		Help: "Deposits and withdrawals that reached a final status: completed, rejected or failed.",
+	}, []string{"kind", "status"})
+	processFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "clob_funding_process_failures_total",
+		Help: "Fundings the processor could not move forward, retried on its next run.",
+	})
+)
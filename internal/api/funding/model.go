@@ -0,0 +1,24 @@
+package funding
+
+import "github.com/JhonesBR/go-clob/internal/storage"
+
+// Representative (schemas will be used for validation and documentation)
+
+type Funding = storage.Funding
+
+type FundingKind = storage.FundingKind
+
+type FundingStatus = storage.FundingStatus
+
+const (
+	Deposit    = storage.FundingDeposit
+	Withdrawal = storage.FundingWithdrawal
+
+	Requested       = storage.FundingRequested
+	PendingApproval = storage.FundingPendingApproval
+	Approved        = storage.FundingApproved
+	Processing      = storage.FundingProcessing
+	Completed       = storage.FundingCompleted
+	Rejected        = storage.FundingRejected
+	Failed          = storage.FundingFailed
+)
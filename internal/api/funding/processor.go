@@ -0,0 +1,195 @@
+package funding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/audit"
+	"github.com/JhonesBR/go-clob/internal/custody"
+	"github.com/JhonesBR/go-clob/internal/storage"
+)
+
+// processBatch is how many fundings of a status a run moves forward
+const processBatch = 100
+
+// Process moves the fundings forward, oldest first: requested ones are
+// screened against the approval thresholds, approved ones submitted to the
+// rail and submitted ones settled once the rail is done with them. A funding
+// failing to move is retried on the next run without holding the others back.
+func (s *Service) Process(ctx context.Context) error {
+	steps := []struct {
+		status FundingStatus
+		step   func(context.Context, Funding) error
+	}{
+		{Requested, s.screen},
+		{Approved, s.submit},
+		{Processing, s.settle},
+	}
+
+	var errs []error
+	for _, step := range steps {
+		var fundings []Funding
+		err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+			var err error
+			fundings, err = tx.Fundings().List(ctx, storage.FundingFilter{Statuses: []FundingStatus{step.status}}, storage.Page{Limit: processBatch})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, funding := range fundings {
+			if err := step.step(ctx, funding); err != nil {
+				processFailures.Inc()
+				errs = append(errs, fmt.Errorf("funding %s: %w", funding.Id, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// screen sends fundings at or above the threshold of their asset to an
+// operator and approves the others
+func (s *Service) screen(ctx context.Context, funding Funding) error {
+	next := Approved
+	if threshold, ok := s.thresholds[funding.AssetCode]; ok && funding.Amount.GreaterThanOrEqual(threshold) {
+		next = PendingApproval
+	}
+	_, err := s.advance(ctx, funding, next, audit.FundingScreened, nil)
+	return err
+}
+
+// submit hands the funding to the rail, outside of any unit of work as the
+// rail may be slow. The rail returns the same reference when a funding is
+// submitted again, after a crash before it was marked processing.
+func (s *Service) submit(ctx context.Context, funding Funding) error {
+	reference, err := s.rail.Submit(ctx, funding)
+	if err != nil {
+		return err
+	}
+	_, err = s.advance(ctx, funding, Processing, audit.FundingSubmitted, func(tx storage.Tx, funding *Funding) error {
+		funding.Reference = reference
+		return nil
+	})
+	return err
+}
+
+// settle completes the funding once the rail settled it: a deposit is
+// credited, a withdrawal keeps its held amount as it left the exchange. A
+// failed withdrawal gets its amount back on the balance.
+func (s *Service) settle(ctx context.Context, funding Funding) error {
+	outcome, err := s.rail.Status(ctx, funding.Reference)
+	if err != nil {
+		return err
+	}
+
+	switch outcome.State {
+	case custody.Settled:
+		settled, err := s.advance(ctx, funding, Completed, audit.FundingCompleted, func(tx storage.Tx, funding *Funding) error {
+			kind := storage.Withdrawal
+			if funding.Kind == Deposit {
+				kind = storage.Deposit
+				if err := credit(ctx, tx, *funding); err != nil {
+					return err
+				}
+			}
+			// Record the movement so reconciliation knows what entered or left the exchange
+			_, err := tx.Movements().Create(ctx, storage.Movement{
+				AccountId: funding.AccountId,
+				AssetId:   funding.AssetId,
+				Kind:      kind,
+				Amount:    funding.Amount,
+			})
+			if err != nil {
+				return err
+			}
+			// Reconciliation must not see the amount both held and moved
+			return tx.Events().Lock(ctx)
+		})
+		if settled {
+			fundingsFinished.WithLabelValues(string(funding.Kind), string(Completed)).Inc()
+		}
+		return err
+	case custody.Failed:
+		failed, err := s.advance(ctx, funding, Failed, audit.FundingFailed, func(tx storage.Tx, funding *Funding) error {
+			funding.Reason = outcome.Reason
+			if funding.Kind == Withdrawal {
+				if err := credit(ctx, tx, *funding); err != nil {
+					return err
+				}
+				// Reconciliation must not see the amount both held and released
+				return tx.Events().Lock(ctx)
+			}
+			return nil
+		})
+		if failed {
+			fundingsFinished.WithLabelValues(string(funding.Kind), string(Failed)).Inc()
+		}
+		return err
+	}
+	return nil
+}
+
+// advance moves the funding to the next status in its own unit of work,
+// applying the change with it and recording it in the audit log. It reports
+// false without error when the funding moved on since it was listed.
+func (s *Service) advance(ctx context.Context, listed Funding, next FundingStatus, action string, apply func(storage.Tx, *Funding) error) (bool, error) {
+	advanced := false
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		funding, err := tx.Fundings().Get(ctx, listed.Id)
+		if err != nil {
+			return err
+		}
+		if funding.Status != listed.Status || !funding.Status.CanTransitionTo(next) {
+			return nil
+		}
+
+		before := funding
+		funding.Status = next
+		if apply != nil {
+			if err := apply(tx, &funding); err != nil {
+				return err
+			}
+		}
+		updated, err := tx.Fundings().Update(ctx, funding)
+		if err != nil {
+			return err
+		}
+		err = audit.Record(ctx, tx, audit.Entry{
+			Actor:      audit.System,
+			Action:     action,
+			EntityType: "funding",
+			EntityId:   updated.Id.String(),
+			Before:     before,
+			After:      updated,
+		})
+		advanced = err == nil
+		return err
+	})
+	return advanced, err
+}
+
+// Start processes the fundings every interval until the context is done, the
+// returned channel is closed once it stopped
+func (s *Service) Start(ctx context.Context, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.Process(ctx); err != nil {
+					slog.ErrorContext(ctx, "Failed to process fundings", "error", err)
+				}
+			}
+		}
+	}()
+	return done
+}
@@ -0,0 +1,68 @@
+package funding
+
+import (
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/openapi"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func InitializeRoutes(app *fiber.App, service *Service) {
+	app.Post("/v1/accounts/:id/deposits", RequestFundingHandler(service, Deposit))
+	app.Post("/v1/accounts/:id/withdrawals", RequestFundingHandler(service, Withdrawal))
+	app.Get("/v1/fundings", GetFundingsHandler(service))
+	app.Get("/v1/fundings/:id", GetFundingHandler(service))
+	app.Post("/v1/admin/fundings/:id/approve", helper.AdminAuth(), ApproveFundingHandler(service))
+	app.Post("/v1/admin/fundings/:id/reject", helper.AdminAuth(), RejectFundingHandler(service))
+}
+
+// Operations documents the routes in the OpenAPI document
+var Operations = []openapi.Operation{
+	{
+		Method: fiber.MethodPost, Path: "/v1/accounts/:id/deposits", Tag: "Fundings",
+		Summary:  "Request a deposit, credited once the custody rail collects it",
+		Body:     RequestFundingSchema{},
+		Status:   fiber.StatusCreated,
+		Response: FundingShowSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusUnprocessableEntity},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/accounts/:id/withdrawals", Tag: "Fundings",
+		Summary:  "Request a withdrawal, its amount is held from the balance until it is paid out or released",
+		Body:     RequestFundingSchema{},
+		Status:   fiber.StatusCreated,
+		Response: FundingShowSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusPaymentRequired, fiber.StatusNotFound, fiber.StatusUnprocessableEntity},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/fundings", Tag: "Fundings",
+		Summary: "List deposits and withdrawals, oldest first by default",
+		Query: append([]openapi.Parameter{
+			{Name: "account_id", Type: uuid.UUID{}},
+			{Name: "kind", Type: Deposit},
+			{Name: "status", Description: "Comma separated statuses, e.g. requested,pending_approval", Type: ""},
+		}, openapi.PageParameters(FundingSorts...)...),
+		Response: helper.Pagination[FundingShowSchema]{},
+		Errors:   []int{fiber.StatusBadRequest},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/fundings/:id", Tag: "Fundings",
+		Summary:  "Get a deposit or withdrawal",
+		Response: FundingShowSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/admin/fundings/:id/approve", Tag: "Admin", Admin: true,
+		Summary:  "Approve a deposit or withdrawal pending approval",
+		Body:     ApproveFundingSchema{},
+		Response: FundingShowSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusConflict, fiber.StatusUnprocessableEntity},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/admin/fundings/:id/reject", Tag: "Admin", Admin: true,
+		Summary:  "Reject a deposit or withdrawal pending approval, a withdrawal is released",
+		Body:     RejectFundingSchema{},
+		Response: FundingShowSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusConflict, fiber.StatusUnprocessableEntity},
+	},
+}
@@ -0,0 +1,62 @@
+package funding
+
+import (
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type RequestFundingSchema struct {
+	AssetCode *string          `json:"asset_code" validate:"required"`
+	Amount    *decimal.Decimal `json:"amount" validate:"required"`
+}
+
+// FundingShowSchema is a deposit or withdrawal. Reference is its id on the
+// custody rail once submitted, Reason why it was rejected or failed and
+// ReviewedBy the operator who approved or rejected it, each null until set.
+type FundingShowSchema struct {
+	Id         uuid.UUID       `json:"id" validate:"required"`
+	AccountId  uuid.UUID       `json:"account_id" validate:"required"`
+	AssetCode  string          `json:"asset_code" validate:"required"`
+	Kind       FundingKind     `json:"kind" validate:"required"`
+	Status     FundingStatus   `json:"status" validate:"required"`
+	Amount     decimal.Decimal `json:"amount" validate:"required"`
+	Reference  *string         `json:"reference"`
+	Reason     *string         `json:"reason"`
+	ReviewedBy *string         `json:"reviewed_by"`
+	CreatedAt  time.Time       `json:"created_at" validate:"required"`
+	UpdatedAt  time.Time       `json:"updated_at" validate:"required"`
+}
+
+type ApproveFundingSchema struct {
+	Operator string `json:"operator" validate:"required"`
+}
+
+type RejectFundingSchema struct {
+	Operator string `json:"operator" validate:"required"`
+	Reason   string `json:"reason" validate:"required"`
+}
+
+func NewFundingShowSchema(funding storage.Funding) FundingShowSchema {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	return FundingShowSchema{
+		Id:         funding.Id,
+		AccountId:  funding.AccountId,
+		AssetCode:  funding.AssetCode,
+		Kind:       funding.Kind,
+		Status:     funding.Status,
+		Amount:     funding.Amount,
+		Reference:  optional(funding.Reference),
+		Reason:     optional(funding.Reason),
+		ReviewedBy: optional(funding.ReviewedBy),
+		CreatedAt:  funding.CreatedAt,
+		UpdatedAt:  funding.UpdatedAt,
+	}
+}
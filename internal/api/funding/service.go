@@ -0,0 +1,143 @@
+package funding
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func RequestFundingHandler(service *Service, kind FundingKind) fiber.Handler {
+	request := service.Deposit
+	if kind == Withdrawal {
+		request = service.Withdraw
+	}
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+
+		var funding RequestFundingSchema
+		if err := c.Bind().Body(&funding); err != nil {
+			return helper.InvalidRequest("Malformed request body")
+		}
+
+		created, err := request(helper.Context(c), id, funding)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(created)
+	}
+}
+
+func GetFundingsHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		page, err := helper.GetPage(c, FundingSorts...)
+		if err != nil {
+			return err
+		}
+		filter, err := getFundingFilter(c)
+		if err != nil {
+			return err
+		}
+
+		pagination, err := service.List(helper.Context(c), filter, page)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(pagination)
+	}
+}
+
+func GetFundingHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+
+		funding, err := service.Get(helper.Context(c), id)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(funding)
+	}
+}
+
+func ApproveFundingHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+
+		var approval ApproveFundingSchema
+		if err := c.Bind().Body(&approval); err != nil {
+			return helper.InvalidRequest("Malformed request body")
+		}
+
+		funding, err := service.Approve(helper.Context(c), id, approval)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(funding)
+	}
+}
+
+func RejectFundingHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+
+		var rejection RejectFundingSchema
+		if err := c.Bind().Body(&rejection); err != nil {
+			return helper.InvalidRequest("Malformed request body")
+		}
+
+		funding, err := service.Reject(helper.Context(c), id, rejection)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(funding)
+	}
+}
+
+// getFundingFilter reads the filters of the funding list from the query
+func getFundingFilter(c fiber.Ctx) (storage.FundingFilter, error) {
+	var filter storage.FundingFilter
+	if c.Query("account_id") != "" {
+		accountId, err := uuid.Parse(c.Query("account_id"))
+		if err != nil {
+			return filter, helper.InvalidId("account_id")
+		}
+		filter.AccountId = &accountId
+	}
+	if kind := FundingKind(c.Query("kind")); kind != "" {
+		if kind != Deposit && kind != Withdrawal {
+			return filter, helper.InvalidRequest("kind must be deposit or withdrawal")
+		}
+		filter.Kind = &kind
+	}
+
+	// Statuses are comma separated, e.g. requested,pending_approval
+	if c.Query("status") != "" {
+		for _, status := range strings.Split(c.Query("status"), ",") {
+			if !slices.Contains(FundingStatuses, FundingStatus(status)) {
+				return filter, helper.InvalidRequest("status must be requested, pending_approval, approved, processing, completed, rejected or failed")
+			}
+			filter.Statuses = append(filter.Statuses, FundingStatus(status))
+		}
+	}
+	return filter, nil
+}
@@ -2,13 +2,55 @@ package api
 
 import (
 	"github.com/gofiber/fiber/v3"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/gofiber/fiber/v3/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/api/auditlog"
+	"github.com/JhonesBR/go-clob/internal/api/events"
+	"github.com/JhonesBR/go-clob/internal/api/funding"
+	"github.com/JhonesBR/go-clob/internal/api/health"
+	"github.com/JhonesBR/go-clob/internal/api/instrument"
 	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/api/reconciliation"
+	"github.com/JhonesBR/go-clob/internal/api/stream"
+	"github.com/JhonesBR/go-clob/internal/api/transfer"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/idempotency"
+	"github.com/JhonesBR/go-clob/internal/logging"
+	"github.com/JhonesBR/go-clob/internal/marketdata"
+	"github.com/JhonesBR/go-clob/internal/ratelimit"
+	"github.com/JhonesBR/go-clob/internal/reconcile"
+	"github.com/JhonesBR/go-clob/internal/shutdown"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/tracing"
 )
 
-func InitializeRoutes(app *fiber.App, db *pgxpool.Pool) {
-	account.InitializeRoutes(app, db)
-	orderbook.InitializeRoutes(app, db)
+func InitializeRoutes(app *fiber.App, store storage.Store, drainer *shutdown.Drainer, limiter *ratelimit.RateLimiter, orders *orderbook.Service, fundings *funding.Service, breaker *circuitbreaker.Breaker, reconciler *reconcile.Reconciler, feed *marketdata.Feed, keys *idempotency.Keys, signingSecret string) {
+	// Probes, metrics and docs are neither rate limited nor refused while draining
+	health.InitializeRoutes(app, store, drainer)
+	prometheus.MustRegister(orderbook.NewOpenOrdersCollector(store))
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+	initializeDocs(app)
+
+	app.Use(observeRequests())
+	app.Use(drainer.Middleware())
+	app.Use(tracing.Middleware())
+	app.Use(logging.Middleware())
+	if signingSecret != "" {
+		app.Use(verifySignatures(signingSecret))
+	}
+	app.Use(limiter.Middleware())
+	app.Use(keys.Middleware())
+
+	account.InitializeRoutes(app, store)
+	auditlog.InitializeRoutes(app, store)
+	events.InitializeRoutes(app, store)
+	funding.InitializeRoutes(app, fundings)
+	instrument.InitializeRoutes(app, store, breaker)
+	orderbook.InitializeRoutes(app, orders)
+	reconciliation.InitializeRoutes(app, reconciler)
+	stream.InitializeRoutes(app, store, orders, feed)
+	transfer.InitializeRoutes(app, store)
 }
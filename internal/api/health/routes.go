@@ -0,0 +1,27 @@
+package health
+
+import (
+	"github.com/JhonesBR/go-clob/internal/openapi"
+	"github.com/JhonesBR/go-clob/internal/shutdown"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+)
+
+func InitializeRoutes(app *fiber.App, store storage.Store, drainer *shutdown.Drainer) {
+	app.Get("/healthz", GetHealthHandler())
+	app.Get("/readyz", GetReadinessHandler(store, drainer))
+}
+
+// Operations documents the routes in the OpenAPI document
+var Operations = []openapi.Operation{
+	{
+		Method: fiber.MethodGet, Path: "/healthz", Tag: "Operations",
+		Summary:  "Liveness probe",
+		Response: HealthSchema{},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/readyz", Tag: "Operations",
+		Summary:  "Readiness probe, 503 with the failing checks when not ready",
+		Response: ReadinessSchema{},
+	},
+}
@@ -0,0 +1,11 @@
+package health
+
+type HealthSchema struct {
+	Status string `json:"status"`
+}
+
+// ReadinessSchema has the error of each failing check, "ok" for the others
+type ReadinessSchema struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
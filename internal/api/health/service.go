@@ -0,0 +1,52 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/shutdown"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+)
+
+// GetHealthHandler answers as long as the process serves requests
+func GetHealthHandler() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		return c.JSON(HealthSchema{
+			Status: "ok",
+		})
+	}
+}
+
+// GetReadinessHandler answers 503 when the database is unreachable or the
+// engine is shutting down. Routes are only served once the engine state was
+// recovered from the event log.
+func GetReadinessHandler(store storage.Store, drainer *shutdown.Drainer) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		ready := true
+		checks := map[string]string{
+			"database": "ok",
+			"engine":   "ok",
+		}
+		if err := store.Ping(ctx); err != nil {
+			ready = false
+			checks["database"] = err.Error()
+		}
+		if drainer.Draining() {
+			ready = false
+			checks["engine"] = "shutting down"
+		}
+
+		status, code := "ok", fiber.StatusOK
+		if !ready {
+			status, code = "unavailable", fiber.StatusServiceUnavailable
+		}
+		return c.Status(code).JSON(ReadinessSchema{
+			Status: status,
+			Checks: checks,
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package instrument
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/auction"
+	"github.com/JhonesBR/go-clob/internal/audit"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// Service reads instruments and their prices and changes their trading status
+// whichever transport the request comes from, refusals are helper.Error
+type Service struct {
+	store   storage.Store
+	breaker *circuitbreaker.Breaker
+}
+
+func NewService(store storage.Store, breaker *circuitbreaker.Breaker) *Service {
+	return &Service{store: store, breaker: breaker}
+}
+
+func (s *Service) List(ctx context.Context) ([]InstrumentShowSchema, error) {
+	instruments := []InstrumentShowSchema{}
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		stored, err := tx.Instruments().List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, instrument := range stored {
+			instruments = append(instruments, newInstrumentShowSchema(instrument, s.breaker))
+		}
+		return nil
+	})
+	return instruments, err
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (InstrumentShowSchema, error) {
+	var instrument storage.Instrument
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		instrument, err = tx.Instruments().Get(ctx, id)
+		return err
+	})
+	if err != nil {
+		return InstrumentShowSchema{}, notFound(err)
+	}
+	return newInstrumentShowSchema(instrument, s.breaker), nil
+}
+
+// Ticker returns the last price, best prices and 24h volume of the instrument
+func (s *Service) Ticker(ctx context.Context, id uuid.UUID) (TickerSchema, error) {
+	var ticker TickerSchema
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		stored, err := tx.Instruments().Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		instrument := newInstrumentShowSchema(stored, s.breaker)
+		ticker = TickerSchema{
+			InstrumentId:   instrument.Id,
+			Symbol:         instrument.Symbol,
+			TradingStatus:  instrument.TradingStatus,
+			CircuitBreaker: instrument.CircuitBreaker,
+		}
+
+		last, err := tx.Trades().Last(ctx, id)
+		if err != nil {
+			return err
+		}
+		if last != nil {
+			ticker.LastPrice = &last.Price
+		}
+		if ticker.BestBid, ticker.BestAsk, err = tx.Orders().BestPrices(ctx, id); err != nil {
+			return err
+		}
+		ticker.Volume24h, err = tx.Trades().VolumeSince(ctx, id, time.Now().Add(-24*time.Hour))
+		return err
+	})
+	if err != nil {
+		return TickerSchema{}, notFound(err)
+	}
+	return ticker, nil
+}
+
+// IndicativeAuction returns the outcome of uncrossing the book now
+func (s *Service) IndicativeAuction(ctx context.Context, id uuid.UUID) (*AuctionSchema, error) {
+	var schema *AuctionSchema
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		instrument, err := orderbook.GetInstrumentByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		result, err := orderbook.IndicativeAuction(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		schema = newAuctionSchema(id, instrument.TradingStatus, result)
+		return nil
+	})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return schema, nil
+}
+
+// UpdateTradingStatus moves the instrument to another trading status, leaving
+// an auction, or opening or closing a crossed book, runs it
+func (s *Service) UpdateTradingStatus(ctx context.Context, id uuid.UUID, update UpdateTradingStatusSchema) (UpdateTradingStatusResponseSchema, error) {
+	if err := helper.ValidateInput(&update); err != nil {
+		return UpdateTradingStatusResponseSchema{}, helper.Invalid(err)
+	}
+	if !update.Status.Valid() {
+		return UpdateTradingStatusResponseSchema{}, helper.Invalid(fmt.Errorf("invalid trading status %s", update.Status))
+	}
+
+	// Transaction to ensure correct update on race conditions
+	var response UpdateTradingStatusResponseSchema
+	var instrument storage.Instrument
+	var auctionResult auction.Result
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		instrument, err = tx.Instruments().Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		current := instrument.TradingStatus
+		if !current.CanTransitionTo(update.Status) {
+			return helper.Error{
+				Status:  fiber.StatusConflict,
+				Code:    helper.CodeInvalidStatusChange,
+				Message: fmt.Sprintf("cannot transition instrument from %s to %s", current, update.Status),
+			}
+		}
+
+		// Leaving an auction to trade or close uncrosses the book at a single
+		// price, so does any move to trade or close with a crossed book, like
+		// the one of an auction that was halted
+		if update.Status == orderbook.TradingOpen || update.Status == orderbook.TradingClosed {
+			uncross := current == orderbook.TradingAuction
+			if !uncross {
+				if uncross, err = orderbook.BookCrossed(ctx, tx, id); err != nil {
+					return err
+				}
+			}
+			if uncross {
+				if auctionResult, err = orderbook.RunAuction(ctx, tx, instrument); err != nil {
+					return err
+				}
+				response.Auction = newAuctionSchema(id, current, auctionResult)
+			}
+		}
+
+		if err := tx.Instruments().UpdateTradingStatus(ctx, id, update.Status); err != nil {
+			return err
+		}
+		instrument.TradingStatus = update.Status
+
+		// Release the funds of resting orders when closing
+		if update.Status == orderbook.TradingClosed && update.CancelRestingOrders {
+			if response.CanceledOrders, err = orderbook.CancelOpenOrders(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+
+		response.InstrumentShowSchema = newInstrumentShowSchema(instrument, s.breaker)
+		return audit.Record(ctx, tx, audit.Entry{
+			Actor:      audit.Admin,
+			Action:     audit.InstrumentStatusChanged,
+			EntityType: "instrument",
+			EntityId:   id.String(),
+			Before:     map[string]any{"trading_status": current},
+			After:      map[string]any{"trading_status": update.Status, "canceled_orders": response.CanceledOrders},
+		})
+	})
+	if err != nil {
+		return UpdateTradingStatusResponseSchema{}, notFound(err)
+	}
+	if auctionResult.Price != nil {
+		s.breaker.Record(id, *auctionResult.Price)
+	}
+	orderbook.ObserveAuction(instrument, auctionResult)
+	orderbook.ObserveCanceled(instrument, response.CanceledOrders)
+
+	return response, nil
+}
+
+// notFound turns a missing instrument into its service error
+func notFound(err error) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return helper.NotFound("Instrument")
+	}
+	return err
+}
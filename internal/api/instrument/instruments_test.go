@@ -0,0 +1,105 @@
+package instrument_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/api/instrument"
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/storage/memory"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TestHaltedAuctionUncrossesOnOpen moves an auction with a crossed book to
+// halted and then open: the book must be uncrossed on open
+func TestHaltedAuctionUncrossesOnOpen(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	breaker := circuitbreaker.New(circuitbreaker.DefaultConfig())
+	instruments := instrument.NewService(store, breaker)
+	orders := orderbook.NewService(store, nil, risk.NewEngine(risk.Config{}), breaker)
+	accounts := account.NewService(store)
+
+	var instrumentId uuid.UUID
+	err := store.WithTx(ctx, func(tx storage.Tx) error {
+		btc, err := tx.Instruments().GetByBaseAssetCode(ctx, "BTC")
+		instrumentId = btc.Id
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newAccount := func(assetCode, amount string) uuid.UUID {
+		var created storage.Account
+		err := store.WithTx(ctx, func(tx storage.Tx) error {
+			var err error
+			created, err = tx.Accounts().Create(ctx, "auction")
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		value := decimal.RequireFromString(amount)
+		if _, err := accounts.Charge(ctx, created.Id, account.UpdateBalanceSchema{AssetCode: &assetCode, Amount: &value}); err != nil {
+			t.Fatal(err)
+		}
+		return created.Id
+	}
+	seller := newAccount("BTC", "1")
+	buyer := newAccount("BRL", "110")
+
+	transition := func(status orderbook.TradingStatus) instrument.UpdateTradingStatusResponseSchema {
+		t.Helper()
+		response, err := instruments.UpdateTradingStatus(ctx, instrumentId, instrument.UpdateTradingStatusSchema{Status: status})
+		if err != nil {
+			t.Fatalf("move to %s: %v", status, err)
+		}
+		return response
+	}
+	place := func(accountId uuid.UUID, side orderbook.OrderType, price string) {
+		t.Helper()
+		_, err := orders.Place(ctx, orderbook.PlaceOrderSchema{
+			AccountId: accountId,
+			AssetCode: "BTC",
+			Quantity:  decimal.NewFromInt(1),
+			Price:     decimal.RequireFromString(price),
+			OrderType: side,
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	transition(orderbook.TradingAuction)
+	place(seller, orderbook.Sell, "100")
+	place(buyer, orderbook.Buy, "110")
+	if response := transition(orderbook.TradingHalted); response.Auction != nil {
+		t.Fatalf("halting ran the auction: %+v", response.Auction)
+	}
+
+	response := transition(orderbook.TradingOpen)
+	if response.Auction == nil || response.Auction.Price == nil || response.Auction.Fills != 1 {
+		t.Fatalf("opening got auction %+v, want one fill", response.Auction)
+	}
+	err = store.WithTx(ctx, func(tx storage.Tx) error {
+		crossed, err := orderbook.BookCrossed(ctx, tx, instrumentId)
+		if err == nil && crossed {
+			t.Error("book is crossed after opening")
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Opening a book that does not cross runs no auction
+	transition(orderbook.TradingHalted)
+	if response := transition(orderbook.TradingOpen); response.Auction != nil {
+		t.Errorf("opening an uncrossed book got auction %+v", response.Auction)
+	}
+}
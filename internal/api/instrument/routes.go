@@ -0,0 +1,52 @@
+package instrument
+
+import (
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/openapi"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+)
+
+func InitializeRoutes(app *fiber.App, store storage.Store, breaker *circuitbreaker.Breaker) {
+	service := NewService(store, breaker)
+	app.Get("/v1/instruments", GetInstrumentsHandler(service))
+	app.Get("/v1/instruments/:id", GetInstrumentByIDHandler(service))
+	app.Get("/v1/instruments/:id/ticker", GetTickerHandler(service))
+	app.Get("/v1/instruments/:id/auction", GetIndicativeAuctionHandler(service))
+	app.Post("/v1/admin/instruments/:id/status", helper.AdminAuth(), UpdateTradingStatusHandler(service))
+}
+
+// Operations documents the routes in the OpenAPI document
+var Operations = []openapi.Operation{
+	{
+		Method: fiber.MethodGet, Path: "/v1/instruments", Tag: "Instruments",
+		Summary:  "List instruments",
+		Response: []InstrumentShowSchema{},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/instruments/:id", Tag: "Instruments",
+		Summary:  "Get an instrument",
+		Response: InstrumentShowSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/instruments/:id/ticker", Tag: "Instruments",
+		Summary:  "Get the last price, best prices and 24h volume of an instrument",
+		Response: TickerSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/instruments/:id/auction", Tag: "Instruments",
+		Summary:  "Get the indicative uncrossing of the book of an instrument",
+		Response: AuctionSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/admin/instruments/:id/status", Tag: "Admin", Admin: true,
+		Summary:  "Change the trading status of an instrument",
+		Body:     UpdateTradingStatusSchema{},
+		Response: UpdateTradingStatusResponseSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusConflict, fiber.StatusUnprocessableEntity},
+	},
+}
@@ -0,0 +1,52 @@
+package instrument
+
+import (
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type InstrumentShowSchema struct {
+	Id             uuid.UUID               `json:"id" validate:"required"`
+	Symbol         string                  `json:"symbol" validate:"required"`
+	BaseAssetId    uuid.UUID               `json:"base_asset_id" validate:"required"`
+	BaseAssetCode  string                  `json:"base_asset_code" validate:"required"`
+	QuoteAssetId   uuid.UUID               `json:"quote_asset_id" validate:"required"`
+	QuoteAssetCode string                  `json:"quote_asset_code" validate:"required"`
+	TradingStatus  orderbook.TradingStatus `json:"trading_status" validate:"required"`
+	CircuitBreaker circuitbreaker.Status   `json:"circuit_breaker" validate:"required"`
+}
+
+type TickerSchema struct {
+	InstrumentId   uuid.UUID               `json:"instrument_id" validate:"required"`
+	Symbol         string                  `json:"symbol" validate:"required"`
+	LastPrice      *decimal.Decimal        `json:"last_price"`
+	BestBid        *decimal.Decimal        `json:"best_bid"`
+	BestAsk        *decimal.Decimal        `json:"best_ask"`
+	Volume24h      decimal.Decimal         `json:"volume_24h" validate:"required"`
+	TradingStatus  orderbook.TradingStatus `json:"trading_status" validate:"required"`
+	CircuitBreaker circuitbreaker.Status   `json:"circuit_breaker" validate:"required"`
+}
+
+type UpdateTradingStatusSchema struct {
+	Status              orderbook.TradingStatus `json:"status" validate:"required"`
+	CancelRestingOrders bool                    `json:"cancel_resting_orders"`
+}
+
+type UpdateTradingStatusResponseSchema struct {
+	InstrumentShowSchema
+	CanceledOrders int            `json:"canceled_orders"`
+	Auction        *AuctionSchema `json:"auction"`
+}
+
+type AuctionSchema struct {
+	InstrumentId  uuid.UUID               `json:"instrument_id" validate:"required"`
+	TradingStatus orderbook.TradingStatus `json:"trading_status" validate:"required"`
+	Price         *decimal.Decimal        `json:"price"`
+	Volume        decimal.Decimal         `json:"volume" validate:"required"`
+	BuyVolume     decimal.Decimal         `json:"buy_volume" validate:"required"`
+	SellVolume    decimal.Decimal         `json:"sell_volume" validate:"required"`
+	Imbalance     decimal.Decimal         `json:"imbalance" validate:"required"`
+	Fills         int                     `json:"fills"`
+}
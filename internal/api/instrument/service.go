@@ -0,0 +1,118 @@
+package instrument
+
+import (
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/auction"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func GetInstrumentsHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		instruments, err := service.List(helper.Context(c))
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(instruments)
+	}
+}
+
+func GetInstrumentByIDHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+
+		instrument, err := service.Get(helper.Context(c), id)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(instrument)
+	}
+}
+
+func GetTickerHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+
+		ticker, err := service.Ticker(helper.Context(c), id)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(ticker)
+	}
+}
+
+func UpdateTradingStatusHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+
+		// Parse update trading status schema
+		var update UpdateTradingStatusSchema
+		if err := c.Bind().Body(&update); err != nil {
+			return helper.InvalidRequest("Malformed request body")
+		}
+
+		response, err := service.UpdateTradingStatus(helper.Context(c), id, update)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(response)
+	}
+}
+
+func GetIndicativeAuctionHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+
+		schema, err := service.IndicativeAuction(helper.Context(c), id)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(schema)
+	}
+}
+
+func newAuctionSchema(instrumentId uuid.UUID, status orderbook.TradingStatus, result auction.Result) *AuctionSchema {
+	return &AuctionSchema{
+		InstrumentId:  instrumentId,
+		TradingStatus: status,
+		Price:         result.Price,
+		Volume:        result.Volume,
+		BuyVolume:     result.BuyVolume,
+		SellVolume:    result.SellVolume,
+		Imbalance:     result.Imbalance(),
+		Fills:         len(result.Fills),
+	}
+}
+
+func newInstrumentShowSchema(instrument storage.Instrument, breaker *circuitbreaker.Breaker) InstrumentShowSchema {
+	return InstrumentShowSchema{
+		Id:             instrument.Id,
+		Symbol:         instrument.BaseAssetCode + "/" + instrument.QuoteAssetCode,
+		BaseAssetId:    instrument.BaseAssetId,
+		BaseAssetCode:  instrument.BaseAssetCode,
+		QuoteAssetId:   instrument.QuoteAssetId,
+		QuoteAssetCode: instrument.QuoteAssetCode,
+		TradingStatus:  instrument.TradingStatus,
+		CircuitBreaker: breaker.Status(instrument.Id),
+	}
+}
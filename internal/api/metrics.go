@@ -0,0 +1,34 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/gofiber/fiber/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "clob_http_request_duration_seconds",
+	Help:    "Time to answer HTTP requests per route.",
+	Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+}, []string{"method", "route", "status"})
+
+// observeRequests records the latency of every request labeled by its route
+// pattern, so ids in paths do not create new series
+func observeRequests() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := helper.Status(c, err)
+		route := c.Route().Path
+		if status == fiber.StatusNotFound && route == "/" && c.Path() != "/" {
+			route = "unmatched"
+		}
+		requestDuration.WithLabelValues(c.Method(), route, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"slices"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/static"
+	swaggerFiles "github.com/swaggo/files/v2"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/api/auditlog"
+	"github.com/JhonesBR/go-clob/internal/api/events"
+	"github.com/JhonesBR/go-clob/internal/api/funding"
+	"github.com/JhonesBR/go-clob/internal/api/health"
+	"github.com/JhonesBR/go-clob/internal/api/instrument"
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/api/reconciliation"
+	"github.com/JhonesBR/go-clob/internal/api/stream"
+	"github.com/JhonesBR/go-clob/internal/api/transfer"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/openapi"
+	"github.com/JhonesBR/go-clob/internal/storage"
+)
+
+// The committed document, TestOpenAPI fails when it is not the one the
+// operations generate
+//
+//go:generate go test -run TestOpenAPI -update
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// swaggerPage loads the Swagger UI distribution with the OpenAPI document,
+// its paths are absolute so the page works on /docs and /docs/
+const swaggerPage = `<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta charset="UTF-8">
+    <title>Central Limit Order Book API</title>
+    <link rel="stylesheet" type="text/css" href="/docs/swagger-ui.css" />
+    <link rel="icon" type="image/png" href="/docs/favicon-32x32.png" sizes="32x32" />
+  </head>
+  <body>
+    <div id="swagger-ui"></div>
+    <script src="/docs/swagger-ui-bundle.js" charset="UTF-8"></script>
+    <script src="/docs/swagger-ui-standalone-preset.js" charset="UTF-8"></script>
+    <script>
+      window.onload = function() {
+        window.ui = SwaggerUIBundle({
+          url: "/openapi.json",
+          dom_id: "#swagger-ui",
+          deepLinking: true,
+          presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+          layout: "StandaloneLayout"
+        });
+      };
+    </script>
+  </body>
+</html>
+`
+
+// Operations are the documented operations of every API package
+func Operations() []openapi.Operation {
+	return slices.Concat(
+		health.Operations,
+		account.Operations,
+		funding.Operations,
+		orderbook.Operations,
+		instrument.Operations,
+		auditlog.Operations,
+		events.Operations,
+		reconciliation.Operations,
+		stream.Operations,
+		transfer.Operations,
+	)
+}
+
+// OpenAPI generates the OpenAPI document of the REST API
+func OpenAPI() ([]byte, error) {
+	g := openapi.NewGenerator()
+	openapi.Enum(g, storage.Buy, storage.Sell)
+	openapi.Enum(g, storage.Open, storage.PartiallyFilled, storage.FullFilled, storage.Canceled)
+	openapi.Enum(g, storage.TradingPreOpen, storage.TradingOpen, storage.TradingAuction, storage.TradingHalted, storage.TradingCancelOnly, storage.TradingClosed)
+	openapi.Enum(g, circuitbreaker.Trading, circuitbreaker.Halted)
+	openapi.Enum(g, eventlog.OrderAccepted, eventlog.OrderRejected, eventlog.OrderMatched, eventlog.OrderCanceled, eventlog.BalanceChanged)
+	openapi.Enum(g, eventlog.CancelRequested, eventlog.CancelReplaced, eventlog.CancelInstrumentClosed, eventlog.CancelHalted)
+	openapi.Enum(g, orderbook.OrderEventAccepted, orderbook.OrderEventPartiallyFilled, orderbook.OrderEventFilled, orderbook.OrderEventAmended, orderbook.OrderEventCanceled)
+	openapi.Enum(g, storage.FundingDeposit, storage.FundingWithdrawal)
+	openapi.Enum(g, storage.FundingRequested, storage.FundingPendingApproval, storage.FundingApproved, storage.FundingProcessing, storage.FundingCompleted, storage.FundingRejected, storage.FundingFailed)
+	openapi.Enum(g, helper.Codes...)
+	g.Errors(helper.ProblemSchema{})
+
+	doc := g.Generate(openapi.Info{
+		Title:       "Central Limit Order Book",
+		Version:     "1.0.0",
+		Description: "Accounts, limit orders and market data of a central limit order book. Decimals are strings.",
+	}, Operations())
+	document, err := json.MarshalIndent(doc, "", "  ")
+	return append(document, '\n'), err
+}
+
+// initializeDocs serves the OpenAPI document and a Swagger UI page on /docs
+func initializeDocs(app *fiber.App) {
+	app.Get("/openapi.json", func(c fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(openAPIDocument)
+	})
+	app.Get("/docs", func(c fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(swaggerPage)
+	})
+	app.Get("/docs/*", static.New("", static.Config{FS: swaggerFiles.FS}))
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/api/funding"
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/custody"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/idempotency"
+	"github.com/JhonesBR/go-clob/internal/marketdata"
+	"github.com/JhonesBR/go-clob/internal/ratelimit"
+	"github.com/JhonesBR/go-clob/internal/reconcile"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/JhonesBR/go-clob/internal/shutdown"
+	"github.com/JhonesBR/go-clob/internal/storage/memory"
+	"github.com/gofiber/fiber/v3"
+)
+
+var update = flag.Bool("update", false, "regenerate openapi.json")
+
+// undocumented are the routes left out of the OpenAPI document
+var undocumented = map[string]bool{
+	"/metrics":      true,
+	"/openapi.json": true,
+	"/docs":         true,
+	"/docs/*":       true,
+}
+
+// routedApp registers the routes once, metrics can only be registered once
+var routedApp = sync.OnceValue(func() *fiber.App {
+	store := memory.NewEmpty()
+	breaker := circuitbreaker.New(circuitbreaker.DefaultConfig())
+	orders := orderbook.NewService(store, nil, risk.NewEngine(risk.Config{}), breaker)
+	fundings := funding.NewService(store, custody.NewFake(), nil)
+
+	feed, err := marketdata.New(context.Background(), store)
+	if err != nil {
+		panic(err)
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: helper.ErrorHandler})
+	InitializeRoutes(app, store, shutdown.NewDrainer(), ratelimit.New(ratelimit.Config{}), orders, fundings, breaker, reconcile.New(store), feed, idempotency.New(time.Hour), "")
+	return app
+})
+
+// TestOpenAPI fails when a route is not documented, an operation is not
+// routed or openapi.json is not the document the operations generate. Run
+// go generate ./internal/api to regenerate it.
+func TestOpenAPI(t *testing.T) {
+	document, err := OpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile("openapi.json", document, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app := routedApp()
+	documented := map[string]bool{}
+	for _, op := range Operations() {
+		documented[op.Method+" "+op.Path] = true
+	}
+	routed := map[string]bool{}
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || undocumented[route.Path] {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		routed[key] = true
+		if !documented[key] {
+			t.Errorf("%s is not documented, add it to the Operations of its package", key)
+		}
+	}
+	for key := range documented {
+		if !routed[key] {
+			t.Errorf("%s is documented but not routed", key)
+		}
+	}
+
+	committed, err := os.ReadFile("openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(committed, document) {
+		t.Fatal("openapi.json is out of date, run go generate ./internal/api")
+	}
+}
+
+func TestDocs(t *testing.T) {
+	app := fiber.New()
+	initializeDocs(app)
+	for path, contentType := range map[string]string{
+		"/openapi.json":              fiber.MIMEApplicationJSON,
+		"/docs":                      fiber.MIMETextHTML,
+		"/docs/":                     fiber.MIMETextHTML,
+		"/docs/swagger-ui-bundle.js": fiber.MIMETextJavaScript,
+		"/docs/swagger-ui.css":       fiber.MIMETextCSS,
+	} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != fiber.StatusOK || !strings.HasPrefix(resp.Header.Get(fiber.HeaderContentType), contentType) || len(body) == 0 {
+			t.Errorf("GET %s: status %d, content type %q", path, resp.StatusCode, resp.Header.Get(fiber.HeaderContentType))
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package orderbook
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+)
+
+// Archive moves every final order from the live book to the order history,
+// batchSize orders per unit of work so matching is never blocked for long,
+// and returns how many it moved
+func Archive(ctx context.Context, store storage.Store, batchSize int) (int, error) {
+	total := 0
+	for {
+		var moved int
+		err := store.WithTx(ctx, func(tx storage.Tx) error {
+			var err error
+			moved, err = tx.Orders().Archive(ctx, batchSize)
+			return err
+		})
+		if err != nil {
+			return total, err
+		}
+		total += moved
+		ordersArchived.Add(float64(moved))
+		if moved < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// StartArchiver archives the final orders every interval until the context
+// is done, the returned channel is closed once it stopped
+func StartArchiver(ctx context.Context, store storage.Store, interval time.Duration, batchSize int) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := Archive(ctx, store, batchSize); err != nil {
+					slog.ErrorContext(ctx, "Failed to archive final orders", "error", err)
+				}
+			}
+		}
+	}()
+	return done
+}
@@ -0,0 +1,81 @@
+package orderbook
+
+import (
+	"context"
+
+	"github.com/JhonesBR/go-clob/internal/auction"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/tracing"
+	"github.com/google/uuid"
+)
+
+// IndicativeAuction computes the equilibrium price and volume of the book
+// without executing it
+func IndicativeAuction(ctx context.Context, tx storage.Tx, instrumentId uuid.UUID) (auction.Result, error) {
+	orders, err := tx.Orders().ListWorking(ctx, instrumentId)
+	if err != nil {
+		return auction.Result{}, err
+	}
+	return uncross(ctx, tx, instrumentId, orders)
+}
+
+// RunAuction uncrosses the book filling every eligible order at the single
+// clearing price
+func RunAuction(ctx context.Context, tx storage.Tx, instrument InstrumentWithAssetsSchema) (result auction.Result, err error) {
+	ctx, span := tracing.Start(ctx, "orderbook.RunAuction", tracing.Instrument.String(symbol(instrument)))
+	defer func() {
+		span.SetAttributes(tracing.Matches.Int(len(result.Fills)))
+		tracing.End(span, err)
+	}()
+
+	orders, err := tx.Orders().ListWorking(ctx, instrument.Id)
+	if err != nil {
+		return auction.Result{}, err
+	}
+
+	result, err = uncross(ctx, tx, instrument.Id, orders)
+	if err != nil || result.Price == nil {
+		return result, err
+	}
+
+	// Keep filled quantities up to date as an order may take part in many fills
+	ordersById := make(map[uuid.UUID]*OrderBook, len(orders))
+	for i := range orders {
+		ordersById[orders[i].Id] = &orders[i]
+	}
+	for _, fill := range result.Fills {
+		buyOrder := ordersById[fill.BuyOrderId]
+		sellOrder := ordersById[fill.SellOrderId]
+		if err := settleTrade(ctx, tx, *buyOrder, *sellOrder, fill.Quantity, *result.Price, instrument); err != nil {
+			return auction.Result{}, err
+		}
+		buyOrder.FilledQuantity = buyOrder.FilledQuantity.Add(fill.Quantity)
+		sellOrder.FilledQuantity = sellOrder.FilledQuantity.Add(fill.Quantity)
+	}
+
+	return result, nil
+}
+
+func uncross(ctx context.Context, tx storage.Tx, instrumentId uuid.UUID, orders []OrderBook) (auction.Result, error) {
+	reference, err := riskState{tx: tx}.LastTradePrice(ctx, instrumentId)
+	if err != nil {
+		return auction.Result{}, err
+	}
+
+	auctionOrders := make([]auction.Order, 0, len(orders))
+	for i, order := range orders {
+		auctionOrders = append(auctionOrders, auction.Order{
+			Id:       order.Id,
+			Side:     string(order.Type),
+			Price:    order.Price,
+			Quantity: order.TotalQuantity.Sub(order.FilledQuantity),
+			Sequence: i,
+		})
+	}
+
+	return auction.Uncross(auctionOrders, reference), nil
+}
+
+func GetInstrumentByID(ctx context.Context, tx storage.Tx, id uuid.UUID) (InstrumentWithAssetsSchema, error) {
+	return tx.Instruments().Get(ctx, id)
+}
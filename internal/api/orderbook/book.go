@@ -0,0 +1,104 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+)
+
+// OrderSorts are the sorts of the order list, oldest first by default
+var OrderSorts = []string{"created_at", "-created_at", "price", "-price"}
+
+// OrderStatuses are the statuses orders can be filtered on
+var OrderStatuses = []OrderStatus{Open, PartiallyFilled, FullFilled, Canceled}
+
+// List returns a page of the live orders matching filter, see helper.NewPage
+func (s *Service) List(ctx context.Context, filter storage.OrderFilter, page storage.Page) (helper.Pagination[OrderBookShowSchema], error) {
+	var pagination helper.Pagination[OrderBookShowSchema]
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		pagination, err = helper.Paginate(page, func(page storage.Page) ([]OrderBook, error) {
+			return tx.Orders().List(ctx, filter, page)
+		}, func(order OrderBook) (OrderBookShowSchema, error) {
+			return NewOrderBookShowSchema(order), nil
+		})
+		return err
+	})
+	return pagination, err
+}
+
+// History returns a page of the archived orders matching filter, see
+// helper.NewPage. Final orders are listed by List until they are archived.
+func (s *Service) History(ctx context.Context, filter storage.OrderFilter, page storage.Page) (helper.Pagination[OrderBookShowSchema], error) {
+	var pagination helper.Pagination[OrderBookShowSchema]
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		pagination, err = helper.Paginate(page, func(page storage.Page) ([]OrderBook, error) {
+			return tx.Orders().History(ctx, filter, page)
+		}, func(order OrderBook) (OrderBookShowSchema, error) {
+			return NewOrderBookShowSchema(order), nil
+		})
+		return err
+	})
+	return pagination, err
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (OrderBookShowSchema, error) {
+	var order OrderBook
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		order, err = tx.Orders().Get(ctx, id)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return OrderBookShowSchema{}, helper.NotFound("Order")
+		}
+		return OrderBookShowSchema{}, err
+	}
+	return NewOrderBookShowSchema(order), nil
+}
+
+// Book returns the working quantity of the instrument aggregated per price
+// level, up to depth levels a side (all of them when 0)
+func (s *Service) Book(ctx context.Context, instrumentId uuid.UUID, depth int) (BookSchema, error) {
+	book := BookSchema{InstrumentId: instrumentId}
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		if _, err := tx.Instruments().Get(ctx, instrumentId); err != nil {
+			return err
+		}
+		var err error
+		if book.Bids, err = tx.Orders().Levels(ctx, instrumentId, Buy, depth); err != nil {
+			return err
+		}
+		if book.Asks, err = tx.Orders().Levels(ctx, instrumentId, Sell, depth); err != nil {
+			return err
+		}
+		book.Sequence, err = tx.Events().Last(ctx)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return BookSchema{}, helper.NotFound("Instrument")
+		}
+		return BookSchema{}, err
+	}
+	return book, nil
+}
+
+func NewOrderBookShowSchema(order OrderBook) OrderBookShowSchema {
+	return OrderBookShowSchema{
+		Id:             order.Id,
+		AccountId:      order.AccountId,
+		InstrumentId:   order.InstrumentId,
+		Type:           order.Type,
+		Status:         order.Status,
+		Price:          order.Price,
+		TotalQuantity:  order.TotalQuantity,
+		FilledQuantity: order.FilledQuantity,
+		CreatedAt:      order.CreatedAt,
+	}
+}
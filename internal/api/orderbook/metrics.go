@@ -0,0 +1,130 @@
+package orderbook
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/auction"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ordersPlaced = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "clob_orders_placed_total",
+		Help: "Orders accepted on the book.",
+	}, []string{"instrument", "side"})
+	ordersCanceled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "clob_orders_canceled_total",
+		Help: "Orders canceled, by their owner or when the instrument closed.",
+	}, []string{"instrument"})
+	ordersRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "clob_orders_rejected_total",
+		Help: "Orders rejected before entering the book, risk rejections count once per failed rule.",
+	}, []string{"reason"})
+	trades = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "clob_trades_total",
+		Help: "Trades executed, in continuous trading and auctions.",
+	}, []string{"instrument"})
+	tradedVolume = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "clob_traded_volume_total",
+		Help: "Base asset quantity traded.",
+	}, []string{"instrument"})
+	tradedNotional = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "clob_traded_notional_total",
+		Help: "Quote asset amount traded.",
+	}, []string{"instrument"})
+	ordersArchived = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "clob_orders_archived_total",
+		Help: "Final orders moved from the live book to the order history.",
+	})
+	matchingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "clob_matching_duration_seconds",
+		Help:    "Time spent matching an incoming order against the book.",
+		Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+	}, []string{"instrument"})
+)
+
+// Rejection reasons other than risk rules
+const (
+	rejectInvalid           = "invalid_request"
+	rejectNotFound          = "instrument_not_found"
+	rejectTradingStatus     = "trading_status"
+	rejectHalted            = "halted"
+	rejectInsufficientFunds = "insufficient_funds"
+)
+
+func symbol(instrument InstrumentWithAssetsSchema) string {
+	return instrument.BaseAssetCode + "/" + instrument.QuoteAssetCode
+}
+
+func observeTrade(instrument InstrumentWithAssetsSchema, quantity, price decimal.Decimal) {
+	trades.WithLabelValues(symbol(instrument)).Inc()
+	tradedVolume.WithLabelValues(symbol(instrument)).Add(quantity.InexactFloat64())
+	tradedNotional.WithLabelValues(symbol(instrument)).Add(quantity.Mul(price).InexactFloat64())
+}
+
+// ObserveAuction records the trades of a committed auction
+func ObserveAuction(instrument InstrumentWithAssetsSchema, result auction.Result) {
+	if result.Price == nil {
+		return
+	}
+	for _, fill := range result.Fills {
+		observeTrade(instrument, fill.Quantity, *result.Price)
+	}
+}
+
+// ObserveCanceled records orders canceled by a committed unit of work
+func ObserveCanceled(instrument InstrumentWithAssetsSchema, count int) {
+	ordersCanceled.WithLabelValues(symbol(instrument)).Add(float64(count))
+}
+
+// OpenOrdersCollector exports the working orders per instrument and side,
+// counted on the store when scraped
+type OpenOrdersCollector struct {
+	store storage.Store
+	desc  *prometheus.Desc
+}
+
+func NewOpenOrdersCollector(store storage.Store) *OpenOrdersCollector {
+	return &OpenOrdersCollector{
+		store: store,
+		desc:  prometheus.NewDesc("clob_open_orders", "Working orders on the book.", []string{"instrument", "side"}, nil),
+	}
+}
+
+func (o *OpenOrdersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- o.desc
+}
+
+func (o *OpenOrdersCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := o.store.WithTx(ctx, func(tx storage.Tx) error {
+		instruments, err := tx.Instruments().List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, instrument := range instruments {
+			for _, side := range []OrderType{Buy, Sell} {
+				count, err := tx.Orders().Count(ctx, storage.OrderFilter{
+					InstrumentId: &instrument.Id,
+					Type:         &side,
+					Statuses:     storage.WorkingStatuses,
+				})
+				if err != nil {
+					return err
+				}
+				ch <- prometheus.MustNewConstMetric(o.desc, prometheus.GaugeValue, float64(count), symbol(instrument), string(side))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("Failed to count open orders", "error", err)
+	}
+}
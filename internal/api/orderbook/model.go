@@ -1,42 +1,38 @@
 package orderbook
 
-import (
-	"github.com/google/uuid"
-	"github.com/shopspring/decimal"
-)
+import "github.com/JhonesBR/go-clob/internal/storage"
 
 // Representative (schemas will be used for validation and documentation)
 
-type Instrument struct {
-	Id           uuid.UUID `json:"id"`
-	BaseAssetId  uuid.UUID `json:"base_asset_id"`
-	QuoteAssetId uuid.UUID `json:"quote_asset_id"`
-}
+type Instrument = storage.Instrument
+
+type TradingStatus = storage.TradingStatus
+
+const (
+	TradingPreOpen    = storage.TradingPreOpen
+	TradingOpen       = storage.TradingOpen
+	TradingAuction    = storage.TradingAuction
+	TradingHalted     = storage.TradingHalted
+	TradingCancelOnly = storage.TradingCancelOnly
+	TradingClosed     = storage.TradingClosed
+)
 
-type OrderType string
+type OrderType = storage.OrderType
 
 const (
-	Buy  OrderType = "buy"
-	Sell OrderType = "sell"
+	Buy  = storage.Buy
+	Sell = storage.Sell
 )
 
-type OrderStatus string
+type OrderStatus = storage.OrderStatus
 
 const (
-	Open            OrderStatus = "open"
-	PartiallyFilled OrderStatus = "partially_filled"
-	FullFilled      OrderStatus = "full_filled"
-	Canceled        OrderStatus = "canceled"
+	Open            = storage.Open
+	PartiallyFilled = storage.PartiallyFilled
+	FullFilled      = storage.FullFilled
+	Canceled        = storage.Canceled
 )
 
-type OrderBook struct {
-	Id               uuid.UUID       `json:"id"`
-	AccountId        uuid.UUID       `json:"account_id"`
-	InstrumentId     uuid.UUID       `json:"instrument_id"`
-	Type             OrderType       `json:"type"`
-	Status           OrderStatus     `json:"status"`
-	Price            decimal.Decimal `json:"price"`
-	TotalQuantity    decimal.Decimal `json:"total_quantity"`
-	FilledQuantity   decimal.Decimal `json:"filled_quantity"`
-	CreatedAt        string          `json:"created_at"`
-}
+type OrderBook = storage.Order
+
+type Trade = storage.Trade
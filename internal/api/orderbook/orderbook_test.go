@@ -0,0 +1,762 @@
+package orderbook_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/reconcile"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/storage/memory"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// harness runs the order book routes against an in-memory store, tracking
+// every deposit so the supply of each asset can be checked
+type harness struct {
+	t          testing.TB
+	app        *fiber.App
+	store      *memory.Store
+	service    *orderbook.Service
+	instrument storage.Instrument
+	assets     map[string]storage.Asset
+	accounts   map[string]uuid.UUID
+	deposits   map[uuid.UUID]decimal.Decimal
+}
+
+func newHarness(t testing.TB) *harness {
+	t.Helper()
+
+	// A breaker that never halts, matching alone is under test
+	breakerConfig := circuitbreaker.DefaultConfig()
+	breakerConfig.ThresholdPercent = decimal.NewFromInt(1_000_000)
+	return newHarnessWithBreaker(t, breakerConfig)
+}
+
+func newHarnessWithBreaker(t testing.TB, breakerConfig circuitbreaker.Config) *harness {
+	t.Helper()
+
+	// A clock moving forward on every read keeps time priority deterministic
+	store := memory.NewEmpty()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.SetClock(func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	})
+	btc := store.AddAsset("BTC", "Bitcoin")
+	brl := store.AddAsset("BRL", "Brazilian Real")
+	instrument := store.AddInstrument(btc, brl)
+
+	// No risk limits
+	service := orderbook.NewService(store, nil, risk.NewEngine(risk.Config{}), circuitbreaker.New(breakerConfig))
+	app := fiber.New(fiber.Config{ErrorHandler: helper.ErrorHandler})
+	account.InitializeRoutes(app, store)
+	orderbook.InitializeRoutes(app, service)
+
+	return &harness{
+		t:          t,
+		app:        app,
+		store:      store,
+		service:    service,
+		instrument: instrument,
+		assets:     map[string]storage.Asset{"BTC": btc, "BRL": brl},
+		accounts:   map[string]uuid.UUID{},
+		deposits:   map[uuid.UUID]decimal.Decimal{},
+	}
+}
+
+func (h *harness) request(method, path string, body any) (int, []byte) {
+	h.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			h.t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.app.Test(req)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return resp.StatusCode, data
+}
+
+// fund creates the account when needed and deposits the amount
+func (h *harness) fund(name, assetCode, amount string) uuid.UUID {
+	h.t.Helper()
+
+	id, ok := h.accounts[name]
+	if !ok {
+		status, body := h.request("POST", "/v1/accounts", fiber.Map{"name": name})
+		if status != fiber.StatusCreated {
+			h.t.Fatalf("create account %s: %d %s", name, status, body)
+		}
+		var created account.CreateAccountResponseSchema
+		if err := json.Unmarshal(body, &created); err != nil {
+			h.t.Fatal(err)
+		}
+		id = uuid.MustParse(created.Id)
+		h.accounts[name] = id
+	}
+
+	status, body := h.request("POST", "/v1/accounts/"+id.String()+"/charge", fiber.Map{"asset_code": assetCode, "amount": amount})
+	if status != fiber.StatusOK {
+		h.t.Fatalf("charge %s %s: %d %s", name, assetCode, status, body)
+	}
+	asset := h.assets[assetCode]
+	h.deposits[asset.Id] = h.deposits[asset.Id].Add(decimal.RequireFromString(amount))
+	return id
+}
+
+func (h *harness) place(name string, side orderbook.OrderType, price, quantity string) int {
+	h.t.Helper()
+
+	status, _ := h.request("POST", "/v1/order_book", fiber.Map{
+		"account_id": h.accounts[name],
+		"asset_code": "BTC",
+		"quantity":   quantity,
+		"price":      price,
+		"order_type": side,
+	})
+	return status
+}
+
+func (h *harness) cancel(id uuid.UUID) int {
+	h.t.Helper()
+
+	status, _ := h.request("POST", "/v1/order_book/"+id.String()+"/cancel", nil)
+	return status
+}
+
+// orders returns every order of the account, or of everyone with uuid.Nil, in time priority
+func (h *harness) orders(accountId uuid.UUID) []storage.Order {
+	h.t.Helper()
+
+	var filter storage.OrderFilter
+	if accountId != uuid.Nil {
+		filter.AccountId = &accountId
+	}
+	var orders []storage.Order
+	err := h.store.WithTx(context.Background(), func(tx storage.Tx) error {
+		var err error
+		orders, err = tx.Orders().List(context.Background(), filter, storage.Page{})
+		return err
+	})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return orders
+}
+
+func (h *harness) balance(name, assetCode string) decimal.Decimal {
+	h.t.Helper()
+
+	var balance *decimal.Decimal
+	err := h.store.WithTx(context.Background(), func(tx storage.Tx) error {
+		var err error
+		balance, err = tx.Balances().Get(context.Background(), h.accounts[name], h.assets[assetCode].Id)
+		return err
+	})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	if balance == nil {
+		return decimal.Decimal{}
+	}
+	return *balance
+}
+
+// checkInvariants verifies that no asset was created or destroyed (balances
+// plus funds reserved by working orders equal the deposits), that fills are
+// consistent with statuses and that the book is not left crossed
+func (h *harness) checkInvariants() {
+	h.t.Helper()
+
+	supply := map[uuid.UUID]decimal.Decimal{}
+	for name := range h.accounts {
+		for code, asset := range h.assets {
+			supply[asset.Id] = supply[asset.Id].Add(h.balance(name, code))
+		}
+	}
+
+	for _, order := range h.orders(uuid.Nil) {
+		remaining := order.TotalQuantity.Sub(order.FilledQuantity)
+		if remaining.IsNegative() || order.FilledQuantity.IsNegative() {
+			h.t.Fatalf("order %s filled %s of %s", order.Id, order.FilledQuantity, order.TotalQuantity)
+		}
+
+		switch order.Status {
+		case orderbook.Open:
+			if !order.FilledQuantity.IsZero() {
+				h.t.Fatalf("open order %s has fills", order.Id)
+			}
+		case orderbook.PartiallyFilled:
+			if order.FilledQuantity.IsZero() || remaining.IsZero() {
+				h.t.Fatalf("partially filled order %s filled %s of %s", order.Id, order.FilledQuantity, order.TotalQuantity)
+			}
+		case orderbook.FullFilled:
+			if !remaining.IsZero() {
+				h.t.Fatalf("full filled order %s has %s remaining", order.Id, remaining)
+			}
+		}
+		if order.Status != orderbook.Open && order.Status != orderbook.PartiallyFilled {
+			continue
+		}
+
+		// Working orders keep their remaining funds reserved
+		if order.Type == orderbook.Buy {
+			supply[h.instrument.QuoteAssetId] = supply[h.instrument.QuoteAssetId].Add(remaining.Mul(order.Price))
+		} else {
+			supply[h.instrument.BaseAssetId] = supply[h.instrument.BaseAssetId].Add(remaining)
+		}
+	}
+
+	for code, asset := range h.assets {
+		if !supply[asset.Id].Equal(h.deposits[asset.Id]) {
+			h.t.Fatalf("%s supply is %s, deposited %s", code, supply[asset.Id], h.deposits[asset.Id])
+		}
+	}
+
+	var bestBid, bestAsk *decimal.Decimal
+	err := h.store.WithTx(context.Background(), func(tx storage.Tx) error {
+		var err error
+		bestBid, bestAsk, err = tx.Orders().BestPrices(context.Background(), h.instrument.Id)
+		return err
+	})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	if bestBid != nil && bestAsk != nil && bestBid.GreaterThanOrEqual(*bestAsk) {
+		h.t.Fatalf("book is crossed, best bid %s and best ask %s", bestBid, bestAsk)
+	}
+
+	// The reconciliation job must agree, per asset and per account
+	report, err := reconcile.Run(context.Background(), h.store)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	if !report.Balanced {
+		h.t.Fatalf("reconciliation is not balanced: %+v", report)
+	}
+}
+
+type orderStep struct {
+	account  string
+	side     orderbook.OrderType
+	price    string
+	quantity string
+	status   int
+}
+
+type orderResult struct {
+	status orderbook.OrderStatus
+	filled string
+}
+
+func TestMatching(t *testing.T) {
+	tests := []struct {
+		name     string
+		steps    []orderStep
+		orders   []orderResult
+		balances map[string]map[string]string
+	}{
+		{
+			name: "orders that do not cross rest on the book",
+			steps: []orderStep{
+				{"bob", orderbook.Sell, "110", "1", fiber.StatusNoContent},
+				{"alice", orderbook.Buy, "100", "1", fiber.StatusNoContent},
+			},
+			orders: []orderResult{{orderbook.Open, "0"}, {orderbook.Open, "0"}},
+			balances: map[string]map[string]string{
+				"alice": {"BRL": "900", "BTC": "0"},
+				"bob":   {"BRL": "0", "BTC": "9"},
+			},
+		},
+		{
+			name: "full fill at the resting sell price",
+			steps: []orderStep{
+				{"bob", orderbook.Sell, "100", "1", fiber.StatusNoContent},
+				{"alice", orderbook.Buy, "100", "1", fiber.StatusNoContent},
+			},
+			orders: []orderResult{{orderbook.FullFilled, "1"}, {orderbook.FullFilled, "1"}},
+			balances: map[string]map[string]string{
+				"alice": {"BRL": "900", "BTC": "1"},
+				"bob":   {"BRL": "100", "BTC": "9"},
+			},
+		},
+		{
+			name: "buyer gets the price improvement back",
+			steps: []orderStep{
+				{"bob", orderbook.Sell, "90", "1", fiber.StatusNoContent},
+				{"alice", orderbook.Buy, "100", "1", fiber.StatusNoContent},
+			},
+			orders: []orderResult{{orderbook.FullFilled, "1"}, {orderbook.FullFilled, "1"}},
+			balances: map[string]map[string]string{
+				"alice": {"BRL": "910", "BTC": "1"},
+				"bob":   {"BRL": "90", "BTC": "9"},
+			},
+		},
+		{
+			name: "incoming sell executes at its own price",
+			steps: []orderStep{
+				{"alice", orderbook.Buy, "110", "1", fiber.StatusNoContent},
+				{"bob", orderbook.Sell, "100", "1", fiber.StatusNoContent},
+			},
+			orders: []orderResult{{orderbook.FullFilled, "1"}, {orderbook.FullFilled, "1"}},
+			balances: map[string]map[string]string{
+				"alice": {"BRL": "900", "BTC": "1"},
+				"bob":   {"BRL": "100", "BTC": "9"},
+			},
+		},
+		{
+			name: "partial fill leaves the remainder working",
+			steps: []orderStep{
+				{"bob", orderbook.Sell, "100", "3", fiber.StatusNoContent},
+				{"alice", orderbook.Buy, "100", "1", fiber.StatusNoContent},
+			},
+			orders: []orderResult{{orderbook.PartiallyFilled, "1"}, {orderbook.FullFilled, "1"}},
+			balances: map[string]map[string]string{
+				"alice": {"BRL": "900", "BTC": "1"},
+				"bob":   {"BRL": "100", "BTC": "7"},
+			},
+		},
+		{
+			name: "sweeps price levels in price priority",
+			steps: []orderStep{
+				{"bob", orderbook.Sell, "101", "1", fiber.StatusNoContent},
+				{"bob", orderbook.Sell, "100", "1", fiber.StatusNoContent},
+				{"alice", orderbook.Buy, "101", "2", fiber.StatusNoContent},
+			},
+			orders: []orderResult{{orderbook.FullFilled, "1"}, {orderbook.FullFilled, "1"}, {orderbook.FullFilled, "2"}},
+			balances: map[string]map[string]string{
+				"alice": {"BRL": "799", "BTC": "2"},
+				"bob":   {"BRL": "201", "BTC": "8"},
+			},
+		},
+		{
+			name: "time priority within a price level",
+			steps: []orderStep{
+				{"bob", orderbook.Sell, "100", "1", fiber.StatusNoContent},
+				{"carol", orderbook.Sell, "100", "1", fiber.StatusNoContent},
+				{"alice", orderbook.Buy, "100", "1", fiber.StatusNoContent},
+			},
+			orders: []orderResult{{orderbook.FullFilled, "1"}, {orderbook.Open, "0"}, {orderbook.FullFilled, "1"}},
+			balances: map[string]map[string]string{
+				"alice": {"BRL": "900", "BTC": "1"},
+				"bob":   {"BRL": "100", "BTC": "9"},
+				"carol": {"BRL": "0", "BTC": "9"},
+			},
+		},
+		{
+			name: "orders above the balance are rejected",
+			steps: []orderStep{
+				{"alice", orderbook.Buy, "100", "11", fiber.StatusPaymentRequired},
+				{"bob", orderbook.Sell, "100", "11", fiber.StatusPaymentRequired},
+			},
+			orders: []orderResult{},
+			balances: map[string]map[string]string{
+				"alice": {"BRL": "1000", "BTC": "0"},
+				"bob":   {"BRL": "0", "BTC": "10"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHarness(t)
+			h.fund("alice", "BRL", "1000")
+			h.fund("bob", "BTC", "10")
+			h.fund("carol", "BTC", "10")
+
+			for i, step := range tt.steps {
+				if status := h.place(step.account, step.side, step.price, step.quantity); status != step.status {
+					t.Fatalf("step %d: got status %d, want %d", i, status, step.status)
+				}
+				h.checkInvariants()
+			}
+
+			orders := h.orders(uuid.Nil)
+			if len(orders) != len(tt.orders) {
+				t.Fatalf("got %d orders, want %d", len(orders), len(tt.orders))
+			}
+			for i, want := range tt.orders {
+				if orders[i].Status != want.status || !orders[i].FilledQuantity.Equal(decimal.RequireFromString(want.filled)) {
+					t.Errorf("order %d: got %s filled %s, want %s filled %s", i, orders[i].Status, orders[i].FilledQuantity, want.status, want.filled)
+				}
+			}
+
+			for name, balances := range tt.balances {
+				for code, want := range balances {
+					if got := h.balance(name, code); !got.Equal(decimal.RequireFromString(want)) {
+						t.Errorf("%s %s balance: got %s, want %s", name, code, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCancelReleasesRemainingFunds(t *testing.T) {
+	h := newHarness(t)
+	alice := h.fund("alice", "BRL", "1000")
+	h.fund("bob", "BTC", "10")
+
+	h.place("alice", orderbook.Buy, "100", "3")
+	h.place("bob", orderbook.Sell, "100", "1")
+	h.checkInvariants()
+
+	order := h.orders(alice)[0]
+	if status := h.cancel(order.Id); status != fiber.StatusNoContent {
+		t.Fatalf("cancel: got status %d", status)
+	}
+	h.checkInvariants()
+
+	if got := h.orders(alice)[0].Status; got != orderbook.Canceled {
+		t.Errorf("got status %s, want %s", got, orderbook.Canceled)
+	}
+	if got := h.balance("alice", "BRL"); !got.Equal(decimal.NewFromInt(900)) {
+		t.Errorf("alice BRL balance: got %s, want 900", got)
+	}
+	if got := h.balance("alice", "BTC"); !got.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("alice BTC balance: got %s, want 1", got)
+	}
+
+	// Canceling again is refused and changes nothing
+	if status := h.cancel(order.Id); status != fiber.StatusConflict {
+		t.Errorf("second cancel: got status %d, want %d", status, fiber.StatusConflict)
+	}
+	h.checkInvariants()
+	if got := h.balance("alice", "BRL"); !got.Equal(decimal.NewFromInt(900)) {
+		t.Errorf("alice BRL balance after second cancel: got %s, want 900", got)
+	}
+
+	if status := h.cancel(uuid.New()); status != fiber.StatusNotFound {
+		t.Errorf("cancel unknown order: got status %d, want %d", status, fiber.StatusNotFound)
+	}
+}
+
+func TestHaltCancelsCrossingRemainder(t *testing.T) {
+	h := newHarnessWithBreaker(t, circuitbreaker.DefaultConfig())
+	h.fund("alice", "BTC", "3")
+	h.fund("bob", "BRL", "100")
+	dave := h.fund("dave", "BRL", "300")
+
+	h.place("alice", orderbook.Sell, "100", "1")
+	h.place("bob", orderbook.Buy, "100", "1")
+	h.place("alice", orderbook.Sell, "100", "1")
+	h.place("alice", orderbook.Sell, "150", "1")
+	h.checkInvariants()
+
+	// The trade at 150 moves the price 50%: it halts the instrument and the
+	// remainder, which would cross the ask at 150, does not rest on the book
+	if status := h.place("dave", orderbook.Buy, "150", "2"); status != fiber.StatusNoContent {
+		t.Fatalf("place: got status %d", status)
+	}
+	h.checkInvariants()
+
+	order := h.orders(dave)[0]
+	if order.Status != orderbook.Canceled || !order.FilledQuantity.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("got order %s filled %s, want canceled filled 1", order.Status, order.FilledQuantity)
+	}
+	if got := h.balance("dave", "BRL"); !got.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("dave BRL balance: got %s, want 200", got)
+	}
+	if status := h.place("dave", orderbook.Buy, "150", "1"); status != fiber.StatusConflict {
+		t.Errorf("place while halted: got status %d, want %d", status, fiber.StatusConflict)
+	}
+}
+
+// TestFilledOrderDoesNotHalt checks that the resting orders left untouched by
+// a filled order do not reach the circuit breaker
+func TestFilledOrderDoesNotHalt(t *testing.T) {
+	h := newHarnessWithBreaker(t, circuitbreaker.DefaultConfig())
+	h.fund("alice", "BTC", "4")
+	h.fund("bob", "BRL", "100")
+	dave := h.fund("dave", "BRL", "250")
+
+	h.place("alice", orderbook.Sell, "100", "1")
+	h.place("bob", orderbook.Buy, "100", "1")
+	h.place("alice", orderbook.Sell, "100", "1")
+	h.place("alice", orderbook.Sell, "150", "1")
+
+	// Filled at 100, the ask at 150 is not traded and does not halt
+	if status := h.place("dave", orderbook.Buy, "150", "1"); status != fiber.StatusNoContent {
+		t.Fatalf("place: got status %d", status)
+	}
+	h.place("alice", orderbook.Sell, "100", "1")
+	if status := h.place("dave", orderbook.Buy, "100", "1"); status != fiber.StatusNoContent {
+		t.Fatalf("place after the fill: got status %d, want %d", status, fiber.StatusNoContent)
+	}
+	h.checkInvariants()
+
+	for _, order := range h.orders(dave) {
+		if order.Status != orderbook.FullFilled {
+			t.Errorf("got order %s at %s, want it full filled", order.Status, order.Price)
+		}
+	}
+}
+
+// TestRolledBackPlacementKeepsPendingAuction checks that the auction ending
+// a halt is still run when the first placement after the halt rolls back
+func TestRolledBackPlacementKeepsPendingAuction(t *testing.T) {
+	breakerConfig := circuitbreaker.DefaultConfig()
+	breakerConfig.CoolOff = 10 * time.Millisecond
+	breakerConfig.ResumeWithAuction = true
+	h := newHarnessWithBreaker(t, breakerConfig)
+	h.fund("alice", "BTC", "2")
+	bob := h.fund("bob", "BRL", "300")
+
+	// The trade at 150 halts the instrument, the rest of the buy order waits
+	// for the auction
+	h.place("alice", orderbook.Sell, "100", "1")
+	h.place("bob", orderbook.Buy, "100", "1")
+	h.place("alice", orderbook.Sell, "150", "1")
+	h.place("bob", orderbook.Buy, "150", "1")
+	time.Sleep(2 * breakerConfig.CoolOff)
+
+	_, err := h.service.Place(context.Background(), orderbook.PlaceOrderSchema{
+		AccountId: bob,
+		AssetCode: "BTC",
+		Quantity:  decimal.NewFromInt(1),
+		Price:     decimal.NewFromInt(50),
+		OrderType: orderbook.Buy,
+	}, func(tx storage.Tx, created orderbook.OrderBook) error {
+		return fmt.Errorf("rolled back")
+	})
+	if err == nil {
+		t.Fatal("placement was not rolled back")
+	}
+
+	if status := h.place("bob", orderbook.Buy, "50", "1"); status != fiber.StatusNoContent {
+		t.Fatalf("place: got status %d", status)
+	}
+	h.checkInvariants()
+	if got := h.balance("bob", "BTC"); !got.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("bob BTC balance: got %s, want 2", got)
+	}
+}
+
+// get decodes the body of a GET request answered with 200
+func (h *harness) get(path string, into any) {
+	h.t.Helper()
+
+	status, body := h.request("GET", path, nil)
+	if status != fiber.StatusOK {
+		h.t.Fatalf("get %s: %d %s", path, status, body)
+	}
+	if err := json.Unmarshal(body, into); err != nil {
+		h.t.Fatal(err)
+	}
+}
+
+func TestOrderDetailAndTimeline(t *testing.T) {
+	h := newHarness(t)
+	alice := h.fund("alice", "BRL", "1000")
+	h.fund("bob", "BTC", "10")
+
+	h.place("alice", orderbook.Buy, "100", "3")
+	h.place("bob", orderbook.Sell, "100", "1")
+	original := h.orders(alice)[0]
+	bobOrder := h.orders(h.accounts["bob"])[0]
+
+	var detail orderbook.OrderDetailSchema
+	h.get("/v1/order_book/"+original.Id.String(), &detail)
+	if !detail.RemainingQuantity.Equal(decimal.NewFromInt(2)) || !detail.ReservedAmount.Equal(decimal.NewFromInt(200)) ||
+		detail.ReservedAssetCode != "BRL" || detail.CancelReason != nil {
+		t.Errorf("detail of the partially filled order is %+v", detail)
+	}
+	if detail.AverageFillPrice == nil || !detail.AverageFillPrice.Equal(decimal.NewFromInt(100)) ||
+		len(detail.Fills) != 1 || detail.Fills[0].CounterOrderId != bobOrder.Id {
+		t.Errorf("fills of the partially filled order are %+v at %v", detail.Fills, detail.AverageFillPrice)
+	}
+
+	// Amending cancels the order, the timeline links it to its replacement
+	placement, err := h.service.Replace(context.Background(), original.Id, decimal.NewFromInt(99), decimal.NewFromInt(3), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replacement := placement.Order.Id
+	h.get("/v1/order_book/"+original.Id.String(), &detail)
+	if detail.Status != orderbook.Canceled || detail.CancelReason == nil || *detail.CancelReason != eventlog.CancelReplaced ||
+		!detail.ReservedAmount.IsZero() {
+		t.Errorf("detail of the amended order is %+v", detail)
+	}
+	var timeline orderbook.OrderTimelineSchema
+	h.get("/v1/order_book/"+original.Id.String()+"/events", &timeline)
+	steps := timeline.Events
+	if len(steps) != 3 || steps[0].Type != orderbook.OrderEventAccepted || steps[1].Type != orderbook.OrderEventPartiallyFilled ||
+		steps[2].Type != orderbook.OrderEventAmended || steps[2].ReplacedBy == nil || *steps[2].ReplacedBy != replacement {
+		t.Fatalf("timeline of the amended order is %+v", steps)
+	}
+	if !steps[1].FilledQuantity.Equal(decimal.NewFromInt(1)) || *steps[1].CounterOrderId != bobOrder.Id {
+		t.Errorf("fill of the amended order is %+v", steps[1])
+	}
+
+	h.get("/v1/order_book/"+replacement.String(), &detail)
+	if !detail.ReservedAmount.Equal(decimal.NewFromInt(198)) || detail.AverageFillPrice != nil || len(detail.Fills) != 0 {
+		t.Errorf("detail of the replacement is %+v", detail)
+	}
+	if status := h.cancel(replacement); status != fiber.StatusNoContent {
+		t.Fatalf("cancel: got status %d", status)
+	}
+	h.get("/v1/order_book/"+replacement.String()+"/events", &timeline)
+	steps = timeline.Events
+	if len(steps) != 2 || steps[0].Replaces == nil || *steps[0].Replaces != original.Id ||
+		steps[1].Type != orderbook.OrderEventCanceled || *steps[1].Reason != eventlog.CancelRequested {
+		t.Fatalf("timeline of the replacement is %+v", steps)
+	}
+
+	h.get("/v1/order_book/"+bobOrder.Id.String()+"/events", &timeline)
+	if steps = timeline.Events; len(steps) != 2 || steps[1].Type != orderbook.OrderEventFilled {
+		t.Errorf("timeline of the filled order is %+v", steps)
+	}
+
+	if status, _ := h.request("GET", "/v1/order_book/"+uuid.NewString(), nil); status != fiber.StatusNotFound {
+		t.Errorf("unknown order: got status %d, want %d", status, fiber.StatusNotFound)
+	}
+	if status, _ := h.request("GET", "/v1/order_book/nope/events", nil); status != fiber.StatusBadRequest {
+		t.Errorf("invalid id: got status %d, want %d", status, fiber.StatusBadRequest)
+	}
+}
+
+func TestArchiveMovesFinalOrdersToHistory(t *testing.T) {
+	h := newHarness(t)
+	alice := h.fund("alice", "BRL", "1000")
+	bob := h.fund("bob", "BTC", "10")
+
+	h.place("alice", orderbook.Buy, "100", "1")
+	h.place("alice", orderbook.Buy, "90", "1")
+	h.place("bob", orderbook.Sell, "100", "1")
+	h.place("bob", orderbook.Sell, "110", "1")
+	canceled := h.orders(alice)[1]
+	if status := h.cancel(canceled.Id); status != fiber.StatusNoContent {
+		t.Fatalf("cancel: got status %d", status)
+	}
+
+	// One order per unit of work still archives every final order
+	archived, err := orderbook.Archive(context.Background(), h.store, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archived != 3 {
+		t.Fatalf("archived %d orders, want 3", archived)
+	}
+	h.checkInvariants()
+
+	live := h.orders(uuid.Nil)
+	if len(live) != 1 || live[0].AccountId != bob || live[0].Status != orderbook.Open {
+		t.Fatalf("live book holds %+v, want the working sell order", live)
+	}
+	var history helper.Pagination[orderbook.OrderBookShowSchema]
+	h.get("/v1/order_history?account_id="+alice.String()+"&sort=-price", &history)
+	if len(history.Items) != 2 || history.Items[0].Status != orderbook.FullFilled || history.Items[1].Id != canceled.Id {
+		t.Fatalf("history of alice is %+v", history.Items)
+	}
+	h.get("/v1/order_history?status=canceled", &history)
+	if len(history.Items) != 1 || history.Items[0].Id != canceled.Id {
+		t.Fatalf("canceled orders in the history are %+v", history.Items)
+	}
+
+	// Archived orders are still found by id
+	var detail orderbook.OrderDetailSchema
+	h.get("/v1/order_book/"+canceled.Id.String(), &detail)
+	if detail.Status != orderbook.Canceled {
+		t.Errorf("archived order is %+v", detail)
+	}
+	if status := h.cancel(canceled.Id); status != fiber.StatusConflict {
+		t.Errorf("cancel archived order: got status %d, want %d", status, fiber.StatusConflict)
+	}
+
+	if archived, err = orderbook.Archive(context.Background(), h.store, 1); err != nil || archived != 0 {
+		t.Errorf("archiving again moved %d orders (%v), want none", archived, err)
+	}
+}
+
+// runStream plays an order stream decoded from data, four bytes per operation,
+// checking the invariants after each one, and returns the final balances
+func runStream(t testing.TB, data []byte) map[string]decimal.Decimal {
+	h := newHarness(t)
+	names := []string{"alice", "bob", "carol"}
+	for _, name := range names {
+		h.fund(name, "BRL", "1000000")
+		h.fund(name, "BTC", "1000")
+	}
+
+	const maxOperations = 64
+	for i := 0; i+4 <= len(data) && i/4 < maxOperations; i += 4 {
+		name := names[int(data[i])%len(names)]
+
+		// One in five operations cancels a working order of the account
+		if data[i+1]%5 == 0 {
+			var working []storage.Order
+			for _, order := range h.orders(h.accounts[name]) {
+				if order.Status == orderbook.Open || order.Status == orderbook.PartiallyFilled {
+					working = append(working, order)
+				}
+			}
+			if len(working) > 0 {
+				h.cancel(working[int(data[i+2])%len(working)].Id)
+			}
+		} else {
+			side := orderbook.Buy
+			if data[i+1]%2 == 0 {
+				side = orderbook.Sell
+			}
+			price := fmt.Sprint(95 + int(data[i+2])%11)
+			quantity := fmt.Sprint(1 + int(data[i+3])%5)
+			if status := h.place(name, side, price, quantity); status != fiber.StatusNoContent {
+				t.Fatalf("place %s %s %s@%s: got status %d", name, side, quantity, price, status)
+			}
+		}
+		h.checkInvariants()
+	}
+
+	balances := map[string]decimal.Decimal{}
+	for _, name := range names {
+		for code := range h.assets {
+			balances[name+" "+code] = h.balance(name, code)
+		}
+	}
+	return balances
+}
+
+func FuzzOrderStream(f *testing.F) {
+	f.Add([]byte{0, 1, 5, 2, 1, 2, 5, 2})
+	f.Add([]byte{0, 1, 10, 4, 1, 2, 0, 4, 2, 2, 3, 1, 0, 5, 0, 0})
+	f.Add([]byte{1, 2, 0, 4, 1, 4, 2, 4, 2, 1, 10, 4, 2, 3, 10, 4, 0, 10, 0, 0, 1, 6, 1, 0})
+	f.Add(bytes.Repeat([]byte{0, 1, 7, 3, 1, 2, 3, 3, 2, 3, 5, 1}, 5))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Replaying the same stream ends in the same balances
+		first := runStream(t, data)
+		second := runStream(t, data)
+		for key, balance := range first {
+			if !balance.Equal(second[key]) {
+				t.Fatalf("%s balance differs between runs: %s and %s", key, balance, second[key])
+			}
+		}
+	})
+}
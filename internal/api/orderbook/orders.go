@@ -0,0 +1,463 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/auction"
+	"github.com/JhonesBR/go-clob/internal/audit"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/ratelimit"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/tracing"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Service places, cancels and replaces orders whichever transport they come
+// from, the REST handlers and the FIX gateway go through it so orders are
+// checked, matched and accounted for the same way
+type Service struct {
+	store        storage.Store
+	orderToTrade *ratelimit.OrderToTradeMonitor
+	riskEngine   *risk.Engine
+	breaker      *circuitbreaker.Breaker
+}
+
+// NewService returns the order service, orderToTrade may be nil when the
+// order-to-trade ratio is not monitored
+func NewService(store storage.Store, orderToTrade *ratelimit.OrderToTradeMonitor, riskEngine *risk.Engine, breaker *circuitbreaker.Breaker) *Service {
+	return &Service{
+		store:        store,
+		orderToTrade: orderToTrade,
+		riskEngine:   riskEngine,
+		breaker:      breaker,
+	}
+}
+
+// Rejection refuses an order or a cancel, its unit of work is rolled back.
+// Status is the HTTP status the REST API answers with, Details are added to
+// its problem document.
+type Rejection struct {
+	Status  int
+	Code    helper.Code
+	Message string
+	Details map[string]any
+	// reasons label the rejected orders metric, one per failed check
+	reasons []string
+}
+
+func (r Rejection) Error() string {
+	return r.Message
+}
+
+// As makes a rejection a helper.Error, so the REST API answers it like any
+// service error
+func (r Rejection) As(target any) bool {
+	serviceErr, ok := target.(*helper.Error)
+	if ok {
+		*serviceErr = helper.Error{Status: r.Status, Code: r.Code, Message: r.Message, Details: r.Details}
+	}
+	return ok
+}
+
+// Fill is a trade of an incoming order against a resting one
+type Fill struct {
+	Match    OrderBook
+	Quantity decimal.Decimal
+	Price    decimal.Decimal
+}
+
+// Placement is the outcome of an accepted order
+type Placement struct {
+	// Order is the order as left by matching
+	Order      OrderBook
+	Instrument InstrumentWithAssetsSchema
+	Fills      []Fill
+	// Auction uncrossed the book before the order when a halt ended
+	Auction auction.Result
+
+	// trades reach the circuit breaker once the placement committed
+	trades *circuitbreaker.Trades
+}
+
+// Place checks the order, reserves its funds and matches it. onCreated, when
+// not nil, runs in the unit of work right after the order is stored and
+// before it is matched, so transports can link it to their own state.
+func (s *Service) Place(ctx context.Context, order PlaceOrderSchema, onCreated func(tx storage.Tx, created OrderBook) error) (Placement, error) {
+	// Invalid orders never reach the event log
+	if err := helper.ValidateInput(&order); err != nil {
+		ordersRejected.WithLabelValues(rejectInvalid).Inc()
+		invalid := helper.Invalid(err)
+		return Placement{}, Rejection{Status: invalid.Status, Code: invalid.Code, Message: invalid.Message, Details: invalid.Details}
+	}
+
+	var placement Placement
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		placement, err = s.place(ctx, tx, order, nil, onCreated)
+		return err
+	})
+	if err != nil {
+		var rejection Rejection
+		if errors.As(err, &rejection) {
+			return Placement{}, s.reject(ctx, order, rejection)
+		}
+		return Placement{}, err
+	}
+
+	placement.trades.Commit()
+	s.observe(order.AccountId, placement)
+	return placement, nil
+}
+
+// Cancel cancels a working order releasing its reserved funds. onCanceled,
+// when not nil, runs in the unit of work after the order is canceled.
+func (s *Service) Cancel(ctx context.Context, id uuid.UUID, onCanceled func(tx storage.Tx, canceled OrderBook) error) (OrderBook, error) {
+	var canceled OrderBook
+	var instrument InstrumentWithAssetsSchema
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		if canceled, instrument, err = s.cancel(ctx, tx, id, eventlog.CancelRequested); err != nil {
+			return err
+		}
+		if onCanceled != nil {
+			return onCanceled(tx, canceled)
+		}
+		return nil
+	})
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	ObserveCanceled(instrument, 1)
+	return canceled, nil
+}
+
+// Replace cancels a working order and places one for the same account,
+// instrument and side at price in its stead, for quantity minus what the
+// original order already filled. It is atomic: when the new order is rejected
+// the original one is left untouched. onReplaced, when not nil, runs in the
+// unit of work once the new order is stored.
+func (s *Service) Replace(ctx context.Context, id uuid.UUID, price, quantity decimal.Decimal, onReplaced func(tx storage.Tx, canceled, created OrderBook) error) (Placement, error) {
+	var placement Placement
+	var instrument InstrumentWithAssetsSchema
+	var order *PlaceOrderSchema
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var canceled OrderBook
+		var err error
+		if canceled, instrument, err = s.cancel(ctx, tx, id, eventlog.CancelReplaced); err != nil {
+			return err
+		}
+		remaining := quantity.Sub(canceled.FilledQuantity)
+		if !remaining.IsPositive() {
+			return Rejection{Status: fiber.StatusUnprocessableEntity, Code: helper.CodeQuantityBelowFilled, Message: "Quantity must exceed the filled quantity"}
+		}
+
+		order = &PlaceOrderSchema{
+			AccountId: canceled.AccountId,
+			AssetCode: instrument.BaseAssetCode,
+			Quantity:  remaining,
+			Price:     price,
+			OrderType: canceled.Type,
+		}
+		placement, err = s.place(ctx, tx, *order, &canceled.Id, func(tx storage.Tx, created OrderBook) error {
+			if onReplaced != nil {
+				return onReplaced(tx, canceled, created)
+			}
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		// Only rejections of the new order are recorded as rejected orders
+		var rejection Rejection
+		if order != nil && errors.As(err, &rejection) {
+			return Placement{}, s.reject(ctx, *order, rejection)
+		}
+		return Placement{}, err
+	}
+
+	placement.trades.Commit()
+	ObserveCanceled(instrument, 1)
+	s.observe(order.AccountId, placement)
+	return placement, nil
+}
+
+// place places the order, replacing the one of id replaces when it is set
+func (s *Service) place(ctx context.Context, tx storage.Tx, order PlaceOrderSchema, replaces *uuid.UUID, onCreated func(tx storage.Tx, created OrderBook) error) (Placement, error) {
+	// Get instrument of order
+	spanCtx, span := tracing.Start(ctx, "orderbook.GetInstrument")
+	instrument, err := tx.Instruments().GetByBaseAssetCode(spanCtx, order.AssetCode)
+	tracing.End(span, err)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return Placement{}, Rejection{Status: fiber.StatusNotFound, Code: helper.CodeInstrumentNotFound, Message: "Instrument not found", reasons: []string{rejectNotFound}}
+		}
+		return Placement{}, err
+	}
+	tracing.SetAttributes(ctx, tracing.Instrument.String(symbol(instrument)), tracing.Side.String(string(order.OrderType)))
+
+	// Only open instruments accept new orders
+	if !instrument.TradingStatus.AcceptsOrders() {
+		return Placement{}, Rejection{
+			Status:  fiber.StatusConflict,
+			Code:    helper.CodeInstrumentNotTrading,
+			Message: fmt.Sprintf("Instrument is %s, new orders are not accepted", instrument.TradingStatus),
+			reasons: []string{rejectTradingStatus},
+		}
+	}
+
+	// Pre-trade risk checks
+	rejections, err := s.riskEngine.Evaluate(ctx, risk.Order{
+		AccountId:     order.AccountId,
+		InstrumentId:  instrument.Id,
+		BaseAssetId:   instrument.BaseAssetId,
+		BaseAssetCode: instrument.BaseAssetCode,
+		Side:          string(order.OrderType),
+		Price:         order.Price,
+		Quantity:      order.Quantity,
+	}, riskState{tx: tx})
+	if err != nil {
+		return Placement{}, err
+	}
+	if len(rejections) > 0 {
+		reasons := make([]string, 0, len(rejections))
+		for _, rejection := range rejections {
+			reasons = append(reasons, "risk_"+rejection.Rule)
+		}
+		return Placement{}, Rejection{
+			Status:  fiber.StatusUnprocessableEntity,
+			Code:    helper.CodeRiskCheckFailed,
+			Message: "Order rejected by risk checks",
+			Details: map[string]any{"reasons": rejections},
+			reasons: reasons,
+		}
+	}
+
+	// Halted instruments only accept orders that do not cross the book,
+	// unless the halt ends with an auction where orders accumulate
+	if s.breaker.Halted(instrument.Id) && !s.breaker.ResumesWithAuction() {
+		crosses, err := crossesBook(ctx, tx, instrument.Id, order.OrderType, order.Price)
+		if err != nil {
+			return Placement{}, err
+		}
+		if crosses {
+			return Placement{}, Rejection{
+				Status:  fiber.StatusConflict,
+				Code:    helper.CodeInstrumentHalted,
+				Message: "Instrument is halted, aggressive orders are rejected",
+				Details: map[string]any{"circuit_breaker": s.breaker.Status(instrument.Id)},
+				reasons: []string{rejectHalted},
+			}
+		}
+	}
+
+	var assetCode string
+	if order.OrderType == Buy {
+		assetCode = instrument.QuoteAssetCode
+	} else {
+		assetCode = order.AssetCode
+	}
+
+	// Verify if the account has the balance
+	balance, assetId, err := account.GetAccountBalance(ctx, tx, order.AccountId, &assetCode, nil)
+	if err != nil {
+		return Placement{}, err
+	}
+
+	// Verify if the account has the necessary balance
+	var necessaryBalance decimal.Decimal
+	if order.OrderType == Buy {
+		necessaryBalance = order.Quantity.Mul(order.Price)
+	} else {
+		necessaryBalance = order.Quantity
+	}
+	if balance == nil || balance.LessThan(necessaryBalance) {
+		return Placement{}, Rejection{Status: fiber.StatusPaymentRequired, Code: helper.CodeInsufficientFunds, Message: "Insufficient funds", reasons: []string{rejectInsufficientFunds}}
+	}
+
+	// Update balance from account
+	if err := account.UpdateAccountBalance(ctx, tx, order.AccountId, balance.Sub(necessaryBalance), *assetId); err != nil {
+		return Placement{}, err
+	}
+
+	// Uncross the book accumulated during a circuit breaker halt
+	placement := Placement{Instrument: instrument, trades: s.breaker.Begin(instrument.Id)}
+	if instrument.TradingStatus.Matches() && placement.trades.PendingAuction() {
+		if placement.Auction, err = RunAuction(ctx, tx, instrument); err != nil {
+			return Placement{}, err
+		}
+		if placement.Auction.Price != nil {
+			placement.trades.Record(*placement.Auction.Price)
+		}
+	}
+
+	// Create a new order
+	created, err := tx.Orders().Create(ctx, OrderBook{
+		AccountId:      order.AccountId,
+		InstrumentId:   instrument.Id,
+		Type:           order.OrderType,
+		Status:         Open,
+		Price:          order.Price,
+		TotalQuantity:  order.Quantity,
+		FilledQuantity: decimal.NewFromInt(0),
+	})
+	if err != nil {
+		return Placement{}, err
+	}
+	err = eventlog.Append(ctx, tx, eventlog.OrderAccepted, created.Id, eventlog.OrderAcceptedPayload{
+		OrderId:      created.Id,
+		AccountId:    order.AccountId,
+		InstrumentId: instrument.Id,
+		Side:         string(order.OrderType),
+		Price:        order.Price,
+		Quantity:     order.Quantity,
+		Replaces:     replaces,
+	})
+	if err != nil {
+		return Placement{}, err
+	}
+	err = audit.Record(ctx, tx, audit.Entry{
+		Actor:      audit.Account(order.AccountId),
+		Action:     audit.OrderPlaced,
+		EntityType: "order",
+		EntityId:   created.Id.String(),
+		After:      created,
+	})
+	if err != nil {
+		return Placement{}, err
+	}
+	if onCreated != nil {
+		if err := onCreated(tx, created); err != nil {
+			return Placement{}, err
+		}
+	}
+
+	// Match order
+	placement.Order = created
+	var halted bool
+	if instrument.TradingStatus.Matches() {
+		start := time.Now()
+		placement.Fills, halted, err = matchOrder(ctx, tx, created, instrument, placement.trades)
+		if err != nil {
+			return Placement{}, err
+		}
+		matchingDuration.WithLabelValues(symbol(instrument)).Observe(time.Since(start).Seconds())
+	}
+	for _, fill := range placement.Fills {
+		placement.Order.FilledQuantity = placement.Order.FilledQuantity.Add(fill.Quantity)
+	}
+	if placement.Order.FilledQuantity.Equal(placement.Order.TotalQuantity) {
+		placement.Order.Status = FullFilled
+	} else if placement.Order.FilledQuantity.IsPositive() {
+		placement.Order.Status = PartiallyFilled
+	}
+
+	// The remainder of an order stopped by the circuit breaker still crosses
+	// the book, it only rests when the halt ends with an auction
+	if halted && placement.Order.Status != FullFilled && !s.breaker.ResumesWithAuction() {
+		if err := CancelOrder(ctx, tx, placement.Order, eventlog.CancelHalted); err != nil {
+			return Placement{}, err
+		}
+		placement.Order.Status = Canceled
+	}
+	return placement, nil
+}
+
+func (s *Service) cancel(ctx context.Context, tx storage.Tx, id uuid.UUID, reason eventlog.CancelReason) (OrderBook, InstrumentWithAssetsSchema, error) {
+	// Get order
+	order, err := tx.Orders().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return OrderBook{}, InstrumentWithAssetsSchema{}, Rejection{Status: fiber.StatusNotFound, Code: helper.CodeOrderNotFound, Message: "Order not found"}
+		}
+		return OrderBook{}, InstrumentWithAssetsSchema{}, err
+	}
+
+	// Verify eligibility for cancelation
+	if err := verifyOrderCancelationEligibility(order); err != nil {
+		return OrderBook{}, InstrumentWithAssetsSchema{}, Rejection{
+			Status:  fiber.StatusConflict,
+			Code:    helper.CodeOrderNotCancelable,
+			Message: fmt.Sprintf("Order is not eligible for cancelation (reason: %s)", err.Error()),
+			Details: map[string]any{"order_status": order.Status},
+		}
+	}
+
+	// Cancels are not accepted on closed instruments
+	instrument, err := tx.Instruments().Get(ctx, order.InstrumentId)
+	if err != nil {
+		return OrderBook{}, InstrumentWithAssetsSchema{}, err
+	}
+	if !instrument.TradingStatus.AcceptsCancels() {
+		return OrderBook{}, InstrumentWithAssetsSchema{}, Rejection{
+			Status:  fiber.StatusConflict,
+			Code:    helper.CodeInstrumentNotTrading,
+			Message: fmt.Sprintf("Instrument is %s, cancels are not accepted", instrument.TradingStatus),
+		}
+	}
+
+	if err := CancelOrder(ctx, tx, order, reason); err != nil {
+		return OrderBook{}, InstrumentWithAssetsSchema{}, err
+	}
+	canceled := order
+	canceled.Status = Canceled
+	err = audit.Record(ctx, tx, audit.Entry{
+		Actor:      audit.Account(order.AccountId),
+		Action:     audit.OrderCanceled,
+		EntityType: "order",
+		EntityId:   order.Id.String(),
+		Before:     order,
+		After:      canceled,
+	})
+	if err != nil {
+		return OrderBook{}, InstrumentWithAssetsSchema{}, err
+	}
+	return canceled, instrument, nil
+}
+
+// reject records the rejection on the event log and returns it, the order
+// unit of work itself is rolled back
+func (s *Service) reject(ctx context.Context, order PlaceOrderSchema, rejection Rejection) error {
+	err := eventlog.AppendCommitted(ctx, s.store, eventlog.OrderRejected, order.AccountId, eventlog.OrderRejectedPayload{
+		AccountId: order.AccountId,
+		AssetCode: order.AssetCode,
+		Side:      string(order.OrderType),
+		Price:     order.Price,
+		Quantity:  order.Quantity,
+		Reason:    rejection.Error(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, reason := range rejection.reasons {
+		ordersRejected.WithLabelValues(reason).Inc()
+	}
+	return rejection
+}
+
+// observe records the metrics of a committed placement and feeds the
+// order-to-trade ratio monitor
+func (s *Service) observe(accountId uuid.UUID, placement Placement) {
+	ordersPlaced.WithLabelValues(symbol(placement.Instrument), string(placement.Order.Type)).Inc()
+	ObserveAuction(placement.Instrument, placement.Auction)
+	for _, fill := range placement.Fills {
+		observeTrade(placement.Instrument, fill.Quantity, fill.Price)
+	}
+
+	if s.orderToTrade != nil {
+		s.orderToTrade.RecordOrder(accountId.String())
+		for _, fill := range placement.Fills {
+			s.orderToTrade.RecordTrade(accountId.String())
+			s.orderToTrade.RecordTrade(fill.Match.AccountId.String())
+		}
+	}
+}
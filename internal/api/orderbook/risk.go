@@ -0,0 +1,80 @@
+package orderbook
+
+import (
+	"context"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// riskState implements risk.State reading from the current unit of work
+type riskState struct {
+	tx storage.Tx
+}
+
+func (s riskState) LastTradePrice(ctx context.Context, instrumentId uuid.UUID) (*decimal.Decimal, error) {
+	trade, err := s.tx.Trades().Last(ctx, instrumentId)
+	if err != nil || trade == nil {
+		return nil, err
+	}
+	return &trade.Price, nil
+}
+
+func (s riskState) MidPrice(ctx context.Context, instrumentId uuid.UUID) (*decimal.Decimal, error) {
+	bestBid, bestAsk, err := s.tx.Orders().BestPrices(ctx, instrumentId)
+	if err != nil {
+		return nil, err
+	}
+	if bestBid == nil || bestAsk == nil {
+		return nil, nil
+	}
+
+	mid := bestBid.Add(*bestAsk).Div(decimal.NewFromInt(2))
+	return &mid, nil
+}
+
+func (s riskState) OpenOrders(ctx context.Context, accountId uuid.UUID) (int, error) {
+	return s.tx.Orders().Count(ctx, storage.OrderFilter{
+		AccountId: &accountId,
+		Statuses:  storage.WorkingStatuses,
+	})
+}
+
+// Position is the balance of the asset plus what open buy orders would add
+func (s riskState) Position(ctx context.Context, accountId, assetId uuid.UUID) (decimal.Decimal, error) {
+	position := decimal.NewFromInt(0)
+	balance, err := s.tx.Balances().Get(ctx, accountId, assetId)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	if balance != nil {
+		position = *balance
+	}
+
+	instruments, err := s.tx.Instruments().List(ctx)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	buy := Buy
+	for _, instrument := range instruments {
+		if instrument.BaseAssetId != assetId {
+			continue
+		}
+
+		filter := storage.OrderFilter{
+			AccountId:    &accountId,
+			InstrumentId: &instrument.Id,
+			Type:         &buy,
+			Statuses:     storage.WorkingStatuses,
+		}
+		orders, err := s.tx.Orders().List(ctx, filter, storage.Page{})
+		if err != nil {
+			return decimal.Decimal{}, err
+		}
+		for _, order := range orders {
+			position = position.Add(order.TotalQuantity.Sub(order.FilledQuantity))
+		}
+	}
+	return position, nil
+}
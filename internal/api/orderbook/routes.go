@@ -1,14 +1,80 @@
 package orderbook
 
 import (
-	"context"
+	"time"
 
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/openapi"
 	"github.com/gofiber/fiber/v3"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
-func InitializeRoutes(app *fiber.App, db *pgxpool.Pool) {
-	app.Get("/v1/order_book", GetOrderBookHandler(db))
-	app.Post("/v1/order_book", PlaceOrderHandler(context.Background(), db))
-	app.Post("/v1/order_book/:id/cancel", CancelOrderHandler(context.Background(), db))
+func InitializeRoutes(app *fiber.App, service *Service) {
+	app.Get("/v1/order_book", GetOrderBookHandler(service))
+	app.Get("/v1/order_history", GetOrderHistoryHandler(service))
+	app.Post("/v1/order_book", PlaceOrderHandler(service))
+	app.Get("/v1/order_book/:id", GetOrderHandler(service))
+	app.Get("/v1/order_book/:id/events", GetOrderEventsHandler(service))
+	app.Post("/v1/order_book/:id/cancel", CancelOrderHandler(service))
+}
+
+// Operations documents the routes in the OpenAPI document
+var Operations = []openapi.Operation{
+	{
+		Method: fiber.MethodGet, Path: "/v1/order_book", Tag: "Orders",
+		Summary:  "List live orders, working and not yet archived, oldest first by default",
+		Query:    append(orderFilterParameters(), openapi.PageParameters(OrderSorts...)...),
+		Response: helper.Pagination[OrderBookShowSchema]{},
+		Errors:   []int{fiber.StatusBadRequest},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/order_history", Tag: "Orders",
+		Summary:  "List archived filled and canceled orders, oldest first by default",
+		Query:    append(orderFilterParameters(), openapi.PageParameters(OrderSorts...)...),
+		Response: helper.Pagination[OrderBookShowSchema]{},
+		Errors:   []int{fiber.StatusBadRequest},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/order_book", Tag: "Orders",
+		Summary: "Place a limit order",
+		Body:    PlaceOrderSchema{},
+		Status:  fiber.StatusNoContent,
+		Errors: []int{
+			fiber.StatusBadRequest, fiber.StatusPaymentRequired, fiber.StatusNotFound,
+			fiber.StatusConflict, fiber.StatusUnprocessableEntity,
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/order_book/:id", Tag: "Orders",
+		Summary:  "Get an order with its fills, reserved funds and cancel reason",
+		Response: OrderDetailSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/order_book/:id/events", Tag: "Orders",
+		Summary:  "Get the lifecycle of an order, oldest step first",
+		Response: OrderTimelineSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/order_book/:id/cancel", Tag: "Orders",
+		Summary: "Cancel an open or partially filled order",
+		Status:  fiber.StatusNoContent,
+		Errors:  []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusConflict},
+	},
+}
+
+// orderFilterParameters are the filters of the live and archived order lists
+func orderFilterParameters() []openapi.Parameter {
+	return []openapi.Parameter{
+		{Name: "account_id", Type: uuid.UUID{}},
+		{Name: "instrument_id", Type: uuid.UUID{}},
+		{Name: "status", Description: "Comma separated statuses, e.g. open,partially_filled", Type: ""},
+		{Name: "side", Type: Buy},
+		{Name: "min_price", Description: "Inclusive lower price bound", Type: decimal.Decimal{}},
+		{Name: "max_price", Description: "Inclusive upper price bound", Type: decimal.Decimal{}},
+		{Name: "created_from", Description: "Created at or after, RFC 3339", Type: time.Time{}},
+		{Name: "created_to", Description: "Created before, RFC 3339", Type: time.Time{}},
+	}
 }
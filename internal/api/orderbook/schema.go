@@ -1,6 +1,10 @@
 package orderbook
 
 import (
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/storage"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -9,11 +13,70 @@ type OrderBookShowSchema struct {
 	Id             uuid.UUID       `json:"id" validate:"required"`
 	AccountId      uuid.UUID       `json:"account_id" validate:"required"`
 	InstrumentId   uuid.UUID       `json:"instrument_id" validate:"required"`
-	Type           OrderType      `json:"type" validate:"required"`
-	Status         OrderStatus    `json:"status" validate:"required"`
+	Type           OrderType       `json:"type" validate:"required"`
+	Status         OrderStatus     `json:"status" validate:"required"`
 	Price          decimal.Decimal `json:"price" validate:"required"`
 	TotalQuantity  decimal.Decimal `json:"total_quantity" validate:"required"`
 	FilledQuantity decimal.Decimal `json:"filled_quantity" validate:"required"`
+	CreatedAt      time.Time       `json:"created_at" validate:"required"`
+}
+
+// OrderDetailSchema is an order with what its events tell about it
+type OrderDetailSchema struct {
+	OrderBookShowSchema
+	RemainingQuantity decimal.Decimal `json:"remaining_quantity" validate:"required"`
+	// AverageFillPrice is null until the order fills
+	AverageFillPrice *decimal.Decimal `json:"average_fill_price"`
+	// ReservedAmount is held for the remaining quantity while the order works,
+	// in the quote asset for buy orders and the base asset for sell orders
+	ReservedAmount    decimal.Decimal `json:"reserved_amount" validate:"required"`
+	ReservedAssetCode string          `json:"reserved_asset_code" validate:"required"`
+	// CancelReason is null unless the order is canceled
+	CancelReason *eventlog.CancelReason `json:"cancel_reason"`
+	Fills        []OrderFillSchema      `json:"fills" validate:"required"`
+}
+
+// OrderFillSchema is a match of the order against a counter order
+type OrderFillSchema struct {
+	CounterOrderId uuid.UUID       `json:"counter_order_id" validate:"required"`
+	Price          decimal.Decimal `json:"price" validate:"required"`
+	Quantity       decimal.Decimal `json:"quantity" validate:"required"`
+	CreatedAt      time.Time       `json:"created_at" validate:"required"`
+}
+
+// OrderEventType is a step of the lifecycle of an order
+type OrderEventType string
+
+const (
+	OrderEventAccepted        OrderEventType = "accepted"
+	OrderEventPartiallyFilled OrderEventType = "partially_filled"
+	OrderEventFilled          OrderEventType = "filled"
+	// OrderEventAmended orders were canceled and replaced by another order
+	OrderEventAmended  OrderEventType = "amended"
+	OrderEventCanceled OrderEventType = "canceled"
+)
+
+// OrderEventSchema is a step of the lifecycle of an order. Acceptances carry
+// the price and quantity of the order and the order it replaces, fills their
+// price, quantity, counter order and the filled quantity after them, cancels
+// their reason and amendments the order replacing it.
+type OrderEventSchema struct {
+	Sequence       int64                  `json:"sequence" validate:"required"`
+	Type           OrderEventType         `json:"type" validate:"required"`
+	CreatedAt      time.Time              `json:"created_at" validate:"required"`
+	Price          *decimal.Decimal       `json:"price,omitempty"`
+	Quantity       *decimal.Decimal       `json:"quantity,omitempty"`
+	FilledQuantity *decimal.Decimal       `json:"filled_quantity,omitempty"`
+	CounterOrderId *uuid.UUID             `json:"counter_order_id,omitempty"`
+	Reason         *eventlog.CancelReason `json:"reason,omitempty"`
+	Replaces       *uuid.UUID             `json:"replaces,omitempty"`
+	ReplacedBy     *uuid.UUID             `json:"replaced_by,omitempty"`
+}
+
+// OrderTimelineSchema is the lifecycle of an order, oldest step first
+type OrderTimelineSchema struct {
+	OrderId uuid.UUID          `json:"order_id" validate:"required"`
+	Events  []OrderEventSchema `json:"events" validate:"required"`
 }
 
 type PlaceOrderSchema struct {
@@ -24,10 +87,14 @@ type PlaceOrderSchema struct {
 	OrderType OrderType       `json:"order_type" validate:"required"`
 }
 
-type InstrumentWithAssetsSchema struct {
-	Id             uuid.UUID `json:"id" validate:"required"`
-	BaseAssetId    uuid.UUID `json:"base_asset_id" validate:"required"`
-	BaseAssetCode  string    `json:"base_asset_code" validate:"required"`
-	QuoteAssetId   uuid.UUID `json:"quote_asset_id" validate:"required"`
-	QuoteAssetCode string    `json:"quote_asset_code" validate:"required"`
+type InstrumentWithAssetsSchema = storage.Instrument
+
+// BookSchema is the book of an instrument aggregated per price level, best
+// price first on each side
+type BookSchema struct {
+	InstrumentId uuid.UUID            `json:"instrument_id" validate:"required"`
+	Bids         []storage.PriceLevel `json:"bids" validate:"required"`
+	Asks         []storage.PriceLevel `json:"asks" validate:"required"`
+	// Sequence is the last event of the log when the book was read
+	Sequence int64 `json:"sequence"`
 }
@@ -3,176 +3,140 @@ package orderbook
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/eventlog"
 	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/tracing"
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 )
 
-func GetOrderBookHandler(db *pgxpool.Pool) fiber.Handler {
+func GetOrderBookHandler(service *Service) fiber.Handler {
 	return func(c fiber.Ctx) error {
-		// Get pagination
-		pagination := helper.GetPagination[OrderBookShowSchema](c)
-
-		// Retrieve query
-		query := "SELECT {{query}} FROM order_book WHERE 1=1"
-		if c.Query("account_id") != "" {
-			query += " AND account_id = '" + c.Query("account_id") + "'"
-		}
-		if c.Query("instrument_id") != "" {
-			query += " AND instrument_id = '" + c.Query("instrument_id") + "'"
-		}
-
-		// Get total
-		countQuery := strings.Replace(query, "{{query}}", "COUNT(*)", 1)
-		var total int
-		if err := db.QueryRow(context.Background(), countQuery).Scan(&total); err != nil {
+		page, err := helper.GetPage(c, OrderSorts...)
+		if err != nil {
 			return err
 		}
-		pagination.Total = &total
-
-		// Retrieve order book
-		retrieveQuery := strings.Replace(query, "{{query}}", "id, account_id, instrument_id, type, status, price, total_quantity, filled_quantity", 1)
-		retrieveQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", pagination.Size, (pagination.Page-1)*pagination.Size)
-		rows, err := db.Query(context.Background(), retrieveQuery)
+		filter, err := getOrderFilter(c)
 		if err != nil {
 			return err
 		}
-		defer rows.Close()
 
-		var order_book = make(map[string]OrderBookShowSchema)
-		for rows.Next() {
-			var order OrderBookShowSchema
-			if err := rows.Scan(&order.Id, &order.AccountId, &order.InstrumentId, &order.Type, &order.Status, &order.Price, &order.TotalQuantity, &order.FilledQuantity); err != nil {
-				return err
-			}
-			order_book[order.Id.String()] = order
+		pagination, err := service.List(helper.Context(c), filter, page)
+		if err != nil {
+			return err
 		}
 
-		if len(order_book) > 0 {
-			pagination.Items = helper.MapToSlice(order_book)
-		}
 		return c.JSON(pagination)
 	}
 }
 
-func PlaceOrderHandler(ctx context.Context, db *pgxpool.Pool) fiber.Handler {
+func GetOrderHistoryHandler(service *Service) fiber.Handler {
 	return func(c fiber.Ctx) error {
-		// Parse place order schema
-		var order = PlaceOrderSchema{}
-		if err := c.Bind().Body(&order); err != nil {
-			return fiber.ErrBadRequest
-		}
-		if err := helper.ValidateInput(&order); err != nil {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
-				"error": err.Error(),
-			})
+		page, err := helper.GetPage(c, OrderSorts...)
+		if err != nil {
+			return err
 		}
-
-		// Transaction to ensure correct update on race conditions
-		tx, err := db.BeginTx(ctx, pgx.TxOptions{})
+		filter, err := getOrderFilter(c)
 		if err != nil {
 			return err
 		}
-		defer tx.Rollback(ctx)
 
-		// Get instrument of order
-		instrument, err := getInstrumentByAssetCode(ctx, tx, order.AssetCode)
+		pagination, err := service.History(helper.Context(c), filter, page)
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-					"error": "Instrument not found",
-				})
-			}
 			return err
 		}
 
-		var assetCode string
-		if order.OrderType == Buy {
-			assetCode = instrument.QuoteAssetCode
-		} else {
-			assetCode = order.AssetCode
-		}
+		return c.JSON(pagination)
+	}
+}
 
-		// Verify if the account has the balance
-		balance, assetId, err := account.GetAccountBalance(ctx, tx, order.AccountId, &assetCode, nil)
+// getOrderFilter reads the filters of the order list from the query
+func getOrderFilter(c fiber.Ctx) (storage.OrderFilter, error) {
+	var filter storage.OrderFilter
+	if c.Query("account_id") != "" {
+		accountId, err := uuid.Parse(c.Query("account_id"))
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				return c.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{
-					"error": "Insufficient funds",
-				})
-			}
-			return err
+			return filter, helper.InvalidId("account_id")
 		}
+		filter.AccountId = &accountId
+	}
+	if c.Query("instrument_id") != "" {
+		instrumentId, err := uuid.Parse(c.Query("instrument_id"))
+		if err != nil {
+			return filter, helper.InvalidId("instrument_id")
+		}
+		filter.InstrumentId = &instrumentId
+	}
 
-		// Verify if the account has the necessary balance
-		var necessaryBalance decimal.Decimal
-		if order.OrderType == Buy {
-			necessaryBalance = order.Quantity.Mul(order.Price)
-		} else {
-			necessaryBalance = order.Quantity
+	// Statuses are comma separated, e.g. open,partially_filled
+	if c.Query("status") != "" {
+		for _, status := range strings.Split(c.Query("status"), ",") {
+			if !slices.Contains(OrderStatuses, OrderStatus(status)) {
+				return filter, helper.InvalidRequest("status must be open, partially_filled, full_filled or canceled")
+			}
+			filter.Statuses = append(filter.Statuses, OrderStatus(status))
 		}
-		if balance.LessThan(necessaryBalance) {
-			return c.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{
-				"error": "Insufficient funds",
-			})
+	}
+	if side := OrderType(c.Query("side")); side != "" {
+		if side != Buy && side != Sell {
+			return filter, helper.InvalidRequest("side must be buy or sell")
 		}
+		filter.Type = &side
+	}
 
-		// Update balance from account
-		var newBalance decimal.Decimal
-		if order.OrderType == Buy {
-			newBalance = balance.Sub(order.Quantity.Mul(order.Price))
-		} else {
-			newBalance = balance.Sub(order.Quantity)
+	if c.Query("min_price") != "" {
+		minPrice, err := decimal.NewFromString(c.Query("min_price"))
+		if err != nil {
+			return filter, helper.InvalidRequest("min_price must be a decimal")
 		}
-		if err := account.UpdateAccountBalance(ctx, tx, order.AccountId, newBalance, *assetId); err != nil {
-			return err
+		filter.MinPrice = &minPrice
+	}
+	if c.Query("max_price") != "" {
+		maxPrice, err := decimal.NewFromString(c.Query("max_price"))
+		if err != nil {
+			return filter, helper.InvalidRequest("max_price must be a decimal")
 		}
+		filter.MaxPrice = &maxPrice
+	}
 
-		// Create a new order at database
-		var orderId uuid.UUID
-		query := `
-			INSERT INTO order_book (account_id, instrument_id, type, status, price, total_quantity, filled_quantity)
-			VALUES ($1, $2, $3, $4, $5, $6, $7)
-			RETURNING id
-		`
-		err = tx.QueryRow(
-			context.Background(),
-			query,
-			order.AccountId,
-			instrument.Id,
-			order.OrderType,
-			Open,
-			order.Price,
-			order.Quantity,
-			0,
-		).Scan(&orderId)
+	// Times are RFC 3339, created_to is exclusive
+	if c.Query("created_from") != "" {
+		createdFrom, err := time.Parse(time.RFC3339Nano, c.Query("created_from"))
 		if err != nil {
-			return err
+			return filter, helper.InvalidRequest("created_from must be an RFC 3339 time")
 		}
-
-		// Match order
-		orderToMatch := OrderBook{
-			Id:             orderId,
-			AccountId:      order.AccountId,
-			InstrumentId:   instrument.Id,
-			Type:           order.OrderType,
-			Status:         Open,
-			Price:          order.Price,
-			TotalQuantity:  order.Quantity,
-			FilledQuantity: decimal.NewFromInt(0),
+		filter.CreatedFrom = &createdFrom
+	}
+	if c.Query("created_to") != "" {
+		createdTo, err := time.Parse(time.RFC3339Nano, c.Query("created_to"))
+		if err != nil {
+			return filter, helper.InvalidRequest("created_to must be an RFC 3339 time")
 		}
-		if err := matchOrder(ctx, tx, orderToMatch, instrument); err != nil {
-			return err
+		filter.CreatedTo = &createdTo
+	}
+	return filter, nil
+}
+
+func PlaceOrderHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		ctx := helper.Context(c)
+
+		// Parse place order schema
+		var order = PlaceOrderSchema{}
+		if err := c.Bind().Body(&order); err != nil {
+			ordersRejected.WithLabelValues(rejectInvalid).Inc()
+			return helper.InvalidRequest("Malformed request body")
 		}
 
-		// Commit transaction
-		if err := tx.Commit(ctx); err != nil {
+		if _, err := service.Place(ctx, order, nil); err != nil {
 			return err
 		}
 
@@ -180,81 +144,48 @@ func PlaceOrderHandler(ctx context.Context, db *pgxpool.Pool) fiber.Handler {
 	}
 }
 
-func CancelOrderHandler(ctx context.Context, db *pgxpool.Pool) fiber.Handler {
+func GetOrderHandler(service *Service) fiber.Handler {
 	return func(c fiber.Ctx) error {
-		id := c.Params("id")
-		if id == "" {
-			return fiber.ErrBadRequest
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
 		}
 
-		// Transaction to ensure correct update on race conditions
-		tx, err := db.BeginTx(ctx, pgx.TxOptions{})
+		detail, err := service.Detail(helper.Context(c), id)
 		if err != nil {
 			return err
 		}
-		defer tx.Rollback(ctx)
-
-		// Get order
-		var order OrderBook
-		query := `
-			SELECT id, account_id, instrument_id, type, status, price, total_quantity, filled_quantity
-			FROM order_book
-			WHERE id = $1
-			FOR UPDATE
-		`
-		if err := tx.QueryRow(ctx, query, id).Scan(&order.Id, &order.AccountId, &order.InstrumentId, &order.Type, &order.Status, &order.Price, &order.TotalQuantity, &order.FilledQuantity); err != nil {
-			if err == pgx.ErrNoRows {
-				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-					"error": "Order not found",
-				})
-			}
-			return err
-		}
 
-		// Verify eligibility for cancelation
-		if err := verifyOrderCancelationEligibility(order); err != nil {
-			return c.JSON(fiber.Map{
-				"error": fmt.Sprintf("order is not eligible for cancelation (reason: %s)", err.Error()),
-			})
-		}
-
-		// Get asset of order
-		var asset account.Asset
-		var innerJoin string
-		if order.Type == Buy {
-			innerJoin = "INNER JOIN instruments ON instruments.quote_asset_id = assets.id"
-		} else {
-			innerJoin = "INNER JOIN instruments ON instruments.base_asset_id = assets.id"
-		}
-
-		query = `
-			SELECT assets.id, assets.code
-			FROM assets
-			` + innerJoin + `
-			WHERE instruments.id = $1
-			FOR UPDATE
-		`
-		if err := tx.QueryRow(ctx, query, order.InstrumentId).Scan(&asset.Id, &asset.Code); err != nil {
-			if err == pgx.ErrNoRows {
-				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-					"error": "Asset not found",
-				})
-			}
-			return err
+		return c.JSON(detail)
+	}
+}
+
+func GetOrderEventsHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
 		}
 
-		// Update order status
-		if err := updateOrderStatus(ctx, tx, order.Id, Canceled); err != nil {
+		timeline, err := service.Timeline(helper.Context(c), id)
+		if err != nil {
 			return err
 		}
 
-		// Rollback account balance
-		if err := account.UpdateAccountBalance(ctx, tx, order.AccountId, order.TotalQuantity.Sub(order.FilledQuantity), asset.Id); err != nil {
-			return err
+		return c.JSON(timeline)
+	}
+}
+
+func CancelOrderHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		ctx := helper.Context(c)
+
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
 		}
 
-		// Commit transaction
-		if err := tx.Commit(ctx); err != nil {
+		if _, err := service.Cancel(ctx, id, nil); err != nil {
 			return err
 		}
 
@@ -262,20 +193,26 @@ func CancelOrderHandler(ctx context.Context, db *pgxpool.Pool) fiber.Handler {
 	}
 }
 
-func getInstrumentByAssetCode(ctx context.Context, tx pgx.Tx, assetCode string) (InstrumentWithAssetsSchema, error) {
-	var instrument InstrumentWithAssetsSchema
-	query := `
-		SELECT instruments.id, instruments.base_asset_id, base_assets.code, instruments.quote_asset_id, quote_assets.code
-		FROM instruments
-		INNER JOIN assets base_assets ON base_assets.id = instruments.base_asset_id
-		INNER JOIN assets quote_assets ON quote_assets.id = instruments.quote_asset_id
-		WHERE base_assets.code = $1
-	`
-	err := tx.QueryRow(ctx, query, assetCode).Scan(&instrument.Id, &instrument.BaseAssetId, &instrument.BaseAssetCode, &instrument.QuoteAssetId, &instrument.QuoteAssetCode)
+// crossesBook reports if an order would match a resting order
+func crossesBook(ctx context.Context, tx storage.Tx, instrumentId uuid.UUID, orderType OrderType, price decimal.Decimal) (bool, error) {
+	bestBid, bestAsk, err := tx.Orders().BestPrices(ctx, instrumentId)
+	if err != nil {
+		return false, err
+	}
+	if orderType == Buy {
+		return bestAsk != nil && bestAsk.LessThanOrEqual(price), nil
+	}
+	return bestBid != nil && bestBid.GreaterThanOrEqual(price), nil
+}
+
+// BookCrossed reports if the best bid of the instrument reaches its best ask,
+// which only an auction leaves behind
+func BookCrossed(ctx context.Context, tx storage.Tx, instrumentId uuid.UUID) (bool, error) {
+	bestBid, bestAsk, err := tx.Orders().BestPrices(ctx, instrumentId)
 	if err != nil {
-		return InstrumentWithAssetsSchema{}, err
+		return false, err
 	}
-	return instrument, nil
+	return bestBid != nil && bestAsk != nil && bestBid.GreaterThanOrEqual(*bestAsk), nil
 }
 
 func verifyOrderCancelationEligibility(order OrderBook) error {
@@ -287,104 +224,111 @@ func verifyOrderCancelationEligibility(order OrderBook) error {
 	return nil
 }
 
-func updateOrderStatus(ctx context.Context, tx pgx.Tx, orderId uuid.UUID, status OrderStatus) error {
-	_, err := tx.Exec(ctx, "UPDATE order_book SET status = $1 WHERE id = $2", status, orderId)
-	return err
-}
-
-func matchOrder(ctx context.Context, tx pgx.Tx, order OrderBook, instrument InstrumentWithAssetsSchema) error {
-	// Get matches for buy/sell order
-	var matchOrders []OrderBook
-	var err error
+// CancelOrder cancels the order for the reason and releases the funds reserved
+// for its remaining quantity
+func CancelOrder(ctx context.Context, tx storage.Tx, order OrderBook, reason eventlog.CancelReason) error {
+	// Get asset of order
+	instrument, err := tx.Instruments().Get(ctx, order.InstrumentId)
+	if err != nil {
+		return err
+	}
+	assetId := instrument.BaseAssetId
 	if order.Type == Buy {
-		matchOrders, err = getCompatibleSellOrders(ctx, tx, order)
-		if err != nil {
-			return err
-		}
-	} else {
-		matchOrders, err = getCompatibleBuyOrders(ctx, tx, order)
-		if err != nil {
-			return err
-		}
+		assetId = instrument.QuoteAssetId
 	}
 
-	for _, match := range matchOrders {
-		order, err = processMatch(ctx, tx, order, match, instrument)
-		if err != nil {
-			return err
-		}
+	// Update order status
+	if err := tx.Orders().UpdateStatus(ctx, order.Id, Canceled); err != nil {
+		return err
+	}
+	err = eventlog.Append(ctx, tx, eventlog.OrderCanceled, order.Id, eventlog.OrderCanceledPayload{
+		OrderId: order.Id,
+		Reason:  reason,
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	// Rollback account balance
+	return addToBalance(ctx, tx, order.AccountId, assetId, reservedFunds(order))
 }
 
-func getCompatibleSellOrders(ctx context.Context, tx pgx.Tx, order OrderBook) ([]OrderBook, error) {
-	var compatibleOrders []OrderBook
-
-	query := `
-		SELECT id, account_id, instrument_id, type, status, price, total_quantity, filled_quantity
-		FROM order_book
-		WHERE
-			instrument_id = $1
-			AND type = 'sell'
-			AND status IN ('open', 'partially_filled')
-			AND price <= $2
-		ORDER BY
-			price ASC,
-			created_at ASC
-		FOR UPDATE SKIP LOCKED
-	`
-	rows, err := tx.Query(ctx, query, order.InstrumentId, order.Price)
+// reservedFunds is what a working order holds for its remaining quantity: the
+// quote asset at its price for buy orders, the base asset for sell orders
+func reservedFunds(order OrderBook) decimal.Decimal {
+	reserved := order.TotalQuantity.Sub(order.FilledQuantity)
+	if order.Type == Buy {
+		reserved = reserved.Mul(order.Price)
+	}
+	return reserved
+}
+
+// CancelOpenOrders cancels every working order of the instrument returning how many were canceled
+func CancelOpenOrders(ctx context.Context, tx storage.Tx, instrumentId uuid.UUID) (int, error) {
+	orders, err := tx.Orders().ListWorking(ctx, instrumentId)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var compatibleOrder OrderBook
-		if err := rows.Scan(&compatibleOrder.Id, &compatibleOrder.AccountId, &compatibleOrder.InstrumentId, &compatibleOrder.Type, &compatibleOrder.Status, &compatibleOrder.Price, &compatibleOrder.TotalQuantity, &compatibleOrder.FilledQuantity); err != nil {
-			return nil, err
+	for _, order := range orders {
+		if err := CancelOrder(ctx, tx, order, eventlog.CancelInstrumentClosed); err != nil {
+			return 0, err
 		}
-		compatibleOrders = append(compatibleOrders, compatibleOrder)
 	}
 
-	return compatibleOrders, nil
+	return len(orders), nil
 }
 
-func getCompatibleBuyOrders(ctx context.Context, tx pgx.Tx, order OrderBook) ([]OrderBook, error) {
-	var compatibleOrders []OrderBook
-
-	query := `
-		SELECT id, account_id, instrument_id, type, status, price, total_quantity, filled_quantity
-		FROM order_book
-		WHERE
-			instrument_id = $1
-			AND type = 'buy'
-			AND status IN ('open', 'partially_filled')
-			AND price >= $2
-		ORDER BY
-			price DESC,
-			created_at ASC
-		FOR UPDATE SKIP LOCKED
-	`
-	rows, err := tx.Query(ctx, query, order.InstrumentId, order.Price)
+// matchOrder returns the fills of the order against the resting orders,
+// recording them on trades. Matching stops, reporting halted, when the
+// circuit breaker refuses a trade.
+func matchOrder(ctx context.Context, tx storage.Tx, order OrderBook, instrument InstrumentWithAssetsSchema, trades *circuitbreaker.Trades) (fills []Fill, halted bool, err error) {
+	ctx, span := tracing.Start(ctx, "orderbook.matchOrder",
+		tracing.Instrument.String(symbol(instrument)),
+		tracing.Side.String(string(order.Type)),
+		tracing.OrderId.String(order.Id.String()),
+	)
+	defer func() {
+		span.SetAttributes(tracing.Matches.Int(len(fills)))
+		tracing.End(span, err)
+	}()
+
+	// Get matches for buy/sell order
+	matchOrders, err := tx.Orders().ListCompatible(ctx, order)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var compatibleOrder OrderBook
-		if err := rows.Scan(&compatibleOrder.Id, &compatibleOrder.AccountId, &compatibleOrder.InstrumentId, &compatibleOrder.Type, &compatibleOrder.Status, &compatibleOrder.Price, &compatibleOrder.TotalQuantity, &compatibleOrder.FilledQuantity); err != nil {
-			return nil, err
+	for _, match := range matchOrders {
+		// A filled order trades no more, the breaker must not see the next price
+		if !order.TotalQuantity.Sub(order.FilledQuantity).IsPositive() {
+			break
+		}
+
+		// Trades are executed at the sell order price
+		price := match.Price
+		if order.Type == Sell {
+			price = order.Price
+		}
+		if !trades.Allow(price) {
+			return fills, true, nil
+		}
+
+		previousFilledQuantity := order.FilledQuantity
+		order, err = processMatch(ctx, tx, order, match, instrument)
+		if err != nil {
+			return nil, false, err
+		}
+		if order.FilledQuantity.GreaterThan(previousFilledQuantity) {
+			trades.Record(price)
+			fills = append(fills, Fill{match, order.FilledQuantity.Sub(previousFilledQuantity), price})
 		}
-		compatibleOrders = append(compatibleOrders, compatibleOrder)
 	}
 
-	return compatibleOrders, nil
+	return fills, false, nil
 }
 
-func processMatch(ctx context.Context, tx pgx.Tx, order OrderBook, match OrderBook, instrument InstrumentWithAssetsSchema) (OrderBook, error) {
+func processMatch(ctx context.Context, tx storage.Tx, order OrderBook, match OrderBook, instrument InstrumentWithAssetsSchema) (OrderBook, error) {
 	// Split orders into buy and sell
 	var buyOrder, sellOrder OrderBook
 	if order.Type == Buy {
@@ -401,85 +345,87 @@ func processMatch(ctx context.Context, tx pgx.Tx, order OrderBook, match OrderBo
 	// Determine the quantity to fulfill
 	fullfillQuantity := decimal.Min(buyOrderAvailableQuantity, sellOrderAvailableQuantity)
 
-	// Fill each order
-	buyOrderNewFilledQuantity := buyOrder.FilledQuantity.Add(fullfillQuantity)
-	if err := fillOrder(ctx, tx, buyOrder, buyOrderNewFilledQuantity); err != nil {
+	// Continuous trading executes at the sell order price
+	if err := settleTrade(ctx, tx, buyOrder, sellOrder, fullfillQuantity, sellOrder.Price, instrument); err != nil {
 		return OrderBook{}, err
 	}
-	sellOrderNewFilledQuantity := sellOrder.FilledQuantity.Add(fullfillQuantity)
-	if err := fillOrder(ctx, tx, sellOrder, sellOrderNewFilledQuantity); err != nil {
-		return OrderBook{}, err
+
+	// Update current order to return
+	order.FilledQuantity = order.FilledQuantity.Add(fullfillQuantity)
+
+	return order, nil
+}
+
+// settleTrade fills quantity of both orders at price, moving the base asset to
+// the buyer and the quote asset to the seller. The buyer reserved funds at its
+// own limit price, so any price improvement is given back.
+func settleTrade(ctx context.Context, tx storage.Tx, buyOrder, sellOrder OrderBook, quantity, price decimal.Decimal, instrument InstrumentWithAssetsSchema) error {
+	if !quantity.GreaterThan(decimal.NewFromInt(0)) {
+		return nil
 	}
 
-	// Update orders statuses
-	if buyOrderNewFilledQuantity.GreaterThan(decimal.NewFromInt(0)) {
-		if buyOrderNewFilledQuantity.LessThan(buyOrder.TotalQuantity) {
-			if err := updateOrderStatus(ctx, tx, buyOrder.Id, PartiallyFilled); err != nil {
-				return OrderBook{}, err
-			}
-		} else {
-			if err := updateOrderStatus(ctx, tx, buyOrder.Id, FullFilled); err != nil {
-				return OrderBook{}, err
-			}
+	// Fill each order and update its status
+	for _, order := range []OrderBook{buyOrder, sellOrder} {
+		newFilledQuantity := order.FilledQuantity.Add(quantity)
+		status := FullFilled
+		if newFilledQuantity.LessThan(order.TotalQuantity) {
+			status = PartiallyFilled
 		}
-	}
-	if sellOrderNewFilledQuantity.GreaterThan(decimal.NewFromInt(0)) {
-		if sellOrderNewFilledQuantity.LessThan(sellOrder.TotalQuantity) {
-			if err := updateOrderStatus(ctx, tx, sellOrder.Id, PartiallyFilled); err != nil {
-				return OrderBook{}, err
-			}
-		} else {
-			if err := updateOrderStatus(ctx, tx, sellOrder.Id, FullFilled); err != nil {
-				return OrderBook{}, err
-			}
+		if err := tx.Orders().UpdateFill(ctx, order.Id, newFilledQuantity, status); err != nil {
+			return err
 		}
 	}
 
-	// Charge the buy account with the asset
-	accountBalance, _, err := account.GetAccountBalance(ctx, tx, order.AccountId, nil, &instrument.BaseAssetId)
+	// Record the trade
+	_, err := tx.Trades().Create(ctx, Trade{
+		InstrumentId: instrument.Id,
+		BuyOrderId:   buyOrder.Id,
+		SellOrderId:  sellOrder.Id,
+		Price:        price,
+		Quantity:     quantity,
+	})
 	if err != nil {
-		return OrderBook{}, err
+		return err
 	}
-	if accountBalance == nil {
-		err = account.CreateAccountBalanceForAccount(ctx, tx, order.AccountId, instrument.BaseAssetId)
-		if err != nil {
-			return OrderBook{}, err
-		}
-		accountBalance = &decimal.Decimal{}
+	err = eventlog.Append(ctx, tx, eventlog.OrderMatched, instrument.Id, eventlog.OrderMatchedPayload{
+		InstrumentId: instrument.Id,
+		BuyOrderId:   buyOrder.Id,
+		SellOrderId:  sellOrder.Id,
+		Price:        price,
+		Quantity:     quantity,
+	})
+	if err != nil {
+		return err
 	}
-	newAccountBalance := accountBalance.Add(fullfillQuantity)
-	if err := account.UpdateAccountBalance(ctx, tx, order.AccountId, newAccountBalance, instrument.BaseAssetId); err != nil {
-		return OrderBook{}, err
+
+	// Charge the buy account with the asset
+	if err := addToBalance(ctx, tx, buyOrder.AccountId, instrument.BaseAssetId, quantity); err != nil {
+		return err
+	}
+
+	// Give back the price improvement to the buy account
+	improvement := buyOrder.Price.Sub(price).Mul(quantity)
+	if improvement.GreaterThan(decimal.NewFromInt(0)) {
+		if err := addToBalance(ctx, tx, buyOrder.AccountId, instrument.QuoteAssetId, improvement); err != nil {
+			return err
+		}
 	}
 
 	// Charge the sell account with the quote asset
-	sellAccountBalance, _, err := account.GetAccountBalance(ctx, tx, sellOrder.AccountId, nil, &instrument.QuoteAssetId)
+	return addToBalance(ctx, tx, sellOrder.AccountId, instrument.QuoteAssetId, quantity.Mul(price))
+}
+
+func addToBalance(ctx context.Context, tx storage.Tx, accountId, assetId uuid.UUID, amount decimal.Decimal) error {
+	balance, _, err := account.GetAccountBalance(ctx, tx, accountId, nil, &assetId)
 	if err != nil {
-		return OrderBook{}, err
+		return err
 	}
-	if sellAccountBalance == nil {
-		err = account.CreateAccountBalanceForAccount(ctx, tx, sellOrder.AccountId, instrument.QuoteAssetId)
+	if balance == nil {
+		created, err := account.CreateAccountBalanceForAccount(ctx, tx, accountId, assetId)
 		if err != nil {
-			return OrderBook{}, err
+			return err
 		}
-		sellAccountBalance = &decimal.Decimal{}
-	}
-	newSellAccountBalance := sellAccountBalance.Add(fullfillQuantity.Mul(sellOrder.Price))
-	if err := account.UpdateAccountBalance(ctx, tx, sellOrder.AccountId, newSellAccountBalance, instrument.QuoteAssetId); err != nil {
-		return OrderBook{}, err
-	}
-
-	// Update current order to return
-	if order.Type == Buy {
-		order.FilledQuantity = buyOrderNewFilledQuantity
-	} else {
-		order.FilledQuantity = sellOrderNewFilledQuantity
+		balance = &created
 	}
-
-	return order, nil
-}
-
-func fillOrder(ctx context.Context, tx pgx.Tx, order OrderBook, quantity decimal.Decimal) error {
-	_, err := tx.Exec(ctx, "UPDATE order_book SET filled_quantity = $1 WHERE id = $2", quantity, order.Id)
-	return err
+	return account.UpdateAccountBalance(ctx, tx, accountId, balance.Add(amount), assetId)
 }
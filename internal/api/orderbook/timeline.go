@@ -0,0 +1,168 @@
+package orderbook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Detail returns the order with its fills, average fill price, the funds it
+// holds and why it was canceled
+func (s *Service) Detail(ctx context.Context, id uuid.UUID) (OrderDetailSchema, error) {
+	order, instrument, events, err := s.orderEvents(ctx, id)
+	if err != nil {
+		return OrderDetailSchema{}, err
+	}
+
+	detail := OrderDetailSchema{
+		OrderBookShowSchema: NewOrderBookShowSchema(order),
+		RemainingQuantity:   order.TotalQuantity.Sub(order.FilledQuantity),
+		ReservedAmount:      decimal.Zero,
+		ReservedAssetCode:   instrument.BaseAssetCode,
+		Fills:               []OrderFillSchema{},
+	}
+	if order.Type == Buy {
+		detail.ReservedAssetCode = instrument.QuoteAssetCode
+	}
+	if order.Status == Open || order.Status == PartiallyFilled {
+		detail.ReservedAmount = reservedFunds(order)
+	}
+
+	notional := decimal.Zero
+	for _, event := range events {
+		switch {
+		case event.Type == eventlog.OrderMatched:
+			var match eventlog.OrderMatchedPayload
+			if err := json.Unmarshal(event.Payload, &match); err != nil {
+				return OrderDetailSchema{}, err
+			}
+			detail.Fills = append(detail.Fills, OrderFillSchema{
+				CounterOrderId: counterOrder(order, match),
+				Price:          match.Price,
+				Quantity:       match.Quantity,
+				CreatedAt:      event.CreatedAt,
+			})
+			notional = notional.Add(match.Price.Mul(match.Quantity))
+		case event.Type == eventlog.OrderCanceled && event.AggregateId == order.Id:
+			var canceled eventlog.OrderCanceledPayload
+			if err := json.Unmarshal(event.Payload, &canceled); err != nil {
+				return OrderDetailSchema{}, err
+			}
+			reason := canceled.Reason
+			detail.CancelReason = &reason
+		}
+	}
+	if order.FilledQuantity.IsPositive() {
+		average := notional.Div(order.FilledQuantity)
+		detail.AverageFillPrice = &average
+	}
+	return detail, nil
+}
+
+// Timeline returns the lifecycle of the order, oldest step first
+func (s *Service) Timeline(ctx context.Context, id uuid.UUID) (OrderTimelineSchema, error) {
+	order, _, events, err := s.orderEvents(ctx, id)
+	if err != nil {
+		return OrderTimelineSchema{}, err
+	}
+
+	timeline := OrderTimelineSchema{OrderId: order.Id, Events: []OrderEventSchema{}}
+	filled := decimal.Zero
+	for _, event := range events {
+		step := OrderEventSchema{Sequence: event.Sequence, CreatedAt: event.CreatedAt}
+		switch event.Type {
+		case eventlog.OrderAccepted:
+			var accepted eventlog.OrderAcceptedPayload
+			if err := json.Unmarshal(event.Payload, &accepted); err != nil {
+				return OrderTimelineSchema{}, err
+			}
+			// The acceptance of the order replacing this one completes its amendment
+			if event.AggregateId != order.Id {
+				for i := len(timeline.Events) - 1; i >= 0; i-- {
+					if timeline.Events[i].Type == OrderEventAmended {
+						timeline.Events[i].ReplacedBy = &accepted.OrderId
+						break
+					}
+				}
+				continue
+			}
+			step.Type = OrderEventAccepted
+			step.Price = &accepted.Price
+			step.Quantity = &accepted.Quantity
+			step.Replaces = accepted.Replaces
+		case eventlog.OrderMatched:
+			var match eventlog.OrderMatchedPayload
+			if err := json.Unmarshal(event.Payload, &match); err != nil {
+				return OrderTimelineSchema{}, err
+			}
+			filled = filled.Add(match.Quantity)
+			step.Type = OrderEventPartiallyFilled
+			if filled.GreaterThanOrEqual(order.TotalQuantity) {
+				step.Type = OrderEventFilled
+			}
+			counter := counterOrder(order, match)
+			step.Price = &match.Price
+			step.Quantity = &match.Quantity
+			step.FilledQuantity = &filled
+			step.CounterOrderId = &counter
+		case eventlog.OrderCanceled:
+			var canceled eventlog.OrderCanceledPayload
+			if err := json.Unmarshal(event.Payload, &canceled); err != nil {
+				return OrderTimelineSchema{}, err
+			}
+			step.Type = OrderEventCanceled
+			if canceled.Reason == eventlog.CancelReplaced {
+				step.Type = OrderEventAmended
+			}
+			if canceled.Reason != "" {
+				step.Reason = &canceled.Reason
+			}
+		default:
+			continue
+		}
+		timeline.Events = append(timeline.Events, step)
+	}
+	return timeline, nil
+}
+
+// orderEvents reads the order, its instrument and its events in one unit of
+// work so they agree
+func (s *Service) orderEvents(ctx context.Context, id uuid.UUID) (OrderBook, InstrumentWithAssetsSchema, []eventlog.Event, error) {
+	var (
+		order      OrderBook
+		instrument InstrumentWithAssetsSchema
+		events     []eventlog.Event
+	)
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		if order, err = tx.Orders().Get(ctx, id); err != nil {
+			return err
+		}
+		if instrument, err = tx.Instruments().Get(ctx, order.InstrumentId); err != nil {
+			return err
+		}
+		events, err = eventlog.ListOrderEvents(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return order, instrument, nil, helper.NotFound("Order")
+		}
+		return order, instrument, nil, err
+	}
+	return order, instrument, events, nil
+}
+
+// counterOrder returns the order the match paired the order with
+func counterOrder(order OrderBook, match eventlog.OrderMatchedPayload) uuid.UUID {
+	if order.Id == match.BuyOrderId {
+		return match.SellOrderId
+	}
+	return match.BuyOrderId
+}
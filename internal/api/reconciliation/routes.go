@@ -0,0 +1,28 @@
+package reconciliation
+
+import (
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/openapi"
+	"github.com/JhonesBR/go-clob/internal/reconcile"
+	"github.com/gofiber/fiber/v3"
+)
+
+func InitializeRoutes(app *fiber.App, reconciler *reconcile.Reconciler) {
+	app.Get("/v1/admin/reconciliation", helper.AdminAuth(), GetLastReconciliationHandler(reconciler))
+	app.Post("/v1/admin/reconciliation", helper.AdminAuth(), RunReconciliationHandler(reconciler))
+}
+
+// Operations documents the routes in the OpenAPI document
+var Operations = []openapi.Operation{
+	{
+		Method: fiber.MethodGet, Path: "/v1/admin/reconciliation", Tag: "Admin", Admin: true,
+		Summary:  "Get the report of the last reconciliation",
+		Response: reconcile.Report{},
+		Errors:   []int{fiber.StatusNotFound},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/admin/reconciliation", Tag: "Admin", Admin: true,
+		Summary:  "Reconcile balances with movements and trades",
+		Response: reconcile.Report{},
+	},
+}
@@ -0,0 +1,29 @@
+package reconciliation
+
+import (
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/reconcile"
+	"github.com/gofiber/fiber/v3"
+)
+
+func GetLastReconciliationHandler(reconciler *reconcile.Reconciler) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		report := reconciler.Last()
+		if report == nil {
+			return helper.Error{Status: fiber.StatusNotFound, Code: helper.CodeReconciliationNotFound, Message: "No reconciliation ran yet"}
+		}
+
+		return c.JSON(report)
+	}
+}
+
+func RunReconciliationHandler(reconciler *reconcile.Reconciler) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		report, err := reconciler.Run(helper.Context(c))
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(report)
+	}
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/ratelimit"
+	"github.com/JhonesBR/go-clob/pkg/signing"
+	"github.com/gofiber/fiber/v3"
+)
+
+// verifySignatures refuses the requests not signed with secret, see
+// pkg/signing for the scheme
+func verifySignatures(secret string) fiber.Handler {
+	key := []byte(secret)
+	return func(c fiber.Ctx) error {
+		err := signing.Verify(key, c.Get(signing.TimestampHeader), c.Get(signing.SignatureHeader), time.Now(), c.Method(), c.OriginalURL(), c.Body())
+		if err != nil {
+			return helper.Error{Status: fiber.StatusUnauthorized, Code: helper.CodeInvalidSignature, Message: err.Error()}
+		}
+		ratelimit.Verify(c)
+		return c.Next()
+	}
+}
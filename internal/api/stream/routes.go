@@ -0,0 +1,35 @@
+package stream
+
+import (
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/marketdata"
+	"github.com/JhonesBR/go-clob/internal/openapi"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+)
+
+func InitializeRoutes(app *fiber.App, store storage.Store, orders *orderbook.Service, feed *marketdata.Feed) {
+	app.Get("/v1/instruments/:id/trades/stream", StreamTradesHandler(store, feed))
+	app.Get("/v1/instruments/:id/book/stream", StreamBookHandler(orders, feed))
+}
+
+// Operations documents the routes in the OpenAPI document
+var Operations = []openapi.Operation{
+	{
+		Method: fiber.MethodGet, Path: "/v1/instruments/:id/trades/stream", Tag: "Market data",
+		Summary:  "WebSocket sending each trade of an instrument as a message",
+		Status:   fiber.StatusSwitchingProtocols,
+		Response: TradeSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusUpgradeRequired},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/instruments/:id/book/stream", Tag: "Market data",
+		Summary: "WebSocket sending the book of an instrument aggregated per price level, then again on every change",
+		Query: []openapi.Parameter{
+			{Name: "depth", Description: "Price levels per side, all when 0 or missing", Type: 0},
+		},
+		Status:   fiber.StatusSwitchingProtocols,
+		Response: orderbook.BookSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusUpgradeRequired},
+	},
+}
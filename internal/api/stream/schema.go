@@ -0,0 +1,19 @@
+package stream
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TradeSchema is a message of the trade stream
+type TradeSchema struct {
+	Sequence     int64           `json:"sequence" validate:"required"`
+	InstrumentId uuid.UUID       `json:"instrument_id" validate:"required"`
+	BuyOrderId   uuid.UUID       `json:"buy_order_id" validate:"required"`
+	SellOrderId  uuid.UUID       `json:"sell_order_id" validate:"required"`
+	Price        decimal.Decimal `json:"price" validate:"required"`
+	Quantity     decimal.Decimal `json:"quantity" validate:"required"`
+	ExecutedAt   time.Time       `json:"executed_at" validate:"required"`
+}
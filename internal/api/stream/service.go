@@ -0,0 +1,181 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/marketdata"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+const (
+	// writeTimeout drops clients that stop reading
+	writeTimeout = 10 * time.Second
+	pingInterval = 30 * time.Second
+)
+
+var upgrader = websocket.FastHTTPUpgrader{}
+
+// StreamTradesHandler upgrades to a WebSocket sending the trades of the
+// instrument executed after the connection
+func StreamTradesHandler(store storage.Store, feed *marketdata.Feed) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+		if !websocket.FastHTTPIsWebSocketUpgrade(c.RequestCtx()) {
+			return fiber.ErrUpgradeRequired
+		}
+		ctx := helper.Context(c)
+
+		// Fail before upgrading on unknown instruments
+		err = store.WithTx(ctx, func(tx storage.Tx) error {
+			_, err := tx.Instruments().Get(ctx, id)
+			return err
+		})
+		if errors.Is(err, storage.ErrNotFound) {
+			return helper.NotFound("Instrument")
+		}
+		if err != nil {
+			return err
+		}
+
+		subscription := feed.SubscribeTrades(id)
+		err = upgrader.Upgrade(c.RequestCtx(), func(conn *websocket.Conn) {
+			defer subscription.Close()
+			send(ctx, conn, subscription, nil, func(update marketdata.Update) (any, error) {
+				trade := update.Trade
+				return TradeSchema{
+					Sequence:     trade.Sequence,
+					InstrumentId: trade.InstrumentId,
+					BuyOrderId:   trade.BuyOrderId,
+					SellOrderId:  trade.SellOrderId,
+					Price:        trade.Price,
+					Quantity:     trade.Quantity,
+					ExecutedAt:   trade.ExecutedAt,
+				}, nil
+			})
+		})
+		if err != nil {
+			subscription.Close()
+		}
+		return err
+	}
+}
+
+// StreamBookHandler upgrades to a WebSocket sending the book of the
+// instrument, then the book again each time it changes
+func StreamBookHandler(orders *orderbook.Service, feed *marketdata.Feed) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+		depth, err := strconv.Atoi(c.Query("depth", "0"))
+		if err != nil || depth < 0 {
+			return helper.InvalidRequest("depth must be a positive integer")
+		}
+		if !websocket.FastHTTPIsWebSocketUpgrade(c.RequestCtx()) {
+			return fiber.ErrUpgradeRequired
+		}
+		ctx := helper.Context(c)
+
+		// Subscribe before reading the book so no change is missed in between
+		subscription := feed.SubscribeBook(id)
+		book, err := orders.Book(ctx, id, depth)
+		if err != nil {
+			subscription.Close()
+			return err
+		}
+
+		err = upgrader.Upgrade(c.RequestCtx(), func(conn *websocket.Conn) {
+			defer subscription.Close()
+			sent := book.Sequence
+			send(ctx, conn, subscription, book, func(marketdata.Update) (any, error) {
+				book, err := orders.Book(ctx, id, depth)
+				if err != nil || book.Sequence == sent {
+					return nil, err
+				}
+				sent = book.Sequence
+				return book, nil
+			})
+		})
+		if err != nil {
+			subscription.Close()
+		}
+		return err
+	}
+}
+
+// send writes first, when not nil, and the message of each update as JSON
+// until the subscription ends or the client goes away. A nil message is
+// skipped.
+func send(ctx context.Context, conn *websocket.Conn, subscription *marketdata.Subscription, first any, message func(marketdata.Update) (any, error)) {
+	defer conn.Close()
+
+	// Reading notices the client closing, messages from the client are ignored
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(msg any) bool {
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		return conn.WriteJSON(msg) == nil
+	}
+	if first != nil && !write(first) {
+		return
+	}
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-gone:
+			return
+		case <-ctx.Done():
+			closeWith(conn, websocket.CloseGoingAway, "server shutting down")
+			return
+		case <-ping.C:
+			if conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)) != nil {
+				return
+			}
+		case update, ok := <-subscription.C:
+			if !ok {
+				if errors.Is(subscription.Err(), marketdata.ErrSlowSubscriber) {
+					closeWith(conn, websocket.CloseTryAgainLater, subscription.Err().Error())
+				} else {
+					closeWith(conn, websocket.CloseGoingAway, "server shutting down")
+				}
+				return
+			}
+			msg, err := message(update)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to stream market data", "error", err)
+				closeWith(conn, websocket.CloseInternalServerErr, "internal error")
+				return
+			}
+			if msg != nil && !write(msg) {
+				return
+			}
+		}
+	}
+}
+
+func closeWith(conn *websocket.Conn, code int, reason string) {
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeTimeout))
+}
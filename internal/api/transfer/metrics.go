@@ -0,0 +1,11 @@
+package transfer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var transfersCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "clob_transfers_total",
+	Help: "Transfers between accounts, replays of an idempotency key excluded.",
+}, []string{"asset"})
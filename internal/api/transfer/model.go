@@ -0,0 +1,7 @@
+package transfer
+
+import "github.com/JhonesBR/go-clob/internal/storage"
+
+// Representative (schemas will be used for validation and documentation)
+
+type Transfer = storage.Transfer
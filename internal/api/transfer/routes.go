@@ -0,0 +1,43 @@
+package transfer
+
+import (
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/openapi"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func InitializeRoutes(app *fiber.App, store storage.Store) {
+	service := NewService(store)
+	app.Post("/v1/transfers", CreateTransferHandler(service))
+	app.Get("/v1/transfers", GetTransfersHandler(service))
+	app.Get("/v1/transfers/:id", GetTransferHandler(service))
+}
+
+// Operations documents the routes in the OpenAPI document
+var Operations = []openapi.Operation{
+	{
+		Method: fiber.MethodPost, Path: "/v1/transfers", Tag: "Transfers",
+		Summary:  "Move an asset between two accounts, a retry with the same Idempotency-Key returns the transfer already made",
+		Body:     CreateTransferSchema{},
+		Status:   fiber.StatusCreated,
+		Response: TransferShowSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusPaymentRequired, fiber.StatusNotFound, fiber.StatusUnprocessableEntity},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/transfers", Tag: "Transfers",
+		Summary: "List transfers, oldest first by default",
+		Query: append([]openapi.Parameter{
+			{Name: "account_id", Description: "Transfers out of or into the account", Type: uuid.UUID{}},
+		}, openapi.PageParameters(TransferSorts...)...),
+		Response: helper.Pagination[TransferShowSchema]{},
+		Errors:   []int{fiber.StatusBadRequest},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/v1/transfers/:id", Tag: "Transfers",
+		Summary:  "Get a transfer",
+		Response: TransferShowSchema{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+	},
+}
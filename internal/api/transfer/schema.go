@@ -0,0 +1,45 @@
+package transfer
+
+import (
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type CreateTransferSchema struct {
+	FromAccountId *uuid.UUID       `json:"from_account_id" validate:"required"`
+	ToAccountId   *uuid.UUID       `json:"to_account_id" validate:"required"`
+	AssetCode     *string          `json:"asset_code" validate:"required"`
+	Amount        *decimal.Decimal `json:"amount" validate:"required"`
+	Memo          string           `json:"memo" validate:"max=255"`
+}
+
+// TransferShowSchema is a transfer between accounts, Memo is null when the
+// request did not send one
+type TransferShowSchema struct {
+	Id            uuid.UUID       `json:"id" validate:"required"`
+	FromAccountId uuid.UUID       `json:"from_account_id" validate:"required"`
+	ToAccountId   uuid.UUID       `json:"to_account_id" validate:"required"`
+	AssetCode     string          `json:"asset_code" validate:"required"`
+	Amount        decimal.Decimal `json:"amount" validate:"required"`
+	Memo          *string         `json:"memo"`
+	CreatedAt     time.Time       `json:"created_at" validate:"required"`
+}
+
+func NewTransferShowSchema(transfer storage.Transfer) TransferShowSchema {
+	var memo *string
+	if transfer.Memo != "" {
+		memo = &transfer.Memo
+	}
+	return TransferShowSchema{
+		Id:            transfer.Id,
+		FromAccountId: transfer.FromAccountId,
+		ToAccountId:   transfer.ToAccountId,
+		AssetCode:     transfer.AssetCode,
+		Amount:        transfer.Amount,
+		Memo:          memo,
+		CreatedAt:     transfer.CreatedAt,
+	}
+}
@@ -0,0 +1,70 @@
+package transfer
+
+import (
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/idempotency"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func CreateTransferHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		var request CreateTransferSchema
+		if err := c.Bind().Body(&request); err != nil {
+			return helper.InvalidRequest("Malformed request body")
+		}
+
+		// The key is kept with the transfer, so a retry finds it after the
+		// idempotency middleware forgot the response or on another instance
+		transfer, replayed, err := service.Transfer(helper.Context(c), c.Get(idempotency.Header), request)
+		if err != nil {
+			return err
+		}
+
+		if replayed {
+			c.Set(idempotency.ReplayedHeader, "true")
+		}
+		return c.Status(fiber.StatusCreated).JSON(transfer)
+	}
+}
+
+func GetTransfersHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		page, err := helper.GetPage(c, TransferSorts...)
+		if err != nil {
+			return err
+		}
+		var filter storage.TransferFilter
+		if c.Query("account_id") != "" {
+			accountId, err := uuid.Parse(c.Query("account_id"))
+			if err != nil {
+				return helper.InvalidId("account_id")
+			}
+			filter.AccountId = &accountId
+		}
+
+		pagination, err := service.List(helper.Context(c), filter, page)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(pagination)
+	}
+}
+
+func GetTransferHandler(service *Service) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return helper.InvalidId("id")
+		}
+
+		transfer, err := service.Get(helper.Context(c), id)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(transfer)
+	}
+}
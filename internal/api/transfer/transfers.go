@@ -0,0 +1,199 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/audit"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TransferSorts are the sorts of the transfer list, oldest first by default
+var TransferSorts = []string{"created_at", "-created_at"}
+
+// Service moves assets between accounts, refusals are helper.Error
+type Service struct {
+	store storage.Store
+}
+
+func NewService(store storage.Store) *Service {
+	return &Service{store: store}
+}
+
+// Transfer moves the amount from an account to the other in a single
+// transaction, recording a movement on each side. A transfer requested again
+// with the idempotency key of one the source account already made returns
+// that one, replayed is then true.
+func (s *Service) Transfer(ctx context.Context, key string, request CreateTransferSchema) (_ TransferShowSchema, replayed bool, _ error) {
+	if err := helper.ValidateInput(&request); err != nil {
+		return TransferShowSchema{}, false, helper.Invalid(err)
+	}
+	if !request.Amount.IsPositive() {
+		return TransferShowSchema{}, false, helper.Invalid(errors.New("amount must be positive"))
+	}
+	if *request.FromAccountId == *request.ToAccountId {
+		return TransferShowSchema{}, false, helper.Invalid(errors.New("accounts must be different"))
+	}
+
+	var transfer Transfer
+	move := func(tx storage.Tx) error {
+		replayed = false
+		if key != "" {
+			previous, err := tx.Transfers().GetByIdempotencyKey(ctx, *request.FromAccountId, key)
+			if err == nil {
+				if !sameTransfer(previous, request) {
+					return helper.Error{Status: fiber.StatusUnprocessableEntity, Code: helper.CodeIdempotencyKeyReused, Message: "Idempotency key was used with another transfer"}
+				}
+				transfer, replayed = previous, true
+				return nil
+			}
+			if !errors.Is(err, storage.ErrNotFound) {
+				return err
+			}
+		}
+
+		for _, accountId := range []uuid.UUID{*request.FromAccountId, *request.ToAccountId} {
+			if _, err := tx.Accounts().Get(ctx, accountId); err != nil {
+				if errors.Is(err, storage.ErrNotFound) {
+					return helper.NotFound("Account")
+				}
+				return err
+			}
+		}
+		asset, err := tx.Assets().GetByCode(ctx, *request.AssetCode)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return helper.NotFound("Asset")
+			}
+			return err
+		}
+
+		// Lock both balances in account id order, so transfers going opposite
+		// ways between the same accounts wait for each other instead of deadlocking
+		accountIds := []uuid.UUID{*request.FromAccountId, *request.ToAccountId}
+		slices.SortFunc(accountIds, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+		balances := map[uuid.UUID]*decimal.Decimal{}
+		for _, accountId := range accountIds {
+			balance, _, err := account.GetAccountBalance(ctx, tx, accountId, nil, &asset.Id)
+			if err != nil {
+				return err
+			}
+			if balance == nil && accountId == *request.ToAccountId {
+				created, err := account.CreateAccountBalanceForAccount(ctx, tx, accountId, asset.Id)
+				if err != nil {
+					return err
+				}
+				balance = &created
+			}
+			balances[accountId] = balance
+		}
+		from, to := balances[*request.FromAccountId], balances[*request.ToAccountId]
+		if from == nil || from.LessThan(*request.Amount) {
+			return helper.Error{Status: fiber.StatusPaymentRequired, Code: helper.CodeInsufficientFunds, Message: "Insufficient funds"}
+		}
+
+		transfer, err = tx.Transfers().Create(ctx, Transfer{
+			FromAccountId:  *request.FromAccountId,
+			ToAccountId:    *request.ToAccountId,
+			AssetId:        asset.Id,
+			Amount:         *request.Amount,
+			Memo:           request.Memo,
+			IdempotencyKey: key,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Debit and credit both sides, each with its movement so reconciliation
+		// expects the balances the transfer leaves
+		sides := []struct {
+			accountId uuid.UUID
+			balance   decimal.Decimal
+			kind      storage.MovementKind
+		}{
+			{transfer.FromAccountId, from.Sub(transfer.Amount), storage.TransferOut},
+			{transfer.ToAccountId, to.Add(transfer.Amount), storage.TransferIn},
+		}
+		for _, side := range sides {
+			if err := account.UpdateAccountBalance(ctx, tx, side.accountId, side.balance, asset.Id); err != nil {
+				return err
+			}
+			_, err := tx.Movements().Create(ctx, storage.Movement{
+				AccountId: side.accountId,
+				AssetId:   asset.Id,
+				Kind:      side.kind,
+				Amount:    transfer.Amount,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return audit.Record(ctx, tx, audit.Entry{
+			Actor:      audit.Account(transfer.FromAccountId),
+			Action:     audit.TransferCreated,
+			EntityType: "transfer",
+			EntityId:   transfer.Id.String(),
+			After:      transfer,
+		})
+	}
+	err := s.store.WithTx(ctx, move)
+	if errors.Is(err, storage.ErrDuplicate) {
+		// A request with the same key made the transfer in the meantime, the
+		// second run finds it and replays it
+		err = s.store.WithTx(ctx, move)
+	}
+	if err != nil {
+		return TransferShowSchema{}, false, err
+	}
+	if !replayed {
+		transfersCreated.WithLabelValues(transfer.AssetCode).Inc()
+	}
+	return NewTransferShowSchema(transfer), replayed, nil
+}
+
+// sameTransfer tells whether the request asks for the transfer already made
+func sameTransfer(transfer Transfer, request CreateTransferSchema) bool {
+	return transfer.ToAccountId == *request.ToAccountId &&
+		transfer.AssetCode == *request.AssetCode &&
+		transfer.Amount.Equal(*request.Amount) &&
+		transfer.Memo == request.Memo
+}
+
+// List returns a page of the transfers matching the filter, see helper.NewPage
+func (s *Service) List(ctx context.Context, filter storage.TransferFilter, page storage.Page) (helper.Pagination[TransferShowSchema], error) {
+	var pagination helper.Pagination[TransferShowSchema]
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		pagination, err = helper.Paginate(page, func(page storage.Page) ([]Transfer, error) {
+			return tx.Transfers().List(ctx, filter, page)
+		}, func(transfer Transfer) (TransferShowSchema, error) {
+			return NewTransferShowSchema(transfer), nil
+		})
+		return err
+	})
+	return pagination, err
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (TransferShowSchema, error) {
+	var transfer Transfer
+	err := s.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		transfer, err = tx.Transfers().Get(ctx, id)
+		return err
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		return TransferShowSchema{}, helper.NotFound("Transfer")
+	}
+	if err != nil {
+		return TransferShowSchema{}, err
+	}
+	return NewTransferShowSchema(transfer), nil
+}
@@ -0,0 +1,95 @@
+package transfer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/api/transfer"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/storage/postgres/postgrestest"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TestConcurrentTransfers sends transfers at the same time: opposite ways
+// between two accounts, then twice with the same idempotency key
+func TestConcurrentTransfers(t *testing.T) {
+	store := postgrestest.Store(t)
+	ctx := context.Background()
+	accounts := account.NewService(store)
+	transfers := transfer.NewService(store)
+
+	btc := "BTC"
+	hundred := decimal.NewFromInt(100)
+	newAccount := func() uuid.UUID {
+		var created storage.Account
+		err := store.WithTx(ctx, func(tx storage.Tx) error {
+			var err error
+			created, err = tx.Accounts().Create(ctx, "transfers-"+uuid.NewString())
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := accounts.Charge(ctx, created.Id, account.UpdateBalanceSchema{AssetCode: &btc, Amount: &hundred}); err != nil {
+			t.Fatal(err)
+		}
+		return created.Id
+	}
+	balance := func(accountId uuid.UUID) decimal.Decimal {
+		var balance *decimal.Decimal
+		err := store.WithTx(ctx, func(tx storage.Tx) error {
+			var err error
+			balance, _, err = account.GetAccountBalance(ctx, tx, accountId, &btc, nil)
+			return err
+		})
+		if err != nil || balance == nil {
+			t.Fatalf("balance of %s is %v, %v", accountId, balance, err)
+		}
+		return *balance
+	}
+	request := func(from, to uuid.UUID, amount int64) transfer.CreateTransferSchema {
+		value := decimal.NewFromInt(amount)
+		return transfer.CreateTransferSchema{FromAccountId: &from, ToAccountId: &to, AssetCode: &btc, Amount: &value}
+	}
+
+	a, b := newAccount(), newAccount()
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			from, to := a, b
+			if i%2 == 1 {
+				from, to = b, a
+			}
+			if _, _, err := transfers.Transfer(ctx, "", request(from, to, 10)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := balance(a).Add(balance(b)); !got.Equal(decimal.NewFromInt(200)) || !balance(a).Equal(hundred) {
+		t.Fatalf("balances are %s and %s, want 100 each", balance(a), balance(b))
+	}
+
+	key := uuid.NewString()
+	results := make([]transfer.TransferShowSchema, 2)
+	errs := make([]error, 2)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _, errs[i] = transfers.Transfer(ctx, key, request(a, b, 30))
+		}()
+	}
+	wg.Wait()
+	if errs[0] != nil || errs[1] != nil || results[0].Id != results[1].Id {
+		t.Fatalf("transfers with the same key are %+v, %v", results, errs)
+	}
+	if !balance(a).Equal(decimal.NewFromInt(70)) {
+		t.Fatalf("balance is %s, want 70", balance(a))
+	}
+}
@@ -0,0 +1,161 @@
+package auction
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Order is a working order taking part in the auction, Sequence gives the
+// time priority (lower first)
+type Order struct {
+	Id       uuid.UUID
+	Side     string
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+	Sequence int
+}
+
+type Fill struct {
+	BuyOrderId  uuid.UUID
+	SellOrderId uuid.UUID
+	Quantity    decimal.Decimal
+}
+
+// Result is the uncrossing outcome, Price is nil when the book does not cross
+type Result struct {
+	Price      *decimal.Decimal `json:"price"`
+	Volume     decimal.Decimal  `json:"volume"`
+	BuyVolume  decimal.Decimal  `json:"buy_volume"`
+	SellVolume decimal.Decimal  `json:"sell_volume"`
+	Fills      []Fill           `json:"-"`
+}
+
+// Imbalance is the quantity left unexecuted at the clearing price, positive
+// for buy surplus and negative for sell surplus
+func (r Result) Imbalance() decimal.Decimal {
+	return r.BuyVolume.Sub(r.SellVolume)
+}
+
+// Uncross computes the single clearing price maximizing the executed volume.
+// Ties are broken by the smallest imbalance, then by the closest price to
+// reference (when given) and finally by the lowest price.
+func Uncross(orders []Order, reference *decimal.Decimal) Result {
+	var bids, asks []Order
+	prices := map[string]decimal.Decimal{}
+	for _, order := range orders {
+		if !order.Quantity.IsPositive() {
+			continue
+		}
+		if order.Side == "buy" {
+			bids = append(bids, order)
+		} else {
+			asks = append(asks, order)
+		}
+		prices[order.Price.String()] = order.Price
+	}
+
+	result := Result{Volume: decimal.Zero, BuyVolume: decimal.Zero, SellVolume: decimal.Zero}
+	for _, price := range prices {
+		demand := decimal.Zero
+		for _, bid := range bids {
+			if bid.Price.GreaterThanOrEqual(price) {
+				demand = demand.Add(bid.Quantity)
+			}
+		}
+		supply := decimal.Zero
+		for _, ask := range asks {
+			if ask.Price.LessThanOrEqual(price) {
+				supply = supply.Add(ask.Quantity)
+			}
+		}
+
+		volume := decimal.Min(demand, supply)
+		if !volume.IsPositive() {
+			continue
+		}
+
+		candidate := Result{Price: &price, Volume: volume, BuyVolume: demand, SellVolume: supply}
+		if result.Price == nil || better(candidate, result, reference) {
+			result = candidate
+		}
+	}
+
+	if result.Price != nil {
+		result.Fills = allocate(bids, asks, *result.Price)
+	}
+	return result
+}
+
+func better(candidate, current Result, reference *decimal.Decimal) bool {
+	if !candidate.Volume.Equal(current.Volume) {
+		return candidate.Volume.GreaterThan(current.Volume)
+	}
+
+	candidateImbalance := candidate.Imbalance().Abs()
+	currentImbalance := current.Imbalance().Abs()
+	if !candidateImbalance.Equal(currentImbalance) {
+		return candidateImbalance.LessThan(currentImbalance)
+	}
+
+	if reference != nil {
+		candidateDistance := candidate.Price.Sub(*reference).Abs()
+		currentDistance := current.Price.Sub(*reference).Abs()
+		if !candidateDistance.Equal(currentDistance) {
+			return candidateDistance.LessThan(currentDistance)
+		}
+	}
+
+	return candidate.Price.LessThan(*current.Price)
+}
+
+// allocate pairs the eligible orders by price then time priority
+func allocate(bids, asks []Order, price decimal.Decimal) []Fill {
+	var eligibleBids, eligibleAsks []Order
+	for _, bid := range bids {
+		if bid.Price.GreaterThanOrEqual(price) {
+			eligibleBids = append(eligibleBids, bid)
+		}
+	}
+	for _, ask := range asks {
+		if ask.Price.LessThanOrEqual(price) {
+			eligibleAsks = append(eligibleAsks, ask)
+		}
+	}
+
+	sort.SliceStable(eligibleBids, func(i, j int) bool {
+		if !eligibleBids[i].Price.Equal(eligibleBids[j].Price) {
+			return eligibleBids[i].Price.GreaterThan(eligibleBids[j].Price)
+		}
+		return eligibleBids[i].Sequence < eligibleBids[j].Sequence
+	})
+	sort.SliceStable(eligibleAsks, func(i, j int) bool {
+		if !eligibleAsks[i].Price.Equal(eligibleAsks[j].Price) {
+			return eligibleAsks[i].Price.LessThan(eligibleAsks[j].Price)
+		}
+		return eligibleAsks[i].Sequence < eligibleAsks[j].Sequence
+	})
+
+	var fills []Fill
+	i, j := 0, 0
+	for i < len(eligibleBids) && j < len(eligibleAsks) {
+		quantity := decimal.Min(eligibleBids[i].Quantity, eligibleAsks[j].Quantity)
+		fills = append(fills, Fill{
+			BuyOrderId:  eligibleBids[i].Id,
+			SellOrderId: eligibleAsks[j].Id,
+			Quantity:    quantity,
+		})
+
+		eligibleBids[i].Quantity = eligibleBids[i].Quantity.Sub(quantity)
+		eligibleAsks[j].Quantity = eligibleAsks[j].Quantity.Sub(quantity)
+		if eligibleBids[i].Quantity.IsZero() {
+			i++
+		}
+		if eligibleAsks[j].Quantity.IsZero() {
+			j++
+		}
+	}
+
+	return fills
+}
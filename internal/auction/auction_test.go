@@ -0,0 +1,119 @@
+package auction_test
+
+import (
+	"testing"
+
+	"github.com/JhonesBR/go-clob/internal/auction"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func order(side string, price, quantity int64, sequence int) auction.Order {
+	return auction.Order{
+		Id:       uuid.New(),
+		Side:     side,
+		Price:    decimal.NewFromInt(price),
+		Quantity: decimal.NewFromInt(quantity),
+		Sequence: sequence,
+	}
+}
+
+func TestUncrossPrice(t *testing.T) {
+	reference := decimal.NewFromInt(102)
+	tests := []struct {
+		name      string
+		orders    []auction.Order
+		reference *decimal.Decimal
+		// price is 0 when the book does not cross
+		price     int64
+		volume    int64
+		imbalance int64
+	}{
+		{
+			name:   "book does not cross",
+			orders: []auction.Order{order("buy", 98, 5, 0), order("sell", 99, 5, 1)},
+		},
+		{
+			// 90 executes 4, 100 executes 10
+			name:   "max volume",
+			orders: []auction.Order{order("buy", 100, 10, 0), order("sell", 90, 4, 1), order("sell", 100, 6, 2)},
+			price:  100, volume: 10,
+		},
+		{
+			// Both execute 5, 99 leaves 3 to buy and 101 nothing
+			name:   "min imbalance",
+			orders: []auction.Order{order("buy", 101, 5, 0), order("buy", 99, 3, 1), order("sell", 99, 5, 2)},
+			price:  101, volume: 5,
+		},
+		{
+			name:      "closest to the reference",
+			orders:    []auction.Order{order("buy", 101, 5, 0), order("sell", 99, 5, 1)},
+			reference: &reference,
+			price:     101, volume: 5,
+		},
+		{
+			name:   "lowest price",
+			orders: []auction.Order{order("buy", 101, 5, 0), order("sell", 99, 5, 1)},
+			price:  99, volume: 5,
+		},
+		{
+			name:   "empty orders ignored",
+			orders: []auction.Order{order("buy", 120, 0, 0), order("buy", 101, 5, 1), order("sell", 99, 5, 2)},
+			price:  99, volume: 5,
+		},
+		{
+			// The surplus to sell at 100 is reported as a negative imbalance
+			name:   "imbalance reported",
+			orders: []auction.Order{order("buy", 100, 3, 0), order("sell", 100, 5, 1)},
+			price:  100, volume: 3, imbalance: -2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := auction.Uncross(test.orders, test.reference)
+			if test.price == 0 {
+				if result.Price != nil || len(result.Fills) > 0 {
+					t.Fatalf("got price %s and %d fills, want no uncrossing", result.Price, len(result.Fills))
+				}
+				return
+			}
+			if result.Price == nil || !result.Price.Equal(decimal.NewFromInt(test.price)) {
+				t.Fatalf("got price %v, want %d", result.Price, test.price)
+			}
+			if !result.Volume.Equal(decimal.NewFromInt(test.volume)) {
+				t.Errorf("got volume %s, want %d", result.Volume, test.volume)
+			}
+			if !result.Imbalance().Equal(decimal.NewFromInt(test.imbalance)) {
+				t.Errorf("got imbalance %s, want %d", result.Imbalance(), test.imbalance)
+			}
+		})
+	}
+}
+
+// TestUncrossAllocation fills the best priced orders first, then the oldest
+// ones at the same price
+func TestUncrossAllocation(t *testing.T) {
+	early := order("buy", 101, 3, 1)
+	late := order("buy", 101, 3, 2)
+	best := order("buy", 102, 3, 3)
+	ask := order("sell", 100, 5, 0)
+
+	result := auction.Uncross([]auction.Order{late, ask, best, early}, nil)
+	if result.Price == nil || !result.Price.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("got price %v, want 100", result.Price)
+	}
+
+	want := []auction.Fill{
+		{BuyOrderId: best.Id, SellOrderId: ask.Id, Quantity: decimal.NewFromInt(3)},
+		{BuyOrderId: early.Id, SellOrderId: ask.Id, Quantity: decimal.NewFromInt(2)},
+	}
+	if len(result.Fills) != len(want) {
+		t.Fatalf("got fills %+v, want %+v", result.Fills, want)
+	}
+	for i, fill := range result.Fills {
+		if fill.BuyOrderId != want[i].BuyOrderId || fill.SellOrderId != want[i].SellOrderId || !fill.Quantity.Equal(want[i].Quantity) {
+			t.Errorf("fill %d is %+v, want %+v", i, fill, want[i])
+		}
+	}
+}
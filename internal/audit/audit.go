@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/JhonesBR/go-clob/internal/logging"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+)
+
+// Actors other than accounts
+const (
+	Admin  = "admin"
+	System = "system"
+)
+
+// Account is the actor of changes requested on behalf of an account
+func Account(id uuid.UUID) string {
+	return "account:" + id.String()
+}
+
+const (
+	AccountCreated          = "account.created"
+	BalanceCharged          = "balance.charged"
+	BalanceRemoved          = "balance.removed"
+	OrderPlaced             = "order.placed"
+	OrderCanceled           = "order.canceled"
+	InstrumentStatusChanged = "instrument.status_changed"
+	SnapshotTaken           = "event_log.snapshot_taken"
+	FundingRequested        = "funding.requested"
+	FundingScreened         = "funding.screened"
+	FundingApproved         = "funding.approved"
+	FundingRejected         = "funding.rejected"
+	FundingSubmitted        = "funding.submitted"
+	FundingCompleted        = "funding.completed"
+	FundingFailed           = "funding.failed"
+	TransferCreated         = "transfer.created"
+)
+
+// Entry is what Record stores, Before and After are marshaled to JSON and
+// left null when nil
+type Entry struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityId   string
+	Before     any
+	After      any
+}
+
+// Record appends the entry to the audit log in the unit of work of the
+// change, tagged with the request id of the context
+func Record(ctx context.Context, tx storage.Tx, entry Entry) error {
+	before, err := marshal(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshal(entry.After)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Audit().Append(ctx, storage.AuditEntry{
+		Actor:      entry.Actor,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityId:   entry.EntityId,
+		Before:     before,
+		After:      after,
+		RequestId:  logging.RequestId(ctx),
+	})
+	return err
+}
+
+func marshal(value any) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
+
+// RecordCommitted appends the entry on its own unit of work, used for actions
+// that commit their changes themselves
+func RecordCommitted(ctx context.Context, store storage.Store, entry Entry) error {
+	return store.WithTx(ctx, func(tx storage.Tx) error {
+		return Record(ctx, tx, entry)
+	})
+}
@@ -0,0 +1,225 @@
+package circuitbreaker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type State string
+
+const (
+	Trading State = "trading"
+	Halted  State = "halted"
+)
+
+// Config halts an instrument when a trade price moves more than
+// ThresholdPercent from any trade in the last Window, for CoolOff. With
+// ResumeWithAuction orders accumulate during the halt and the book is
+// uncrossed by a call auction when trading resumes.
+type Config struct {
+	ThresholdPercent  decimal.Decimal
+	Window            time.Duration
+	CoolOff           time.Duration
+	ResumeWithAuction bool
+}
+
+func DefaultConfig() Config {
+	return Config{
+		ThresholdPercent: decimal.NewFromInt(10),
+		Window:           5 * time.Minute,
+		CoolOff:          5 * time.Minute,
+	}
+}
+
+type Status struct {
+	State       State      `json:"state"`
+	HaltedUntil *time.Time `json:"halted_until"`
+	Reason      string     `json:"reason,omitempty"`
+}
+
+type pricePoint struct {
+	price decimal.Decimal
+	at    time.Time
+}
+
+type instrumentState struct {
+	trades         []pricePoint
+	haltedUntil    time.Time
+	reason         string
+	pendingAuction bool
+}
+
+type Breaker struct {
+	config      Config
+	mu          sync.Mutex
+	instruments map[uuid.UUID]*instrumentState
+	now         func() time.Time
+}
+
+func New(config Config) *Breaker {
+	return &Breaker{
+		config:      config,
+		instruments: make(map[uuid.UUID]*instrumentState),
+		now:         time.Now,
+	}
+}
+
+// Trades are the trades of a unit of work on an instrument. They are checked
+// against the rolling window as they happen but only reach the breaker, with
+// the halt they trip, on Commit once the unit of work committed.
+type Trades struct {
+	breaker      *Breaker
+	instrumentId uuid.UUID
+	prices       []decimal.Decimal
+	halt         string
+	auction      bool
+}
+
+// Begin starts collecting the trades of a unit of work on the instrument
+func (b *Breaker) Begin(instrumentId uuid.UUID) *Trades {
+	return &Trades{breaker: b, instrumentId: instrumentId}
+}
+
+// Allow reports if a trade at price can happen. It cannot while the
+// instrument is halted, nor when the price moves beyond the threshold from a
+// trade inside the rolling window, which halts the instrument on Commit.
+func (t *Trades) Allow(price decimal.Decimal) bool {
+	if t.halt != "" {
+		return false
+	}
+
+	b := t.breaker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	state := b.state(t.instrumentId, now)
+	if now.Before(state.haltedUntil) {
+		return false
+	}
+
+	hundred := decimal.NewFromInt(100)
+	check := func(previous decimal.Decimal) bool {
+		if previous.IsZero() {
+			return true
+		}
+		move := price.Sub(previous).Abs().Div(previous).Mul(hundred)
+		if move.GreaterThan(b.config.ThresholdPercent) {
+			t.halt = fmt.Sprintf("price moved %s%% (from %s to %s) within %s", move.StringFixed(2), previous, price, b.config.Window)
+			return false
+		}
+		return true
+	}
+	for _, trade := range state.trades {
+		if !check(trade.price) {
+			return false
+		}
+	}
+	for _, previous := range t.prices {
+		if !check(previous) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Record adds an executed trade price to the trades of the unit of work
+func (t *Trades) Record(price decimal.Decimal) {
+	t.prices = append(t.prices, price)
+}
+
+// Commit adds the trades to the rolling window, halts the instrument when one
+// of them was refused for moving the price beyond the threshold and clears
+// the pending auction the unit of work ran
+func (t *Trades) Commit() {
+	b := t.breaker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	state := b.state(t.instrumentId, now)
+	for _, price := range t.prices {
+		state.trades = append(state.trades, pricePoint{price: price, at: now})
+	}
+	if t.auction {
+		state.pendingAuction = false
+	}
+	if t.halt != "" && !now.Before(state.haltedUntil) {
+		state.haltedUntil = now.Add(b.config.CoolOff)
+		state.reason = t.halt
+	}
+}
+
+// Record adds a trade price executed by a committed unit of work to the
+// rolling window
+func (b *Breaker) Record(instrumentId uuid.UUID, price decimal.Decimal) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	state := b.state(instrumentId, now)
+	state.trades = append(state.trades, pricePoint{price: price, at: now})
+}
+
+func (b *Breaker) ResumesWithAuction() bool {
+	return b.config.ResumeWithAuction
+}
+
+// PendingAuction reports that a halt ended and the book must be uncrossed
+// before continuous trading resumes. The auction stays pending until Commit,
+// so it runs again when the unit of work running it rolls back.
+func (t *Trades) PendingAuction() bool {
+	b := t.breaker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t.auction = b.state(t.instrumentId, b.now()).pendingAuction
+	return t.auction
+}
+
+func (b *Breaker) Halted(instrumentId uuid.UUID) bool {
+	return b.Status(instrumentId).State == Halted
+}
+
+func (b *Breaker) Status(instrumentId uuid.UUID) Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	state := b.state(instrumentId, now)
+	if now.Before(state.haltedUntil) {
+		haltedUntil := state.haltedUntil
+		return Status{State: Halted, HaltedUntil: &haltedUntil, Reason: state.reason}
+	}
+	return Status{State: Trading}
+}
+
+// state returns the instrument state dropping trades outside of the window.
+// The window restarts after a halt so the cool-off resumes from a clean state.
+func (b *Breaker) state(instrumentId uuid.UUID, now time.Time) *instrumentState {
+	state, ok := b.instruments[instrumentId]
+	if !ok {
+		state = &instrumentState{}
+		b.instruments[instrumentId] = state
+	}
+
+	if !state.haltedUntil.IsZero() && !now.Before(state.haltedUntil) {
+		state.haltedUntil = time.Time{}
+		state.reason = ""
+		state.trades = nil
+		state.pendingAuction = b.config.ResumeWithAuction
+	}
+
+	cut := 0
+	for cut < len(state.trades) && now.Sub(state.trades[cut].at) > b.config.Window {
+		cut++
+	}
+	state.trades = state.trades[cut:]
+
+	return state
+}
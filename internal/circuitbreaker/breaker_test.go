@@ -0,0 +1,176 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// clock is a time source moved forward by hand
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newTestBreaker(config Config) (*Breaker, *clock) {
+	c := &clock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := New(config)
+	b.now = c.Now
+	return b, c
+}
+
+func prices(values ...int64) []decimal.Decimal {
+	var list []decimal.Decimal
+	for _, value := range values {
+		list = append(list, decimal.NewFromInt(value))
+	}
+	return list
+}
+
+func TestAllow(t *testing.T) {
+	tests := []struct {
+		name string
+		// committed are recorded by a previous unit of work, elapsed before
+		// the unit of work under test records pending and checks price
+		committed []decimal.Decimal
+		elapsed   time.Duration
+		pending   []decimal.Decimal
+		price     int64
+		allowed   bool
+	}{
+		{name: "no trade yet", price: 1000, allowed: true},
+		{name: "within threshold", committed: prices(100), price: 110, allowed: true},
+		{name: "above threshold", committed: prices(100), price: 111, allowed: false},
+		{name: "below threshold", committed: prices(100), price: 89, allowed: false},
+		{name: "any trade of the window", committed: prices(100, 120), price: 125, allowed: false},
+		{name: "trade left the window", committed: prices(100), elapsed: 6 * time.Minute, price: 150, allowed: true},
+		{name: "trade still in the window", committed: prices(100), elapsed: 4 * time.Minute, price: 150, allowed: false},
+		{name: "zero price ignored", committed: prices(0), price: 100, allowed: true},
+		{name: "trades of the unit of work", pending: prices(100), price: 120, allowed: false},
+		{name: "trades of the unit of work within threshold", pending: prices(100, 105), price: 109, allowed: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, c := newTestBreaker(DefaultConfig())
+			id := uuid.New()
+
+			previous := b.Begin(id)
+			for _, price := range test.committed {
+				previous.Record(price)
+			}
+			previous.Commit()
+			c.now = c.now.Add(test.elapsed)
+
+			trades := b.Begin(id)
+			for _, price := range test.pending {
+				trades.Record(price)
+			}
+			if got := trades.Allow(decimal.NewFromInt(test.price)); got != test.allowed {
+				t.Fatalf("Allow(%d) = %v, want %v", test.price, got, test.allowed)
+			}
+
+			// The halt only starts once the unit of work commits
+			if b.Halted(id) {
+				t.Fatal("halted before commit")
+			}
+			trades.Commit()
+			if got := b.Halted(id); got == test.allowed {
+				t.Errorf("halted after commit = %v, want %v", got, !test.allowed)
+			}
+		})
+	}
+}
+
+func TestRolledBackTradesAreForgotten(t *testing.T) {
+	b, _ := newTestBreaker(DefaultConfig())
+	id := uuid.New()
+
+	// Neither the trades nor the halt of a unit of work that did not commit count
+	trades := b.Begin(id)
+	trades.Record(decimal.NewFromInt(100))
+	if trades.Allow(decimal.NewFromInt(200)) {
+		t.Fatal("move of 100% allowed")
+	}
+
+	if b.Halted(id) {
+		t.Error("halted by a unit of work that did not commit")
+	}
+	if !b.Begin(id).Allow(decimal.NewFromInt(200)) {
+		t.Error("trade refused against a price that was not committed")
+	}
+}
+
+func TestCoolOff(t *testing.T) {
+	b, c := newTestBreaker(DefaultConfig())
+	id := uuid.New()
+
+	trades := b.Begin(id)
+	trades.Record(decimal.NewFromInt(100))
+	trades.Allow(decimal.NewFromInt(150))
+	trades.Commit()
+
+	status := b.Status(id)
+	if status.State != Halted || status.Reason == "" || !status.HaltedUntil.Equal(c.now.Add(5*time.Minute)) {
+		t.Fatalf("got status %+v, want halted for 5 minutes with a reason", status)
+	}
+	if b.Begin(id).Allow(decimal.NewFromInt(100)) {
+		t.Error("trade allowed while halted")
+	}
+
+	// The window restarts after the halt, the price may have moved anyway
+	c.now = c.now.Add(5 * time.Minute)
+	if status := b.Status(id); status.State != Trading {
+		t.Fatalf("got status %+v after the cool-off, want trading", status)
+	}
+	if !b.Begin(id).Allow(decimal.NewFromInt(150)) {
+		t.Error("trade refused after the cool-off")
+	}
+}
+
+func TestPendingAuction(t *testing.T) {
+	tests := []struct {
+		name              string
+		resumeWithAuction bool
+		commit            bool
+		pendingAfter      bool
+	}{
+		{name: "resumes without auction", resumeWithAuction: false, commit: true, pendingAfter: false},
+		{name: "auction committed", resumeWithAuction: true, commit: true, pendingAfter: false},
+		{name: "auction rolled back", resumeWithAuction: true, commit: false, pendingAfter: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.ResumeWithAuction = test.resumeWithAuction
+			b, c := newTestBreaker(config)
+			id := uuid.New()
+
+			if b.Begin(id).PendingAuction() {
+				t.Fatal("auction pending before any halt")
+			}
+			halt := b.Begin(id)
+			halt.Record(decimal.NewFromInt(100))
+			halt.Allow(decimal.NewFromInt(150))
+			halt.Commit()
+			c.now = c.now.Add(config.CoolOff)
+
+			trades := b.Begin(id)
+			if got := trades.PendingAuction(); got != test.resumeWithAuction {
+				t.Fatalf("PendingAuction() = %v, want %v", got, test.resumeWithAuction)
+			}
+			if test.commit {
+				trades.Commit()
+			}
+			if got := b.Begin(id).PendingAuction(); got != test.pendingAfter {
+				t.Errorf("PendingAuction() afterwards = %v, want %v", got, test.pendingAfter)
+			}
+		})
+	}
+}
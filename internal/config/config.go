@@ -0,0 +1,258 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Config is the whole application configuration. Every setting has a default,
+// may be set in the file (YAML or TOML, keys as in the key tags), overridden by
+// the environment variable in its env tag and then by the -<path> flag, e.g.
+// -http.address. Fields tagged secret are redacted when printed.
+type Config struct {
+	HTTP           HTTP           `key:"http"`
+	GRPC           GRPC           `key:"grpc"`
+	Fix            Fix            `key:"fix"`
+	Log            Log            `key:"log"`
+	Tracing        Tracing        `key:"tracing"`
+	Storage        string         `key:"storage" env:"STORAGE"`
+	Database       Database       `key:"database"`
+	Admin          Admin          `key:"admin"`
+	Pagination     Pagination     `key:"pagination"`
+	Matching       Matching       `key:"matching"`
+	RateLimit      RateLimit      `key:"rate_limit"`
+	EventLog       EventLog       `key:"event_log"`
+	OrderHistory   OrderHistory   `key:"order_history"`
+	Funding        Funding        `key:"funding"`
+	Reconciliation Reconciliation `key:"reconciliation"`
+	Features       Features       `key:"features"`
+}
+
+type HTTP struct {
+	Address      string        `key:"address" env:"HTTP_ADDRESS"`
+	ReadTimeout  time.Duration `key:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	WriteTimeout time.Duration `key:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout  time.Duration `key:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	// ShutdownTimeout bounds how long in-flight requests are waited for on
+	// SIGINT or SIGTERM before being canceled
+	ShutdownTimeout time.Duration `key:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT"`
+	// SigningSecret, when set, is required to sign the /v1 requests
+	SigningSecret string `key:"signing_secret" env:"HTTP_SIGNING_SECRET" secret:"true"`
+	// IdempotencyTTL is how long the response to a request with an
+	// Idempotency-Key is replayed to its retries
+	IdempotencyTTL time.Duration `key:"idempotency_ttl" env:"HTTP_IDEMPOTENCY_TTL"`
+}
+
+type GRPC struct {
+	// Address the gRPC API listens on, it is off when empty
+	Address string `key:"address" env:"GRPC_ADDRESS"`
+}
+
+type Fix struct {
+	// Address the FIX 4.4 acceptor listens on, the gateway is off when empty
+	Address string `key:"address" env:"FIX_ADDRESS"`
+	// CompId is the SenderCompID of the exchange, counterparties address it
+	// as TargetCompID
+	CompId string `key:"comp_id" env:"FIX_COMP_ID"`
+	// Password, when set, must be sent on logon (tag 554)
+	Password string `key:"password" env:"FIX_PASSWORD" secret:"true"`
+}
+
+type Log struct {
+	// Level is debug, info, warn or error
+	Level string `key:"level" env:"LOG_LEVEL"`
+	// Format is json or text
+	Format string `key:"format" env:"LOG_FORMAT"`
+}
+
+type Tracing struct {
+	// Exporter is none, stdout or otlp
+	Exporter string `key:"exporter" env:"TRACING_EXPORTER"`
+	// Endpoint is the OTLP/HTTP collector url, the standard
+	// OTEL_EXPORTER_OTLP_* variables apply when it is empty
+	Endpoint string `key:"endpoint" env:"TRACING_ENDPOINT"`
+	// SampleRatio is the share of traces started here that are recorded,
+	// requests with a sampled parent are always recorded
+	SampleRatio float64 `key:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	ServiceName string  `key:"service_name" env:"TRACING_SERVICE_NAME"`
+}
+
+type Database struct {
+	URL             string        `key:"url" env:"DATABASE_URL" secret:"true"`
+	MaxConns        int           `key:"max_conns" env:"DATABASE_MAX_CONNS"`
+	MinConns        int           `key:"min_conns" env:"DATABASE_MIN_CONNS"`
+	MaxConnLifetime time.Duration `key:"max_conn_lifetime" env:"DATABASE_MAX_CONN_LIFETIME"`
+	MaxConnIdleTime time.Duration `key:"max_conn_idle_time" env:"DATABASE_MAX_CONN_IDLE_TIME"`
+	// LogQueries logs every query with its duration and request id
+	LogQueries bool `key:"log_queries" env:"DATABASE_LOG_QUERIES"`
+}
+
+type Admin struct {
+	// Token guards the admin endpoints, which are disabled when it is empty
+	Token string `key:"token" env:"ADMIN_TOKEN" secret:"true"`
+}
+
+type Pagination struct {
+	DefaultSize int `key:"default_size" env:"PAGINATION_DEFAULT_SIZE"`
+	MaxSize     int `key:"max_size" env:"PAGINATION_MAX_SIZE"`
+}
+
+type Matching struct {
+	// RiskConfig is the path of the JSON risk limits, no limit applies when empty
+	RiskConfig     string         `key:"risk_config" env:"RISK_CONFIG"`
+	CircuitBreaker CircuitBreaker `key:"circuit_breaker"`
+}
+
+type CircuitBreaker struct {
+	ThresholdPercent decimal.Decimal `key:"threshold_percent" env:"CIRCUIT_BREAKER_THRESHOLD_PERCENT"`
+	Window           time.Duration   `key:"window" env:"CIRCUIT_BREAKER_WINDOW"`
+	CoolOff          time.Duration   `key:"cool_off" env:"CIRCUIT_BREAKER_COOL_OFF"`
+}
+
+// Budget allows Limit requests per Window, its variables are prefixed with
+// the env tag of the budget, e.g. RATE_LIMIT_ORDERS_LIMIT
+type Budget struct {
+	Limit  int           `key:"limit" env:"LIMIT"`
+	Window time.Duration `key:"window" env:"WINDOW"`
+}
+
+type RateLimit struct {
+	Orders       Budget       `key:"orders" env:"RATE_LIMIT_ORDERS"`
+	Cancels      Budget       `key:"cancels" env:"RATE_LIMIT_CANCELS"`
+	Reads        Budget       `key:"reads" env:"RATE_LIMIT_READS"`
+	OrderToTrade OrderToTrade `key:"order_to_trade"`
+}
+
+type OrderToTrade struct {
+	Window    time.Duration `key:"window" env:"ORDER_TO_TRADE_WINDOW"`
+	MaxRatio  float64       `key:"max_ratio" env:"ORDER_TO_TRADE_MAX_RATIO"`
+	MinOrders int           `key:"min_orders" env:"ORDER_TO_TRADE_MIN_ORDERS"`
+	Penalty   time.Duration `key:"penalty" env:"ORDER_TO_TRADE_PENALTY"`
+}
+
+type EventLog struct {
+	SnapshotInterval time.Duration `key:"snapshot_interval" env:"SNAPSHOT_INTERVAL"`
+}
+
+type OrderHistory struct {
+	// ArchiveInterval is how often filled and canceled orders are moved from
+	// the live book to the order history
+	ArchiveInterval time.Duration `key:"archive_interval" env:"ORDER_HISTORY_ARCHIVE_INTERVAL"`
+	// BatchSize is how many orders are moved per transaction
+	BatchSize int `key:"batch_size" env:"ORDER_HISTORY_BATCH_SIZE"`
+}
+
+type Funding struct {
+	// Rail collects deposits and pays withdrawals out, only fake for now
+	Rail string `key:"rail" env:"FUNDING_RAIL"`
+	// ApprovalThresholds are the amounts per asset from which deposits and
+	// withdrawals wait for an operator, as comma separated CODE:AMOUNT pairs,
+	// e.g. BTC:1,BRL:50000. Assets without one never need approval.
+	ApprovalThresholds string `key:"approval_thresholds" env:"FUNDING_APPROVAL_THRESHOLDS"`
+	// ProcessInterval is how often requested fundings are screened, approved
+	// ones submitted to the rail and submitted ones settled
+	ProcessInterval time.Duration `key:"process_interval" env:"FUNDING_PROCESS_INTERVAL"`
+}
+
+// Thresholds parses the approval thresholds, keyed by asset code
+func (f Funding) Thresholds() (map[string]decimal.Decimal, error) {
+	thresholds := map[string]decimal.Decimal{}
+	if strings.TrimSpace(f.ApprovalThresholds) == "" {
+		return thresholds, nil
+	}
+	for _, pair := range strings.Split(f.ApprovalThresholds, ",") {
+		code, value, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || code == "" {
+			return nil, fmt.Errorf("expected CODE:AMOUNT, got %q", pair)
+		}
+		amount, err := decimal.NewFromString(value)
+		if err != nil || !amount.IsPositive() {
+			return nil, fmt.Errorf("the threshold of %s must be a positive amount, got %q", code, value)
+		}
+		thresholds[code] = amount
+	}
+	return thresholds, nil
+}
+
+type Reconciliation struct {
+	Interval time.Duration `key:"interval" env:"RECONCILIATION_INTERVAL"`
+}
+
+type Features struct {
+	AutoMigrate         bool `key:"auto_migrate" env:"AUTO_MIGRATE"`
+	OrderToTradeMonitor bool `key:"order_to_trade_monitor" env:"ORDER_TO_TRADE_MONITOR"`
+	// ResumeWithAuction uncrosses the book with a call auction when a circuit breaker halt ends
+	ResumeWithAuction bool `key:"resume_with_auction" env:"CIRCUIT_BREAKER_RESUME_WITH_AUCTION"`
+}
+
+func Default() Config {
+	return Config{
+		HTTP: HTTP{
+			Address:         ":8000",
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     time.Minute,
+			ShutdownTimeout: 30 * time.Second,
+			IdempotencyTTL:  24 * time.Hour,
+		},
+		Fix: Fix{
+			CompId: "CLOB",
+		},
+		Log: Log{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			SampleRatio: 1,
+			ServiceName: "clob",
+		},
+		Storage: "postgres",
+		Database: Database{
+			MaxConns:        10,
+			MinConns:        0,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: 30 * time.Minute,
+		},
+		Pagination: Pagination{
+			DefaultSize: 50,
+			MaxSize:     100,
+		},
+		Matching: Matching{
+			CircuitBreaker: CircuitBreaker{
+				ThresholdPercent: decimal.NewFromInt(10),
+				Window:           5 * time.Minute,
+				CoolOff:          5 * time.Minute,
+			},
+		},
+		RateLimit: RateLimit{
+			Orders:  Budget{Limit: 20, Window: time.Second},
+			Cancels: Budget{Limit: 20, Window: time.Second},
+			Reads:   Budget{Limit: 50, Window: time.Second},
+			OrderToTrade: OrderToTrade{
+				Window:    time.Minute,
+				MaxRatio:  50,
+				MinOrders: 100,
+				Penalty:   30 * time.Second,
+			},
+		},
+		EventLog: EventLog{
+			SnapshotInterval: time.Hour,
+		},
+		OrderHistory: OrderHistory{
+			ArchiveInterval: time.Minute,
+			BatchSize:       1000,
+		},
+		Funding: Funding{
+			Rail:               "fake",
+			ApprovalThresholds: "BTC:1,BRL:50000",
+			ProcessInterval:    5 * time.Second,
+		},
+		Reconciliation: Reconciliation{
+			Interval: 10 * time.Minute,
+		},
+	}
+}
@@ -0,0 +1,301 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+// field is a setting of the configuration, addressed by its dotted key path
+type field struct {
+	path   string
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+var decimalType = reflect.TypeOf(decimal.Decimal{})
+
+// fields lists the settings of cfg in declaration order
+func fields(cfg *Config) []field {
+	var out []field
+	walk(reflect.ValueOf(cfg).Elem(), "", "", &out)
+	return out
+}
+
+func walk(v reflect.Value, path, env string, out *[]field) {
+	t := v.Type()
+	for i := range t.NumField() {
+		structField := t.Field(i)
+		key := join(path, structField.Tag.Get("key"), ".")
+		name := join(env, structField.Tag.Get("env"), "_")
+		if structField.Type.Kind() == reflect.Struct && structField.Type != decimalType {
+			walk(v.Field(i), key, name, out)
+			continue
+		}
+		*out = append(*out, field{
+			path:   key,
+			env:    name,
+			secret: structField.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+}
+
+func join(prefix, name, separator string) string {
+	if prefix == "" || name == "" {
+		return prefix + name
+	}
+	return prefix + separator + name
+}
+
+func (f field) set(value string) error {
+	switch v := f.value.Addr().Interface().(type) {
+	case *string:
+		*v = value
+	case *bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*v = parsed
+	case *int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*v = parsed
+	case *float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*v = parsed
+	case *time.Duration:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*v = parsed
+	case *decimal.Decimal:
+		parsed, err := decimal.NewFromString(value)
+		if err != nil {
+			return err
+		}
+		*v = parsed
+	default:
+		return fmt.Errorf("unsupported type %s", f.value.Type())
+	}
+	return nil
+}
+
+func (f field) String() string {
+	return fmt.Sprint(f.value.Interface())
+}
+
+// Load builds the configuration from the defaults, the file given by -config
+// or CONFIG_FILE, the environment (a .env file included) and the flags in
+// args, in increasing precedence, and validates it
+func Load(args []string) (Config, error) {
+	if err := godotenv.Load(); err != nil {
+		slog.Warn("Could not load .env file, using system environment variables")
+	}
+
+	cfg := Default()
+	settings := fields(&cfg)
+
+	// Flags are parsed first to find the file but applied last
+	type override struct {
+		field field
+		value string
+	}
+	var overrides []override
+	flags := flag.NewFlagSet("clob", flag.ContinueOnError)
+	path := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML configuration file, env CONFIG_FILE")
+	for _, setting := range settings {
+		usage := fmt.Sprintf("env %s (default %s)", setting.env, setting)
+		flags.Func(setting.path, usage, func(value string) error {
+			overrides = append(overrides, override{setting, value})
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if flags.NArg() > 0 {
+		return Config{}, fmt.Errorf("unexpected argument %s", flags.Arg(0))
+	}
+
+	if *path != "" {
+		if err := loadFile(*path, settings); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for _, setting := range settings {
+		value, ok := os.LookupEnv(setting.env)
+		if !ok || value == "" {
+			continue
+		}
+		if err := setting.set(value); err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", setting.env, err)
+		}
+	}
+
+	for _, override := range overrides {
+		if err := override.field.set(override.value); err != nil {
+			return Config{}, fmt.Errorf("invalid -%s: %w", override.field.path, err)
+		}
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// loadFile sets the settings present in the file, rejecting unknown keys
+func loadFile(path string, settings []field) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	document := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &document)
+	case ".toml":
+		err = toml.Unmarshal(content, &document)
+	default:
+		return fmt.Errorf("unsupported config file %s, expected .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	byPath := make(map[string]field, len(settings))
+	for _, setting := range settings {
+		byPath[setting.path] = setting
+	}
+	values := map[string]any{}
+	flatten(document, "", values)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		setting, ok := byPath[key]
+		if !ok {
+			return fmt.Errorf("unknown key %s in %s", key, path)
+		}
+		if err := setting.set(fmt.Sprint(values[key])); err != nil {
+			return fmt.Errorf("invalid %s in %s: %w", key, path, err)
+		}
+	}
+	return nil
+}
+
+func flatten(document map[string]any, prefix string, out map[string]any) {
+	for key, value := range document {
+		if nested, ok := value.(map[string]any); ok {
+			flatten(nested, join(prefix, key, "."), out)
+			continue
+		}
+		out[join(prefix, key, ".")] = value
+	}
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.HTTP.Address != "", "http.address is required")
+	check(c.HTTP.ReadTimeout >= 0, "http.read_timeout must not be negative")
+	check(c.HTTP.WriteTimeout >= 0, "http.write_timeout must not be negative")
+	check(c.HTTP.IdleTimeout >= 0, "http.idle_timeout must not be negative")
+	check(c.HTTP.ShutdownTimeout > 0, "http.shutdown_timeout must be positive")
+	check(c.HTTP.IdempotencyTTL > 0, "http.idempotency_ttl must be positive")
+
+	check(c.Fix.CompId != "", "fix.comp_id is required")
+
+	check(slices.Contains([]string{"debug", "info", "warn", "error"}, strings.ToLower(c.Log.Level)), "log.level must be debug, info, warn or error, got %q", c.Log.Level)
+	check(slices.Contains([]string{"json", "text"}, strings.ToLower(c.Log.Format)), "log.format must be json or text, got %q", c.Log.Format)
+
+	check(slices.Contains([]string{"none", "stdout", "otlp"}, c.Tracing.Exporter), "tracing.exporter must be none, stdout or otlp, got %q", c.Tracing.Exporter)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+	check(c.Tracing.ServiceName != "", "tracing.service_name is required")
+
+	check(c.Storage == "postgres" || c.Storage == "memory", "storage must be postgres or memory, got %q", c.Storage)
+	if c.Storage == "postgres" {
+		check(c.Database.URL != "", "database.url is required with postgres storage")
+	}
+	check(c.Database.MaxConns >= 1, "database.max_conns must be at least 1")
+	check(c.Database.MinConns >= 0 && c.Database.MinConns <= c.Database.MaxConns, "database.min_conns must be between 0 and database.max_conns")
+	check(c.Database.MaxConnLifetime > 0, "database.max_conn_lifetime must be positive")
+	check(c.Database.MaxConnIdleTime > 0, "database.max_conn_idle_time must be positive")
+
+	check(c.Pagination.MaxSize >= 1, "pagination.max_size must be at least 1")
+	check(c.Pagination.DefaultSize >= 1 && c.Pagination.DefaultSize <= c.Pagination.MaxSize, "pagination.default_size must be between 1 and pagination.max_size")
+
+	check(c.Matching.CircuitBreaker.ThresholdPercent.IsPositive(), "matching.circuit_breaker.threshold_percent must be positive")
+	check(c.Matching.CircuitBreaker.Window > 0, "matching.circuit_breaker.window must be positive")
+	check(c.Matching.CircuitBreaker.CoolOff > 0, "matching.circuit_breaker.cool_off must be positive")
+
+	budgets := []struct {
+		name   string
+		budget Budget
+	}{{"orders", c.RateLimit.Orders}, {"cancels", c.RateLimit.Cancels}, {"reads", c.RateLimit.Reads}}
+	for _, b := range budgets {
+		check(b.budget.Limit >= 1, "rate_limit.%s.limit must be at least 1", b.name)
+		check(b.budget.Window > 0, "rate_limit.%s.window must be positive", b.name)
+	}
+	check(c.RateLimit.OrderToTrade.Window > 0, "rate_limit.order_to_trade.window must be positive")
+	check(c.RateLimit.OrderToTrade.MaxRatio > 0, "rate_limit.order_to_trade.max_ratio must be positive")
+	check(c.RateLimit.OrderToTrade.MinOrders >= 0, "rate_limit.order_to_trade.min_orders must not be negative")
+	check(c.RateLimit.OrderToTrade.Penalty > 0, "rate_limit.order_to_trade.penalty must be positive")
+
+	check(c.EventLog.SnapshotInterval > 0, "event_log.snapshot_interval must be positive")
+	check(c.OrderHistory.ArchiveInterval > 0, "order_history.archive_interval must be positive")
+	check(c.OrderHistory.BatchSize >= 1, "order_history.batch_size must be at least 1")
+	check(c.Funding.Rail == "fake", "funding.rail must be fake, got %q", c.Funding.Rail)
+	if _, err := c.Funding.Thresholds(); err != nil {
+		check(false, "funding.approval_thresholds: %v", err)
+	}
+	check(c.Funding.ProcessInterval > 0, "funding.process_interval must be positive")
+	check(c.Reconciliation.Interval > 0, "reconciliation.interval must be positive")
+
+	return errors.Join(errs...)
+}
+
+// LogValue renders the configuration one attribute per key with secrets
+// masked, so it can be logged at startup
+func (c Config) LogValue() slog.Value {
+	settings := fields(&c)
+	attrs := make([]slog.Attr, 0, len(settings))
+	for _, setting := range settings {
+		value := setting.String()
+		if setting.secret && value != "" {
+			value = "***"
+		}
+		attrs = append(attrs, slog.String(setting.path, value))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,46 @@
+// Package custody moves funds between the exchange and the outside world:
+// deposits are collected and withdrawals paid out by a rail, a custodian or
+// payment network, which settles them asynchronously.
+package custody
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+)
+
+type State string
+
+const (
+	Pending State = "pending"
+	Settled State = "settled"
+	Failed  State = "failed"
+)
+
+// Outcome is where a submitted funding stands on the rail, Reason says why it
+// failed
+type Outcome struct {
+	State  State
+	Reason string
+}
+
+// Rail is a custodian or payment network. Implementations must be safe for
+// concurrent use.
+type Rail interface {
+	// Submit hands the funding to the rail and returns its reference there.
+	// Submitting a funding again returns the same reference, so submissions
+	// can be retried after a crash.
+	Submit(ctx context.Context, funding storage.Funding) (string, error)
+	// Status returns the outcome of the funding of the reference
+	Status(ctx context.Context, reference string) (Outcome, error)
+}
+
+// New returns the rail of the name, only fake for now
+func New(name string) (Rail, error) {
+	switch name {
+	case "fake":
+		return NewFake(), nil
+	}
+	return nil, fmt.Errorf("unknown custody rail %q", name)
+}
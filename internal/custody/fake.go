@@ -0,0 +1,61 @@
+package custody
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+)
+
+// Fake is a rail for local runs and tests, every funding settles the first
+// time its status is asked unless it was told to fail
+type Fake struct {
+	mu         sync.Mutex
+	references map[uuid.UUID]string
+	outcomes   map[string]Outcome
+	failures   map[uuid.UUID]string
+}
+
+func NewFake() *Fake {
+	return &Fake{
+		references: make(map[uuid.UUID]string),
+		outcomes:   make(map[string]Outcome),
+		failures:   make(map[uuid.UUID]string),
+	}
+}
+
+// Fail makes the funding of the id fail with the reason once submitted
+func (f *Fake) Fail(id uuid.UUID, reason string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[id] = reason
+}
+
+func (f *Fake) Submit(ctx context.Context, funding storage.Funding) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if reference, ok := f.references[funding.Id]; ok {
+		return reference, nil
+	}
+	reference := "fake-" + funding.Id.String()
+	f.references[funding.Id] = reference
+	f.outcomes[reference] = Outcome{State: Settled}
+	if reason, ok := f.failures[funding.Id]; ok {
+		f.outcomes[reference] = Outcome{State: Failed, Reason: reason}
+	}
+	return reference, nil
+}
+
+func (f *Fake) Status(ctx context.Context, reference string) (Outcome, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	outcome, ok := f.outcomes[reference]
+	if !ok {
+		return Outcome{}, fmt.Errorf("unknown reference %s", reference)
+	}
+	return outcome, nil
+}
@@ -2,31 +2,35 @@ package db
 
 import (
 	"context"
-	"log"
-	"os"
+	"fmt"
 
+	"github.com/JhonesBR/go-clob/internal/config"
+	"github.com/JhonesBR/go-clob/internal/logging"
+	"github.com/JhonesBR/go-clob/internal/tracing"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/joho/godotenv"
 )
 
-func NewConnection() *pgxpool.Pool {
-	// Load environment variables from .env file
-	err := godotenv.Load()
-	if err != nil {
-		log.Printf("Warning: Could not load .env file, using system environment variables")
-	}
-
+func NewConnection(cfg config.Database) (*pgxpool.Pool, error) {
 	// Create a new database connection pool
-	config, err := pgxpool.ParseConfig(os.Getenv("DATABASE_URL"))
+	poolConfig, err := pgxpool.ParseConfig(cfg.URL)
 	if err != nil {
-		log.Fatalf("Failed to parse db config: %v", err)
+		return nil, fmt.Errorf("failed to parse db config: %w", err)
+	}
+	poolConfig.MaxConns = int32(cfg.MaxConns)
+	poolConfig.MinConns = int32(cfg.MinConns)
+	poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolConfig.ConnConfig.Tracer = tracing.QueryTracer()
+	if cfg.LogQueries {
+		poolConfig.ConnConfig.Tracer = multitracer.New(tracing.QueryTracer(), logging.QueryTracer())
 	}
 
 	// Create a new connection pool
-	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
-		log.Fatalf("Failed to create db pool: %v", err)
+		return nil, fmt.Errorf("failed to create db pool: %w", err)
 	}
 
-	return pool
+	return pool, nil
 }
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector exports the statistics of the connection pool
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns       *prometheus.Desc
+	idleConns           *prometheus.Desc
+	totalConns          *prometheus.Desc
+	maxConns            *prometheus.Desc
+	acquires            *prometheus.Desc
+	acquireDuration     *prometheus.Desc
+	emptyAcquires       *prometheus.Desc
+	canceledAcquires    *prometheus.Desc
+	newConns            *prometheus.Desc
+	maxLifetimeDestroys *prometheus.Desc
+	maxIdleDestroys     *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("clob_db_pool_"+name, help, nil, nil)
+	}
+	return &PoolCollector{
+		pool:                pool,
+		acquiredConns:       desc("acquired_connections", "Connections currently in use."),
+		idleConns:           desc("idle_connections", "Connections currently idle."),
+		totalConns:          desc("total_connections", "Connections open, in use, idle or being established."),
+		maxConns:            desc("max_connections", "Maximum size of the pool."),
+		acquires:            desc("acquires_total", "Successful connection acquisitions."),
+		acquireDuration:     desc("acquire_duration_seconds_total", "Time spent acquiring connections."),
+		emptyAcquires:       desc("empty_acquires_total", "Acquisitions that waited for a connection because the pool was empty."),
+		canceledAcquires:    desc("canceled_acquires_total", "Acquisitions canceled by their context."),
+		newConns:            desc("new_connections_total", "Connections opened."),
+		maxLifetimeDestroys: desc("max_lifetime_destroys_total", "Connections closed for exceeding the maximum lifetime."),
+		maxIdleDestroys:     desc("max_idle_destroys_total", "Connections closed for exceeding the maximum idle time."),
+	}
+}
+
+func (p *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(p, ch)
+}
+
+func (p *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := p.pool.Stat()
+	gauge := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+	}
+	counter := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value)
+	}
+
+	gauge(p.acquiredConns, float64(stat.AcquiredConns()))
+	gauge(p.idleConns, float64(stat.IdleConns()))
+	gauge(p.totalConns, float64(stat.TotalConns()))
+	gauge(p.maxConns, float64(stat.MaxConns()))
+	counter(p.acquires, float64(stat.AcquireCount()))
+	counter(p.acquireDuration, stat.AcquireDuration().Seconds())
+	counter(p.emptyAcquires, float64(stat.EmptyAcquireCount()))
+	counter(p.canceledAcquires, float64(stat.CanceledAcquireCount()))
+	counter(p.newConns, float64(stat.NewConnsCount()))
+	counter(p.maxLifetimeDestroys, float64(stat.MaxLifetimeDestroyCount()))
+	counter(p.maxIdleDestroys, float64(stat.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,226 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLock is the advisory lock key held while applying migrations so
+// concurrent instances do not migrate at the same time
+const migrationLock = 7_240_101
+
+// Migration is a numbered schema change, named NNNN_name.up.sql and
+// NNNN_name.down.sql in the migrations directory
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrations returns the embedded migrations ordered by version
+func Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		name, direction, ok := strings.Cut(strings.TrimSuffix(entry.Name(), ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+		number, label, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+
+		content, err := migrationFiles.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: label}
+			byVersion[version] = migration
+		} else if migration.Name != label {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, label)
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// MigrateUp applies every pending migration, each one in its own transaction,
+// returning the applied ones
+func MigrateUp(ctx context.Context, pool *pgxpool.Pool) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for {
+		migration, err := step(ctx, pool, func(tx pgx.Tx, versions map[int64]time.Time) (*Migration, error) {
+			for _, migration := range migrations {
+				if _, ok := versions[migration.Version]; ok {
+					continue
+				}
+				if _, err := tx.Exec(ctx, migration.Up); err != nil {
+					return nil, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+				}
+				if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name); err != nil {
+					return nil, err
+				}
+				return &migration, nil
+			}
+			return nil, nil
+		})
+		if err != nil || migration == nil {
+			return applied, err
+		}
+		applied = append(applied, *migration)
+	}
+}
+
+// MigrateDown reverts the last steps applied migrations, returning the reverted ones
+func MigrateDown(ctx context.Context, pool *pgxpool.Pool, steps int) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	for range steps {
+		migration, err := step(ctx, pool, func(tx pgx.Tx, versions map[int64]time.Time) (*Migration, error) {
+			for i := len(migrations) - 1; i >= 0; i-- {
+				migration := migrations[i]
+				if _, ok := versions[migration.Version]; !ok {
+					continue
+				}
+				if _, err := tx.Exec(ctx, migration.Down); err != nil {
+					return nil, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+				}
+				if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+					return nil, err
+				}
+				return &migration, nil
+			}
+			return nil, nil
+		})
+		if err != nil || migration == nil {
+			return reverted, err
+		}
+		reverted = append(reverted, *migration)
+	}
+	return reverted, nil
+}
+
+// MigrationStatuses lists every known migration with when it was applied
+func MigrationStatuses(ctx context.Context, pool *pgxpool.Pool) ([]MigrationStatus, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	_, err = step(ctx, pool, func(tx pgx.Tx, versions map[int64]time.Time) (*Migration, error) {
+		for _, migration := range migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := versions[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil, nil
+	})
+	return statuses, err
+}
+
+// step runs fn in a transaction holding the migration lock, with the versions
+// already applied
+func step(ctx context.Context, pool *pgxpool.Pool, fn func(tx pgx.Tx, versions map[int64]time.Time) (*Migration, error)) (*Migration, error) {
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLock); err != nil {
+		return nil, err
+	}
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)
+	`
+	if _, err := tx.Exec(ctx, query); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		versions[version] = appliedAt
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	migration, err := fn(tx, versions)
+	if err != nil {
+		return nil, err
+	}
+	return migration, tx.Commit(ctx)
+}
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS snapshots;
+DROP TABLE IF EXISTS events;
+DROP TABLE IF EXISTS trades;
+DROP TABLE IF EXISTS order_book;
+DROP TABLE IF EXISTS instruments;
+DROP TABLE IF EXISTS account_balances;
+DROP TABLE IF EXISTS accounts;
+DROP TABLE IF EXISTS assets;
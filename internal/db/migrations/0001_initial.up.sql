@@ -0,0 +1,106 @@
+-- Databases created by the former dataset/init.sql already have part of this
+-- schema: only what is missing is created, so they upgrade with migrate up
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- ------------------------------------------------------------------
+-- Assets
+CREATE TABLE IF NOT EXISTS assets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL
+);
+
+INSERT INTO assets (code, name) VALUES
+    ('BTC', 'Bitcoin'),
+    ('BRL', 'Brazilian Real')
+ON CONFLICT (code) DO NOTHING;
+-- ------------------------------------------------------------------
+
+
+-- ------------------------------------------------------------------
+-- Accounts
+CREATE TABLE IF NOT EXISTS accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL
+);
+-- ------------------------------------------------------------------
+
+
+-- ------------------------------------------------------------------
+-- Account Balances
+CREATE TABLE IF NOT EXISTS account_balances (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    asset_id UUID NOT NULL REFERENCES assets(id),
+    balance NUMERIC NOT NULL
+);
+-- ------------------------------------------------------------------
+
+
+-- ------------------------------------------------------------------
+-- Instruments
+CREATE TABLE IF NOT EXISTS instruments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    base_asset_id UUID NOT NULL REFERENCES assets(id),
+    quote_asset_id UUID NOT NULL REFERENCES assets(id),
+    trading_status TEXT NOT NULL DEFAULT 'open' CHECK (trading_status IN ('pre_open', 'open', 'auction', 'halted', 'cancel_only', 'closed'))
+);
+
+-- init.sql created instruments before they had a trading status
+ALTER TABLE instruments
+    ADD COLUMN IF NOT EXISTS trading_status TEXT NOT NULL DEFAULT 'open' CHECK (trading_status IN ('pre_open', 'open', 'auction', 'halted', 'cancel_only', 'closed'));
+
+INSERT INTO instruments (base_asset_id, quote_asset_id)
+SELECT base.id, quote.id
+FROM assets base, assets quote
+WHERE base.code = 'BTC' AND quote.code = 'BRL'
+    AND NOT EXISTS (SELECT 1 FROM instruments WHERE base_asset_id = base.id AND quote_asset_id = quote.id);
+-- ------------------------------------------------------------------
+
+
+-- ------------------------------------------------------------------
+-- Order Book
+CREATE TABLE IF NOT EXISTS order_book (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    instrument_id UUID NOT NULL REFERENCES instruments(id),
+    type TEXT NOT NULL CHECK (type IN ('buy', 'sell')),
+    status TEXT NOT NULL CHECK (status IN ('open', 'partially_filled', 'full_filled', 'canceled')),
+    price NUMERIC NOT NULL,
+    total_quantity NUMERIC NOT NULL,
+    filled_quantity NUMERIC NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- ------------------------------------------------------------------
+
+
+-- ------------------------------------------------------------------
+-- Trades
+CREATE TABLE IF NOT EXISTS trades (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    instrument_id UUID NOT NULL REFERENCES instruments(id),
+    buy_order_id UUID NOT NULL REFERENCES order_book(id),
+    sell_order_id UUID NOT NULL REFERENCES order_book(id),
+    price NUMERIC NOT NULL,
+    quantity NUMERIC NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- ------------------------------------------------------------------
+
+
+-- ------------------------------------------------------------------
+-- Event Log
+CREATE TABLE IF NOT EXISTS events (
+    sequence BIGSERIAL PRIMARY KEY,
+    type TEXT NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS snapshots (
+    sequence BIGINT PRIMARY KEY,
+    state JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- ------------------------------------------------------------------
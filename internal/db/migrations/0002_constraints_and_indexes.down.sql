@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS events_aggregate_idx;
+DROP INDEX IF EXISTS trades_instrument_idx;
+DROP INDEX IF EXISTS order_book_instrument_idx;
+DROP INDEX IF EXISTS order_book_account_idx;
+DROP INDEX IF EXISTS order_book_working_idx;
+
+ALTER TABLE trades
+    DROP CONSTRAINT IF EXISTS trades_quantity_check,
+    DROP CONSTRAINT IF EXISTS trades_price_check;
+
+ALTER TABLE order_book
+    DROP CONSTRAINT IF EXISTS order_book_filled_quantity_check,
+    DROP CONSTRAINT IF EXISTS order_book_quantity_check,
+    DROP CONSTRAINT IF EXISTS order_book_price_check;
+
+ALTER TABLE instruments
+    DROP CONSTRAINT IF EXISTS instruments_distinct_assets_check,
+    DROP CONSTRAINT IF EXISTS instruments_assets_key;
+
+ALTER TABLE account_balances
+    DROP CONSTRAINT IF EXISTS account_balances_account_asset_key;
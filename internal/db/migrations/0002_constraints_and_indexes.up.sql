@@ -0,0 +1,32 @@
+-- One balance row per account and asset
+ALTER TABLE account_balances
+    ADD CONSTRAINT account_balances_account_asset_key UNIQUE (account_id, asset_id);
+
+-- One instrument per asset pair
+ALTER TABLE instruments
+    ADD CONSTRAINT instruments_assets_key UNIQUE (base_asset_id, quote_asset_id),
+    ADD CONSTRAINT instruments_distinct_assets_check CHECK (base_asset_id <> quote_asset_id);
+
+-- Orders always have a positive price and quantity and are never overfilled
+ALTER TABLE order_book
+    ADD CONSTRAINT order_book_price_check CHECK (price > 0),
+    ADD CONSTRAINT order_book_quantity_check CHECK (total_quantity > 0),
+    ADD CONSTRAINT order_book_filled_quantity_check CHECK (filled_quantity >= 0 AND filled_quantity <= total_quantity);
+
+ALTER TABLE trades
+    ADD CONSTRAINT trades_price_check CHECK (price > 0),
+    ADD CONSTRAINT trades_quantity_check CHECK (quantity > 0);
+
+-- Matching and best prices only look at working orders in price then time priority
+CREATE INDEX order_book_working_idx ON order_book (instrument_id, type, price, created_at)
+    WHERE status IN ('open', 'partially_filled');
+
+-- Order book listing per account and instrument
+CREATE INDEX order_book_account_idx ON order_book (account_id, created_at);
+CREATE INDEX order_book_instrument_idx ON order_book (instrument_id, created_at);
+
+-- Last trade and volume per instrument
+CREATE INDEX trades_instrument_idx ON trades (instrument_id, created_at);
+
+-- Events of a single aggregate
+CREATE INDEX events_aggregate_idx ON events (aggregate_id, sequence);
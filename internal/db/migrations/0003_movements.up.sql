@@ -0,0 +1,55 @@
+-- ------------------------------------------------------------------
+-- Movements (money entering or leaving the exchange)
+CREATE TABLE movements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    asset_id UUID NOT NULL REFERENCES assets(id),
+    kind TEXT NOT NULL CHECK (kind IN ('deposit', 'withdrawal', 'fee')),
+    amount NUMERIC NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX movements_account_idx ON movements (account_id, asset_id);
+-- ------------------------------------------------------------------
+
+-- Balances charged before movements were recorded are taken as opening
+-- deposits: what each account holds (balance plus funds reserved by working
+-- orders) minus what it got from trades
+INSERT INTO movements (account_id, asset_id, kind, amount)
+SELECT account_id, asset_id, CASE WHEN SUM(amount) > 0 THEN 'deposit' ELSE 'withdrawal' END, ABS(SUM(amount))
+FROM (
+    SELECT account_id, asset_id, balance AS amount
+    FROM account_balances
+    UNION ALL
+    SELECT ob.account_id, instruments.quote_asset_id, (ob.total_quantity - ob.filled_quantity) * ob.price
+    FROM order_book ob
+    INNER JOIN instruments ON instruments.id = ob.instrument_id
+    WHERE ob.type = 'buy' AND ob.status IN ('open', 'partially_filled')
+    UNION ALL
+    SELECT ob.account_id, instruments.base_asset_id, ob.total_quantity - ob.filled_quantity
+    FROM order_book ob
+    INNER JOIN instruments ON instruments.id = ob.instrument_id
+    WHERE ob.type = 'sell' AND ob.status IN ('open', 'partially_filled')
+    UNION ALL
+    SELECT buy_orders.account_id, instruments.base_asset_id, -trades.quantity
+    FROM trades
+    INNER JOIN instruments ON instruments.id = trades.instrument_id
+    INNER JOIN order_book buy_orders ON buy_orders.id = trades.buy_order_id
+    UNION ALL
+    SELECT buy_orders.account_id, instruments.quote_asset_id, trades.quantity * trades.price
+    FROM trades
+    INNER JOIN instruments ON instruments.id = trades.instrument_id
+    INNER JOIN order_book buy_orders ON buy_orders.id = trades.buy_order_id
+    UNION ALL
+    SELECT sell_orders.account_id, instruments.base_asset_id, trades.quantity
+    FROM trades
+    INNER JOIN instruments ON instruments.id = trades.instrument_id
+    INNER JOIN order_book sell_orders ON sell_orders.id = trades.sell_order_id
+    UNION ALL
+    SELECT sell_orders.account_id, instruments.quote_asset_id, -trades.quantity * trades.price
+    FROM trades
+    INNER JOIN instruments ON instruments.id = trades.instrument_id
+    INNER JOIN order_book sell_orders ON sell_orders.id = trades.sell_order_id
+) holdings
+GROUP BY account_id, asset_id
+HAVING SUM(amount) <> 0;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS fix_orders;
+DROP TABLE IF EXISTS fix_messages;
+DROP TABLE IF EXISTS fix_sessions;
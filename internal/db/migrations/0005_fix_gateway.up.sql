@@ -0,0 +1,45 @@
+-- ------------------------------------------------------------------
+-- FIX sessions, keyed by the comp id of the counterparty, with the
+-- sequence numbers to resume from and the last event reported on them
+CREATE TABLE fix_sessions (
+    id TEXT PRIMARY KEY,
+    next_sender_seq INTEGER NOT NULL CHECK (next_sender_seq > 0),
+    next_target_seq INTEGER NOT NULL CHECK (next_target_seq > 0),
+    last_event BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- ------------------------------------------------------------------
+
+
+-- ------------------------------------------------------------------
+-- Messages sent on each session, kept to answer resend requests
+CREATE TABLE fix_messages (
+    session_id TEXT NOT NULL REFERENCES fix_sessions(id),
+    seq INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    data BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (session_id, seq)
+);
+-- ------------------------------------------------------------------
+
+
+-- ------------------------------------------------------------------
+-- Orders placed through FIX with their client order id and the order as
+-- the client sees it, which survives replaces
+CREATE TABLE fix_orders (
+    order_id UUID PRIMARY KEY REFERENCES order_book(id),
+    session_id TEXT NOT NULL REFERENCES fix_sessions(id),
+    cl_ord_id TEXT NOT NULL,
+    orig_cl_ord_id TEXT NOT NULL DEFAULT '',
+    symbol TEXT NOT NULL,
+    side TEXT NOT NULL CHECK (side IN ('buy', 'sell')),
+    price NUMERIC NOT NULL,
+    order_qty NUMERIC NOT NULL,
+    cum_qty NUMERIC NOT NULL DEFAULT 0,
+    avg_px NUMERIC NOT NULL DEFAULT 0,
+    replaced BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (session_id, cl_ord_id)
+);
+-- ------------------------------------------------------------------
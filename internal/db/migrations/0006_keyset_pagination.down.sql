@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS order_book_instrument_idx;
+CREATE INDEX order_book_instrument_idx ON order_book (instrument_id, created_at);
+DROP INDEX IF EXISTS order_book_account_idx;
+CREATE INDEX order_book_account_idx ON order_book (account_id, created_at);
+
+DROP INDEX IF EXISTS accounts_name_idx;
+DROP INDEX IF EXISTS order_book_price_idx;
+DROP INDEX IF EXISTS order_book_created_at_idx;
@@ -0,0 +1,11 @@
+-- Lists are paginated on their sort column then id, pages are read from
+-- these indexes rather than sorting the whole table
+CREATE INDEX order_book_created_at_idx ON order_book (created_at, id);
+CREATE INDEX order_book_price_idx ON order_book (price, id);
+CREATE INDEX accounts_name_idx ON accounts (name, id);
+
+-- Order listing per account and instrument breaks ties by id
+DROP INDEX IF EXISTS order_book_account_idx;
+CREATE INDEX order_book_account_idx ON order_book (account_id, created_at, id);
+DROP INDEX IF EXISTS order_book_instrument_idx;
+CREATE INDEX order_book_instrument_idx ON order_book (instrument_id, created_at, id);
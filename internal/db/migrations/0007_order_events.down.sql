@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS events_replaces_idx;
+DROP INDEX IF EXISTS events_sell_order_idx;
+DROP INDEX IF EXISTS events_buy_order_idx;
@@ -0,0 +1,5 @@
+-- Matches are appended on the instrument and replacements on the new order,
+-- the timeline of an order finds them by the order ids of their payload
+CREATE INDEX events_buy_order_idx ON events ((payload->>'buy_order_id')) WHERE type = 'order_matched';
+CREATE INDEX events_sell_order_idx ON events ((payload->>'sell_order_id')) WHERE type = 'order_matched';
+CREATE INDEX events_replaces_idx ON events ((payload->>'replaces')) WHERE type = 'order_accepted';
@@ -0,0 +1,15 @@
+DROP VIEW IF EXISTS all_orders;
+
+-- Archived orders go back to the live book before the references return
+INSERT INTO order_book (id, account_id, instrument_id, type, status, price, total_quantity, filled_quantity, created_at)
+SELECT id, account_id, instrument_id, type, status, price, total_quantity, filled_quantity, created_at
+FROM order_history;
+
+ALTER TABLE fix_orders
+    ADD CONSTRAINT fix_orders_order_id_fkey FOREIGN KEY (order_id) REFERENCES order_book(id);
+ALTER TABLE trades
+    ADD CONSTRAINT trades_buy_order_id_fkey FOREIGN KEY (buy_order_id) REFERENCES order_book(id),
+    ADD CONSTRAINT trades_sell_order_id_fkey FOREIGN KEY (sell_order_id) REFERENCES order_book(id);
+
+DROP INDEX IF EXISTS order_book_final_idx;
+DROP TABLE IF EXISTS order_history;
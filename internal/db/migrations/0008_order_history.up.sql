@@ -0,0 +1,41 @@
+-- ------------------------------------------------------------------
+-- Order History (final orders moved out of the live book by the archiver)
+CREATE TABLE order_history (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    instrument_id UUID NOT NULL REFERENCES instruments(id),
+    type TEXT NOT NULL CHECK (type IN ('buy', 'sell')),
+    status TEXT NOT NULL CHECK (status IN ('full_filled', 'canceled')),
+    price NUMERIC NOT NULL CHECK (price > 0),
+    total_quantity NUMERIC NOT NULL CHECK (total_quantity > 0),
+    filled_quantity NUMERIC NOT NULL CHECK (filled_quantity >= 0 AND filled_quantity <= total_quantity),
+    created_at TIMESTAMP NOT NULL,
+    archived_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- The history is listed like the live book
+CREATE INDEX order_history_created_at_idx ON order_history (created_at, id);
+CREATE INDEX order_history_price_idx ON order_history (price, id);
+CREATE INDEX order_history_account_idx ON order_history (account_id, created_at, id);
+CREATE INDEX order_history_instrument_idx ON order_history (instrument_id, created_at, id);
+-- ------------------------------------------------------------------
+
+-- The archiver picks the oldest final orders of the live book
+CREATE INDEX order_book_final_idx ON order_book (created_at)
+    WHERE status IN ('full_filled', 'canceled');
+
+-- Orders live in either table, so trades and FIX orders can no longer
+-- reference order_book
+ALTER TABLE trades
+    DROP CONSTRAINT IF EXISTS trades_buy_order_id_fkey,
+    DROP CONSTRAINT IF EXISTS trades_sell_order_id_fkey;
+ALTER TABLE fix_orders
+    DROP CONSTRAINT IF EXISTS fix_orders_order_id_fkey;
+
+-- Every order, live or archived
+CREATE VIEW all_orders AS
+    SELECT id, account_id, instrument_id, type, status, price, total_quantity, filled_quantity, created_at
+    FROM order_book
+    UNION ALL
+    SELECT id, account_id, instrument_id, type, status, price, total_quantity, filled_quantity, created_at
+    FROM order_history;
@@ -0,0 +1,24 @@
+-- ------------------------------------------------------------------
+-- Fundings (deposits and withdrawals going through approval and custody)
+CREATE TABLE fundings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    asset_id UUID NOT NULL REFERENCES assets(id),
+    kind TEXT NOT NULL CHECK (kind IN ('deposit', 'withdrawal')),
+    status TEXT NOT NULL CHECK (status IN ('requested', 'pending_approval', 'approved', 'processing', 'completed', 'rejected', 'failed')),
+    amount NUMERIC NOT NULL CHECK (amount > 0),
+    -- Id of the funding on the custody rail once submitted
+    reference TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    reviewed_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX fundings_created_at_idx ON fundings (created_at, id);
+CREATE INDEX fundings_account_idx ON fundings (account_id, created_at, id);
+
+-- The processor and reconciliation only look at fundings still in flight
+CREATE INDEX fundings_open_idx ON fundings (status, created_at)
+    WHERE status IN ('requested', 'pending_approval', 'approved', 'processing');
+-- ------------------------------------------------------------------
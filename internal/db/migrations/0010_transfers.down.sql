@@ -0,0 +1,4 @@
+DELETE FROM movements WHERE kind IN ('transfer_in', 'transfer_out');
+ALTER TABLE movements DROP CONSTRAINT movements_kind_check;
+ALTER TABLE movements ADD CONSTRAINT movements_kind_check CHECK (kind IN ('deposit', 'withdrawal', 'fee'));
+DROP TABLE IF EXISTS transfers;
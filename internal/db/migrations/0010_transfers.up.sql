@@ -0,0 +1,28 @@
+-- ------------------------------------------------------------------
+-- Transfers (an asset moved between two accounts of the exchange)
+CREATE TABLE transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_account_id UUID NOT NULL REFERENCES accounts(id),
+    to_account_id UUID NOT NULL REFERENCES accounts(id),
+    asset_id UUID NOT NULL REFERENCES assets(id),
+    amount NUMERIC NOT NULL CHECK (amount > 0),
+    memo TEXT NOT NULL DEFAULT '',
+    -- Idempotency-Key the transfer was requested with, empty when none was sent
+    idempotency_key TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (from_account_id <> to_account_id)
+);
+
+CREATE INDEX transfers_created_at_idx ON transfers (created_at, id);
+CREATE INDEX transfers_from_account_idx ON transfers (from_account_id, created_at, id);
+CREATE INDEX transfers_to_account_idx ON transfers (to_account_id, created_at, id);
+
+-- A retried transfer finds the one its key already made instead of moving the amount twice
+CREATE UNIQUE INDEX transfers_idempotency_key_idx ON transfers (from_account_id, idempotency_key)
+    WHERE idempotency_key <> '';
+
+-- Each transfer is a movement out of one account and into the other
+ALTER TABLE movements DROP CONSTRAINT movements_kind_check;
+ALTER TABLE movements ADD CONSTRAINT movements_kind_check
+    CHECK (kind IN ('deposit', 'withdrawal', 'fee', 'transfer_in', 'transfer_out'));
+-- ------------------------------------------------------------------
@@ -0,0 +1,118 @@
+package eventlog
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type EventType string
+
+const (
+	OrderAccepted  EventType = "order_accepted"
+	OrderRejected  EventType = "order_rejected"
+	OrderMatched   EventType = "order_matched"
+	OrderCanceled  EventType = "order_canceled"
+	BalanceChanged EventType = "balance_changed"
+)
+
+type Event struct {
+	Sequence    int64           `json:"sequence"`
+	Type        EventType       `json:"type"`
+	AggregateId uuid.UUID       `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+type OrderAcceptedPayload struct {
+	OrderId      uuid.UUID       `json:"order_id"`
+	AccountId    uuid.UUID       `json:"account_id"`
+	InstrumentId uuid.UUID       `json:"instrument_id"`
+	Side         string          `json:"side"`
+	Price        decimal.Decimal `json:"price"`
+	Quantity     decimal.Decimal `json:"quantity"`
+	// Replaces is the order this one amends, canceled in the same unit of work
+	Replaces *uuid.UUID `json:"replaces,omitempty"`
+}
+
+type OrderRejectedPayload struct {
+	AccountId uuid.UUID       `json:"account_id"`
+	AssetCode string          `json:"asset_code"`
+	Side      string          `json:"side"`
+	Price     decimal.Decimal `json:"price"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	Reason    string          `json:"reason"`
+}
+
+type OrderMatchedPayload struct {
+	InstrumentId uuid.UUID       `json:"instrument_id"`
+	BuyOrderId   uuid.UUID       `json:"buy_order_id"`
+	SellOrderId  uuid.UUID       `json:"sell_order_id"`
+	Price        decimal.Decimal `json:"price"`
+	Quantity     decimal.Decimal `json:"quantity"`
+}
+
+// CancelReason tells why an order was canceled
+type CancelReason string
+
+const (
+	// CancelRequested orders were canceled by their account
+	CancelRequested CancelReason = "requested"
+	// CancelReplaced orders were amended, another order took their place
+	CancelReplaced CancelReason = "replaced"
+	// CancelInstrumentClosed orders were resting when their instrument closed
+	CancelInstrumentClosed CancelReason = "instrument_closed"
+	// CancelHalted orders would have crossed the book when the circuit
+	// breaker halted their instrument, their remainder was canceled
+	CancelHalted CancelReason = "halted"
+)
+
+// OrderCanceledPayload has no reason for orders canceled before reasons were
+// recorded
+type OrderCanceledPayload struct {
+	OrderId uuid.UUID    `json:"order_id"`
+	Reason  CancelReason `json:"reason,omitempty"`
+}
+
+// BalanceChangedPayload carries the resulting balance so replaying is idempotent
+type BalanceChangedPayload struct {
+	AccountId uuid.UUID       `json:"account_id"`
+	AssetId   uuid.UUID       `json:"asset_id"`
+	Balance   decimal.Decimal `json:"balance"`
+}
+
+// Append adds an event to the log inside the unit of work
+func Append(ctx context.Context, tx storage.Tx, eventType EventType, aggregateId uuid.UUID, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return tx.Events().Append(ctx, storage.Event{
+		Type:        string(eventType),
+		AggregateId: aggregateId,
+		Payload:     data,
+	})
+}
+
+// AppendCommitted appends an event on its own unit of work, used for outcomes
+// whose unit of work is rolled back like rejections
+func AppendCommitted(ctx context.Context, store storage.Store, eventType EventType, aggregateId uuid.UUID, payload any) error {
+	return store.WithTx(ctx, func(tx storage.Tx) error {
+		return Append(ctx, tx, eventType, aggregateId, payload)
+	})
+}
+
+func fromStorage(event storage.Event) Event {
+	return Event{
+		Sequence:    event.Sequence,
+		Type:        EventType(event.Type),
+		AggregateId: event.AggregateId,
+		Payload:     event.Payload,
+		CreatedAt:   event.CreatedAt,
+	}
+}
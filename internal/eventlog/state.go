@@ -0,0 +1,96 @@
+package eventlog
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type OrderState struct {
+	OrderAcceptedPayload
+	FilledQuantity decimal.Decimal `json:"filled_quantity"`
+	Status         string          `json:"status"`
+}
+
+// State is the working order book and balances rebuilt from the events.
+// Finished orders leave the state once filled or canceled. Orders resting
+// before the event log was introduced were never accepted in it, the state
+// does not know them and skips their matches and cancels.
+type State struct {
+	Sequence int64                                       `json:"sequence"`
+	Orders   map[uuid.UUID]*OrderState                   `json:"orders"`
+	Balances map[uuid.UUID]map[uuid.UUID]decimal.Decimal `json:"balances"`
+}
+
+func NewState() *State {
+	return &State{
+		Orders:   make(map[uuid.UUID]*OrderState),
+		Balances: make(map[uuid.UUID]map[uuid.UUID]decimal.Decimal),
+	}
+}
+
+// Apply folds an event into the state, events must be applied in sequence
+func (s *State) Apply(event Event) error {
+	if event.Sequence <= s.Sequence {
+		return fmt.Errorf("event %d already applied (state at %d)", event.Sequence, s.Sequence)
+	}
+
+	switch event.Type {
+	case OrderAccepted:
+		var payload OrderAcceptedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		s.Orders[payload.OrderId] = &OrderState{
+			OrderAcceptedPayload: payload,
+			FilledQuantity:       decimal.Zero,
+			Status:               "open",
+		}
+
+	case OrderMatched:
+		var payload OrderMatchedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		for _, orderId := range []uuid.UUID{payload.BuyOrderId, payload.SellOrderId} {
+			order, ok := s.Orders[orderId]
+			if !ok {
+				continue
+			}
+			order.FilledQuantity = order.FilledQuantity.Add(payload.Quantity)
+			if order.FilledQuantity.LessThan(order.Quantity) {
+				order.Status = "partially_filled"
+			} else {
+				delete(s.Orders, orderId)
+			}
+		}
+
+	case OrderCanceled:
+		var payload OrderCanceledPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		delete(s.Orders, payload.OrderId)
+
+	case BalanceChanged:
+		var payload BalanceChangedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		if _, ok := s.Balances[payload.AccountId]; !ok {
+			s.Balances[payload.AccountId] = make(map[uuid.UUID]decimal.Decimal)
+		}
+		s.Balances[payload.AccountId][payload.AssetId] = payload.Balance
+
+	case OrderRejected:
+		// Rejections do not change the state, they are kept for auditing
+
+	default:
+		return fmt.Errorf("unknown event type %s", event.Type)
+	}
+
+	s.Sequence = event.Sequence
+	return nil
+}
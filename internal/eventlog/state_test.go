@@ -0,0 +1,43 @@
+package eventlog_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TestApplySkipsOrdersBeforeTheLog replays a match against an order resting
+// before the event log was introduced, never accepted in it
+func TestApplySkipsOrdersBeforeTheLog(t *testing.T) {
+	event := func(sequence int64, eventType eventlog.EventType, payload any) eventlog.Event {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return eventlog.Event{Sequence: sequence, Type: eventType, Payload: data}
+	}
+	buy, legacy := uuid.New(), uuid.New()
+
+	state := eventlog.NewState()
+	events := []eventlog.Event{
+		event(1, eventlog.OrderAccepted, eventlog.OrderAcceptedPayload{OrderId: buy, Side: "buy", Price: decimal.NewFromInt(10), Quantity: decimal.NewFromInt(3)}),
+		event(2, eventlog.OrderMatched, eventlog.OrderMatchedPayload{BuyOrderId: buy, SellOrderId: legacy, Price: decimal.NewFromInt(10), Quantity: decimal.NewFromInt(1)}),
+		event(3, eventlog.OrderCanceled, eventlog.OrderCanceledPayload{OrderId: legacy}),
+	}
+	for _, event := range events {
+		if err := state.Apply(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	order, ok := state.Orders[buy]
+	if !ok || order.Status != "partially_filled" || !order.FilledQuantity.Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("buy order is %+v", order)
+	}
+	if _, ok := state.Orders[legacy]; ok || state.Sequence != 3 {
+		t.Fatalf("state is %+v", state)
+	}
+}
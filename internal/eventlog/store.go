@@ -0,0 +1,234 @@
+package eventlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// replayBatch is the amount of events loaded at once while replaying
+const replayBatch = 1000
+
+// ListEvents returns up to limit events after the given sequence
+func ListEvents(ctx context.Context, store storage.Store, after int64, limit int) ([]Event, error) {
+	events := []Event{}
+	err := store.WithTx(ctx, func(tx storage.Tx) error {
+		stored, err := tx.Events().List(ctx, after, -1, limit)
+		if err != nil {
+			return err
+		}
+		for _, event := range stored {
+			events = append(events, fromStorage(event))
+		}
+		return nil
+	})
+	return events, err
+}
+
+// ListOrderEvents returns the events of an order in the unit of work: its
+// acceptance, the matches it took part in, its cancel and the acceptance of
+// the order replacing it
+func ListOrderEvents(ctx context.Context, tx storage.Tx, orderId uuid.UUID) ([]Event, error) {
+	stored, err := tx.Events().ListByOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0, len(stored))
+	for _, event := range stored {
+		events = append(events, fromStorage(event))
+	}
+	return events, nil
+}
+
+// StateAt rebuilds the state as it was right after the event with the given
+// sequence, starting from the closest snapshot. A negative sequence replays
+// up to the last event.
+func StateAt(ctx context.Context, store storage.Store, sequence int64) (*State, error) {
+	state := NewState()
+	err := store.WithTx(ctx, func(tx storage.Tx) error {
+		return replay(ctx, tx, state, sequence)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func replay(ctx context.Context, tx storage.Tx, state *State, sequence int64) error {
+	snapshot, err := tx.Events().LatestSnapshot(ctx, sequence)
+	if err != nil {
+		return err
+	}
+	if snapshot != nil {
+		if err := json.Unmarshal(snapshot.State, state); err != nil {
+			return err
+		}
+	}
+
+	for {
+		events, err := tx.Events().List(ctx, state.Sequence, sequence, replayBatch)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := state.Apply(fromStorage(event)); err != nil {
+				return err
+			}
+		}
+		if len(events) < replayBatch {
+			return nil
+		}
+	}
+}
+
+// Recover rebuilds the current state of the log from the last snapshot and
+// checks it against the tables, failing on any working order or balance they
+// disagree on. Nothing commits between the replay and the comparison.
+func Recover(ctx context.Context, store storage.Store) (*State, error) {
+	state := NewState()
+	err := store.WithTx(ctx, func(tx storage.Tx) error {
+		if err := tx.Events().Lock(ctx); err != nil {
+			return err
+		}
+		if err := replay(ctx, tx, state, -1); err != nil {
+			return err
+		}
+		return verify(ctx, tx, state)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// maxMismatches is how many mismatches a failed recovery reports
+const maxMismatches = 10
+
+// verify compares the state with the working orders and the balances stored.
+// Orders resting and balances left untouched since before the event log are
+// unknown to the state and not compared.
+func verify(ctx context.Context, tx storage.Tx, state *State) error {
+	working, err := tx.Orders().List(ctx, storage.OrderFilter{Statuses: storage.WorkingStatuses}, storage.Page{})
+	if err != nil {
+		return err
+	}
+	balances, err := tx.Balances().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var mismatches []string
+	stored := make(map[uuid.UUID]storage.Order, len(working))
+	for _, order := range working {
+		stored[order.Id] = order
+		if _, ok := state.Orders[order.Id]; ok {
+			continue
+		}
+		accepted, err := acceptedInLog(ctx, tx, order.Id)
+		if err != nil {
+			return err
+		}
+		if accepted {
+			mismatches = append(mismatches, fmt.Sprintf("order %s is working but finished in the log", order.Id))
+		}
+	}
+	for id, order := range state.Orders {
+		row, ok := stored[id]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("order %s is working in the log but not on the book", id))
+			continue
+		}
+		if string(row.Status) != order.Status || !row.FilledQuantity.Equal(order.FilledQuantity) {
+			mismatches = append(mismatches, fmt.Sprintf("order %s is %s filled %s but %s filled %s in the log", id, row.Status, row.FilledQuantity, order.Status, order.FilledQuantity))
+		}
+	}
+
+	rows := make(map[[2]uuid.UUID]decimal.Decimal, len(balances))
+	for _, balance := range balances {
+		rows[[2]uuid.UUID{balance.AccountId, balance.AssetId}] = balance.Balance
+	}
+	for accountId, assets := range state.Balances {
+		for assetId, balance := range assets {
+			row, ok := rows[[2]uuid.UUID{accountId, assetId}]
+			if !ok || !row.Equal(balance) {
+				mismatches = append(mismatches, fmt.Sprintf("balance of asset %s for account %s is %s but %s in the log", assetId, accountId, row, balance))
+			}
+		}
+	}
+
+	if len(mismatches) == 0 {
+		return nil
+	}
+	sort.Strings(mismatches)
+	count := len(mismatches)
+	if count > maxMismatches {
+		mismatches = mismatches[:maxMismatches]
+	}
+	return fmt.Errorf("event log disagrees with the tables on %d entries: %s", count, strings.Join(mismatches, "; "))
+}
+
+// acceptedInLog reports if the order was accepted in the event log
+func acceptedInLog(ctx context.Context, tx storage.Tx, orderId uuid.UUID) (bool, error) {
+	events, err := tx.Events().ListByOrder(ctx, orderId)
+	if err != nil {
+		return false, err
+	}
+	for _, event := range events {
+		if EventType(event.Type) == OrderAccepted && event.AggregateId == orderId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// TakeSnapshot persists the current state so later recoveries replay less events
+func TakeSnapshot(ctx context.Context, store storage.Store) (*State, error) {
+	state, err := StateAt(ctx, store, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	err = store.WithTx(ctx, func(tx storage.Tx) error {
+		return tx.Events().SaveSnapshot(ctx, storage.Snapshot{Sequence: state.Sequence, State: data})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// StartSnapshots takes a snapshot every interval until the context is done,
+// the returned channel is closed once it stopped
+func StartSnapshots(ctx context.Context, store storage.Store, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := TakeSnapshot(ctx, store); err != nil {
+					slog.ErrorContext(ctx, "Failed to take event log snapshot", "error", err)
+				}
+			}
+		}
+	}()
+	return done
+}
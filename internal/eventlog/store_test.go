@@ -0,0 +1,103 @@
+package eventlog_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/storage/memory"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TestRecoverMatchesTables replays the log of a store where orders were
+// placed, matched and canceled: the state must agree with the tables, and
+// recovery must fail once a row is changed behind the log
+func TestRecoverMatchesTables(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	accounts := account.NewService(store)
+	orders := orderbook.NewService(store, nil, risk.NewEngine(risk.Config{}), circuitbreaker.New(circuitbreaker.DefaultConfig()))
+
+	newAccount := func(assetCode, amount string) uuid.UUID {
+		var created storage.Account
+		err := store.WithTx(ctx, func(tx storage.Tx) error {
+			var err error
+			created, err = tx.Accounts().Create(ctx, "recover")
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		value := decimal.RequireFromString(amount)
+		if _, err := accounts.Charge(ctx, created.Id, account.UpdateBalanceSchema{AssetCode: &assetCode, Amount: &value}); err != nil {
+			t.Fatal(err)
+		}
+		return created.Id
+	}
+	place := func(accountId uuid.UUID, side orderbook.OrderType, price, quantity string) orderbook.Placement {
+		placement, err := orders.Place(ctx, orderbook.PlaceOrderSchema{
+			AccountId: accountId,
+			AssetCode: "BTC",
+			Quantity:  decimal.RequireFromString(quantity),
+			Price:     decimal.RequireFromString(price),
+			OrderType: side,
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return placement
+	}
+
+	seller := newAccount("BTC", "5")
+	buyer := newAccount("BRL", "1000")
+	place(seller, orderbook.Sell, "100", "3")
+	place(buyer, orderbook.Buy, "100", "1")
+	resting := place(buyer, orderbook.Buy, "90", "2")
+	canceled := place(buyer, orderbook.Buy, "80", "1")
+	if _, err := orders.Cancel(ctx, canceled.Order.Id, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A snapshot in the middle of the log is replayed from
+	if _, err := eventlog.TakeSnapshot(ctx, store); err != nil {
+		t.Fatal(err)
+	}
+	place(buyer, orderbook.Buy, "100", "1")
+
+	state, err := eventlog.Recover(ctx, store)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if len(state.Orders) != 2 {
+		t.Errorf("got %d working orders, want the sell and the buy at 90", len(state.Orders))
+	}
+
+	// A balance changed without its event is caught
+	err = store.WithTx(ctx, func(tx storage.Tx) error {
+		return tx.Balances().Update(ctx, buyer, resting.Instrument.QuoteAssetId, decimal.NewFromInt(1))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := eventlog.Recover(ctx, store); err == nil || !strings.Contains(err.Error(), buyer.String()) {
+		t.Errorf("recover after changing a balance got %v", err)
+	}
+
+	// So is an order filled without its match
+	err = store.WithTx(ctx, func(tx storage.Tx) error {
+		return tx.Orders().UpdateFill(ctx, resting.Order.Id, decimal.NewFromInt(1), storage.PartiallyFilled)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := eventlog.Recover(ctx, store); err == nil || !strings.Contains(err.Error(), resting.Order.Id.String()) {
+		t.Errorf("recover after filling an order got %v", err)
+	}
+}
@@ -0,0 +1,332 @@
+package fix
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/config"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/storage/memory"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// harness runs a gateway on a loopback port against an in-memory store with
+// one instrument, BTC/BRL
+type harness struct {
+	t       *testing.T
+	store   *memory.Store
+	gateway *Gateway
+	address string
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	store := memory.NewEmpty()
+	btc := store.AddAsset("BTC", "Bitcoin")
+	brl := store.AddAsset("BRL", "Brazilian Real")
+	store.AddInstrument(btc, brl)
+
+	breakerConfig := circuitbreaker.DefaultConfig()
+	breakerConfig.ThresholdPercent = decimal.NewFromInt(1_000_000)
+	orders := orderbook.NewService(store, nil, risk.NewEngine(risk.Config{}), circuitbreaker.New(breakerConfig))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gateway := New(config.Fix{CompId: "CLOB", Password: "secret"}, store, orders)
+	go gateway.Serve(listener)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return &harness{t: t, store: store, gateway: gateway, address: listener.Addr().String()}
+}
+
+// fund creates an account holding the amounts of each asset
+func (h *harness) fund(amounts map[string]string) uuid.UUID {
+	h.t.Helper()
+
+	ctx := context.Background()
+	var id uuid.UUID
+	err := h.store.WithTx(ctx, func(tx storage.Tx) error {
+		account, err := tx.Accounts().Create(ctx, "fix")
+		if err != nil {
+			return err
+		}
+		id = account.Id
+		for code, amount := range amounts {
+			asset, err := tx.Assets().GetByCode(ctx, code)
+			if err != nil {
+				return err
+			}
+			if err := tx.Balances().Create(ctx, id, asset.Id); err != nil {
+				return err
+			}
+			if err := tx.Balances().Update(ctx, id, asset.Id, decimal.RequireFromString(amount)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return id
+}
+
+// disconnect closes the connection of the client and waits for the gateway
+// to notice, so the session can log on again
+func (h *harness) disconnect(c *client) {
+	h.t.Helper()
+
+	c.conn.Close()
+	for deadline := time.Now().Add(5 * time.Second); h.gateway.connected("TRADER") != nil; {
+		if time.Now().After(deadline) {
+			h.t.Fatal("session still logged on")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type client struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+	seq    int
+}
+
+// connect logs on as TRADER with the given first sequence number
+func (h *harness) connect(seq int, fields ...field) *client {
+	h.t.Helper()
+
+	conn, err := net.Dial("tcp", h.address)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	h.t.Cleanup(func() { conn.Close() })
+	c := &client{t: h.t, conn: conn, reader: bufio.NewReader(conn), seq: seq}
+
+	logon := NewMessage(msgLogon).Set(tagEncryptMethod, "0").Set(tagHeartBtInt, "30").Set(tagPassword, "secret")
+	for _, f := range fields {
+		logon.Set(f.tag, f.value)
+	}
+	c.send(logon)
+	return c
+}
+
+func (c *client) send(msg *Message) {
+	c.t.Helper()
+
+	msg.Set(tagSenderCompID, "TRADER").
+		Set(tagTargetCompID, "CLOB").
+		SetInt(tagMsgSeqNum, c.seq).
+		SetTime(tagSendingTime, time.Now())
+	c.seq++
+	if _, err := c.conn.Write(msg.Bytes()); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// expect reads the next message, which must have the type and fields given
+// as tag and value pairs
+func (c *client) expect(msgType string, fields ...field) *Message {
+	c.t.Helper()
+
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	msg, err := readMessage(c.reader)
+	if err != nil {
+		c.t.Fatalf("expected MsgType %s: %v", msgType, err)
+	}
+	if msg.Type() != msgType {
+		c.t.Fatalf("expected MsgType %s, got %s", msgType, msg)
+	}
+	for _, f := range fields {
+		if got := msg.Get(f.tag); got != f.value {
+			c.t.Fatalf("expected %d=%s, got %s", f.tag, f.value, msg)
+		}
+	}
+	return msg
+}
+
+func order(clOrdId string, account uuid.UUID, side, price, quantity string) *Message {
+	return NewMessage(msgNewOrderSingle).
+		Set(tagClOrdID, clOrdId).
+		Set(tagAccount, account.String()).
+		Set(tagSymbol, "BTC").
+		Set(tagSide, side).
+		Set(tagOrdType, "2").
+		Set(tagPrice, price).
+		Set(tagOrderQty, quantity)
+}
+
+func TestMessageRoundTrip(t *testing.T) {
+	msg := NewMessage(msgNewOrderSingle).Set(tagClOrdID, "1").SetInt(tagMsgSeqNum, 7).Set(tagSenderCompID, "TRADER")
+	parsed, err := parseMessage(msg.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.String() != msg.String() {
+		t.Fatalf("expected %s, got %s", msg, parsed)
+	}
+
+	// Header fields come first whatever the order they were set in
+	want := "8=FIX.4.4|9=25|35=D|49=TRADER|34=7|11=1|10="
+	if got := msg.String(); got[:len(want)] != want {
+		t.Fatalf("expected %s..., got %s", want, got)
+	}
+
+	corrupted := msg.Bytes()
+	corrupted[len(corrupted)-3]++
+	if _, err := parseMessage(corrupted); err != errGarbled {
+		t.Fatalf("expected a garbled message, got %v", err)
+	}
+}
+
+func TestOrderEntry(t *testing.T) {
+	h := newHarness(t)
+	seller := h.fund(map[string]string{"BTC": "2"})
+	buyer := h.fund(map[string]string{"BRL": "1000"})
+
+	c := h.connect(1)
+	c.expect(msgLogon, field{tagMsgSeqNum, "1"}, field{tagHeartBtInt, "30"})
+
+	c.send(order("sell-1", seller, "2", "100", "1"))
+	sell := c.expect(msgExecutionReport, field{tagClOrdID, "sell-1"}, field{tagExecType, execNew}, field{tagOrdStatus, "0"}, field{tagLeavesQty, "1"})
+
+	// Both sides of the trade are reported, the buy first
+	c.send(order("buy-1", buyer, "1", "100", "0.4"))
+	c.expect(msgExecutionReport, field{tagClOrdID, "buy-1"}, field{tagExecType, execNew})
+	c.expect(msgExecutionReport, field{tagClOrdID, "buy-1"}, field{tagExecType, execTrade}, field{tagOrdStatus, "2"}, field{tagLastQty, "0.4"}, field{tagLastPx, "100"})
+	c.expect(msgExecutionReport,
+		field{tagClOrdID, "sell-1"}, field{tagExecType, execTrade}, field{tagOrdStatus, "1"},
+		field{tagCumQty, "0.4"}, field{tagLeavesQty, "0.6"}, field{tagAvgPx, "100"},
+	)
+
+	// Rejections are answered right away
+	c.send(order("sell-1", seller, "2", "100", "0.1"))
+	c.expect(msgExecutionReport, field{tagClOrdID, "sell-1"}, field{tagExecType, "8"}, field{tagOrdRejReason, "6"})
+	c.send(order("buy-2", buyer, "1", "100", "100"))
+	c.expect(msgExecutionReport, field{tagExecType, "8"}, field{tagOrdRejReason, "3"}, field{tagText, "Insufficient funds"})
+	unknown := order("buy-3", buyer, "1", "100", "1").Set(tagSymbol, "ETH")
+	c.send(unknown)
+	c.expect(msgExecutionReport, field{tagExecType, "8"}, field{tagOrdRejReason, "1"})
+	c.send(NewMessage(msgNewOrderSingle).Set(tagClOrdID, "buy-4"))
+	c.expect(msgReject, field{tagSessionRejectReason, "1"}, field{tagRefTagID, "1"})
+
+	// The remaining quantity is canceled under the cancel's ClOrdID
+	c.send(NewMessage(msgOrderCancelRequest).Set(tagClOrdID, "cancel-1").Set(tagOrigClOrdID, "sell-1").Set(tagSide, "2"))
+	c.expect(msgExecutionReport,
+		field{tagOrderID, sell.Get(tagOrderID)}, field{tagClOrdID, "cancel-1"}, field{tagOrigClOrdID, "sell-1"},
+		field{tagExecType, execCanceled}, field{tagOrdStatus, "4"}, field{tagCumQty, "0.4"}, field{tagLeavesQty, "0"},
+	)
+	c.send(NewMessage(msgOrderCancelRequest).Set(tagClOrdID, "cancel-2").Set(tagOrigClOrdID, "cancel-1"))
+	c.expect(msgOrderCancelReject, field{tagCxlRejResponseTo, "1"}, field{tagCxlRejReason, "0"})
+	c.send(NewMessage(msgOrderCancelRequest).Set(tagClOrdID, "cancel-3").Set(tagOrigClOrdID, "missing"))
+	c.expect(msgOrderCancelReject, field{tagOrderID, "NONE"}, field{tagCxlRejReason, "1"})
+
+	c.send(NewMessage("AE"))
+	c.expect(msgBusinessMessageReject, field{tagRefMsgType, "AE"}, field{tagBusinessRejectReason, "3"})
+}
+
+func TestOrderCancelReplace(t *testing.T) {
+	h := newHarness(t)
+	seller := h.fund(map[string]string{"BTC": "1"})
+	buyer := h.fund(map[string]string{"BRL": "1000"})
+
+	c := h.connect(1)
+	c.expect(msgLogon)
+	c.send(order("buy-1", buyer, "1", "90", "2"))
+	c.expect(msgExecutionReport, field{tagExecType, execNew})
+	c.send(order("sell-1", seller, "2", "90", "0.5"))
+	c.expect(msgExecutionReport, field{tagClOrdID, "sell-1"}, field{tagExecType, execNew})
+	c.expect(msgExecutionReport, field{tagClOrdID, "buy-1"}, field{tagExecType, execTrade}, field{tagCumQty, "0.5"})
+	c.expect(msgExecutionReport, field{tagClOrdID, "sell-1"}, field{tagExecType, execTrade})
+
+	// The replacement keeps the executed quantity of the original order
+	replace := NewMessage(msgOrderCancelReplaceRequest).
+		Set(tagClOrdID, "buy-2").Set(tagOrigClOrdID, "buy-1").Set(tagSide, "1").
+		Set(tagOrdType, "2").Set(tagPrice, "95").Set(tagOrderQty, "3")
+	c.send(replace)
+	c.expect(msgExecutionReport,
+		field{tagClOrdID, "buy-2"}, field{tagOrigClOrdID, "buy-1"}, field{tagExecType, execReplaced}, field{tagOrdStatus, "1"},
+		field{tagPrice, "95"}, field{tagOrderQty, "3"}, field{tagCumQty, "0.5"}, field{tagLeavesQty, "2.5"},
+	)
+
+	// Not above the executed quantity
+	c.send(NewMessage(msgOrderCancelReplaceRequest).Set(tagClOrdID, "buy-3").Set(tagOrigClOrdID, "buy-2").Set(tagPrice, "95").Set(tagOrderQty, "0.5"))
+	c.expect(msgOrderCancelReject, field{tagCxlRejResponseTo, "2"}, field{tagOrdStatus, "1"})
+	c.send(NewMessage(msgOrderCancelReplaceRequest).Set(tagClOrdID, "buy-3").Set(tagOrigClOrdID, "buy-1").Set(tagPrice, "95").Set(tagOrderQty, "1"))
+	c.expect(msgOrderCancelReject, field{tagCxlRejResponseTo, "2"}, field{tagCxlRejReason, "0"})
+}
+
+func TestSequenceRecovery(t *testing.T) {
+	h := newHarness(t)
+	buyer := h.fund(map[string]string{"BRL": "1000"})
+
+	c := h.connect(1)
+	c.expect(msgLogon)
+	c.send(order("buy-1", buyer, "1", "90", "1"))
+	ack := c.expect(msgExecutionReport, field{tagMsgSeqNum, "2"})
+	h.disconnect(c)
+
+	// A lower sequence number than the one expected is refused
+	c = h.connect(1)
+	c.expect(msgLogout, field{tagMsgSeqNum, "3"})
+	h.disconnect(c)
+
+	// Missed messages are resent, the session ones are gap filled
+	c = h.connect(3)
+	c.expect(msgLogon, field{tagMsgSeqNum, "4"})
+	c.send(NewMessage(msgResendRequest).SetInt(tagBeginSeqNo, 1).SetInt(tagEndSeqNo, 0))
+	c.expect(msgSequenceReset, field{tagMsgSeqNum, "1"}, field{tagGapFillFlag, "Y"}, field{tagNewSeqNo, "2"})
+	c.expect(msgExecutionReport,
+		field{tagMsgSeqNum, "2"}, field{tagPossDupFlag, "Y"}, field{tagExecID, ack.Get(tagExecID)},
+		field{tagOrigSendingTime, ack.Get(tagSendingTime)},
+	)
+	c.expect(msgSequenceReset, field{tagMsgSeqNum, "3"}, field{tagNewSeqNo, "5"})
+
+	// A gap in what the client sent is asked for
+	c.seq += 2
+	c.send(NewMessage(msgTestRequest).Set(tagTestReqID, "late"))
+	c.expect(msgResendRequest, field{tagBeginSeqNo, "5"}, field{tagEndSeqNo, "0"})
+	c.seq = 5
+	c.send(NewMessage(msgSequenceReset).Set(tagPossDupFlag, "Y").Set(tagGapFillFlag, "Y").SetInt(tagNewSeqNo, 7))
+	c.seq = 7
+	c.send(NewMessage(msgTestRequest).Set(tagTestReqID, "now"))
+	c.expect(msgHeartbeat, field{tagTestReqID, "now"})
+
+	// Resetting starts both sequences over
+	h.disconnect(c)
+	c = h.connect(1, field{tagResetSeqNumFlag, "Y"})
+	c.expect(msgLogon, field{tagMsgSeqNum, "1"}, field{tagResetSeqNumFlag, "Y"})
+}
+
+func TestLogonRejected(t *testing.T) {
+	h := newHarness(t)
+
+	conn, err := net.Dial("tcp", h.address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	c := &client{t: t, conn: conn, reader: bufio.NewReader(conn), seq: 1}
+	c.send(NewMessage(msgLogon).Set(tagEncryptMethod, "0").Set(tagHeartBtInt, "30").Set(tagPassword, "wrong"))
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := c.reader.ReadByte(); err == nil {
+		t.Fatal("expected the connection to be closed")
+	}
+}
@@ -0,0 +1,273 @@
+package fix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/config"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var sessionsConnected = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "clob_fix_sessions_connected",
+	Help: "FIX sessions logged on to the gateway.",
+})
+
+// Gateway accepts FIX 4.4 order entry sessions. Orders go through the same
+// service as the REST API and execution reports are built from the event log,
+// so orders placed or matched by any transport are reported.
+type Gateway struct {
+	cfg    config.Fix
+	store  storage.Store
+	orders *orderbook.Service
+
+	listener net.Listener
+	ctx      context.Context
+	stop     context.CancelFunc
+	wg       sync.WaitGroup
+	wake     chan struct{}
+
+	mu sync.Mutex
+	// conns holds the logged on connection of each session
+	conns map[string]*conn
+	// locks serialize the messages sent on each session, so they are written
+	// in sequence number order
+	locks map[string]*sync.Mutex
+}
+
+func New(cfg config.Fix, store storage.Store, orders *orderbook.Service) *Gateway {
+	ctx, stop := context.WithCancel(context.Background())
+	return &Gateway{
+		cfg:    cfg,
+		store:  store,
+		orders: orders,
+		ctx:    ctx,
+		stop:   stop,
+		wake:   make(chan struct{}, 1),
+		conns:  map[string]*conn{},
+		locks:  map[string]*sync.Mutex{},
+	}
+}
+
+// Listen accepts sessions on the address and reports executions until
+// Shutdown is called
+func (g *Gateway) Listen(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return g.Serve(listener)
+}
+
+// Serve is Listen on an open listener
+func (g *Gateway) Serve(listener net.Listener) error {
+	reporter, err := newReporter(g)
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	g.mu.Lock()
+	g.listener = listener
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		reporter.run(g.ctx)
+	}()
+
+	for {
+		netConn, err := listener.Accept()
+		if err != nil {
+			if g.ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		g.wg.Add(1)
+		go func() {
+			defer g.wg.Done()
+			g.handle(netConn)
+		}()
+	}
+}
+
+// Shutdown stops accepting sessions, logs out the connected ones and waits
+// for the messages being processed
+func (g *Gateway) Shutdown(ctx context.Context) error {
+	g.stop()
+	g.mu.Lock()
+	if g.listener != nil {
+		g.listener.Close()
+	}
+	conns := make([]*conn, 0, len(g.conns))
+	for _, c := range g.conns {
+		conns = append(conns, c)
+	}
+	g.mu.Unlock()
+
+	for _, c := range conns {
+		c.logout("Gateway shutting down")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (g *Gateway) lock(id string) *sync.Mutex {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	lock, ok := g.locks[id]
+	if !ok {
+		lock = &sync.Mutex{}
+		g.locks[id] = lock
+	}
+	return lock
+}
+
+func (g *Gateway) connected(id string) *conn {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.conns[id]
+}
+
+// register makes c the connection of its session, false when the session is
+// already logged on
+func (g *Gateway) register(c *conn) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.conns[c.id]; ok || g.ctx.Err() != nil {
+		return false
+	}
+	g.conns[c.id] = c
+	sessionsConnected.Inc()
+	return true
+}
+
+func (g *Gateway) unregister(c *conn) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.conns[c.id] == c {
+		delete(g.conns, c.id)
+		sessionsConnected.Dec()
+	}
+}
+
+// notify wakes the reporter up so executions are reported right away
+func (g *Gateway) notify() {
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends the messages built by build on the session, if it is logged
+// on. They are stored with their sequence number first so they can be resent
+// and reach sessions logged on later through resend requests.
+func (g *Gateway) deliver(ctx context.Context, id string, event int64, build func(tx storage.Tx) ([]*Message, error)) error {
+	lock := g.lock(id)
+	lock.Lock()
+	defer lock.Unlock()
+
+	data, err := g.send(ctx, id, event, build)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+	if c := g.connected(id); c != nil {
+		c.write(data)
+	}
+	return nil
+}
+
+// send stamps the messages built by build with the session header, stores
+// them and returns their encoding. Messages reporting an event the session
+// already got are not built again. The session lock must be held.
+func (g *Gateway) send(ctx context.Context, id string, event int64, build func(tx storage.Tx) ([]*Message, error)) ([]byte, error) {
+	var out bytes.Buffer
+	err := g.store.WithTx(ctx, func(tx storage.Tx) error {
+		out.Reset()
+		session, err := tx.Fix().GetSession(ctx, id)
+		if err != nil {
+			return err
+		}
+		if event > 0 && session.LastEvent >= event {
+			return nil
+		}
+
+		messages, err := build(tx)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, message := range messages {
+			message.Set(tagSenderCompID, g.cfg.CompId).
+				Set(tagTargetCompID, id).
+				SetInt(tagMsgSeqNum, session.NextSenderSeq).
+				SetTime(tagSendingTime, now)
+			data := message.Bytes()
+			err := tx.Fix().AppendMessage(ctx, storage.FixMessage{
+				SessionId: id,
+				Seq:       session.NextSenderSeq,
+				Type:      message.Type(),
+				Data:      data,
+			})
+			if err != nil {
+				return err
+			}
+			session.NextSenderSeq++
+			out.Write(data)
+		}
+		if event > 0 {
+			session.LastEvent = event
+		}
+		return tx.Fix().SaveSession(ctx, session)
+	})
+	return out.Bytes(), err
+}
+
+// received records the sequence number the session is expected to send next
+func (g *Gateway) received(ctx context.Context, id string, next int) error {
+	return g.store.WithTx(ctx, func(tx storage.Tx) error {
+		session, err := tx.Fix().GetSession(ctx, id)
+		if err != nil {
+			return err
+		}
+		session.NextTargetSeq = next
+		return tx.Fix().SaveSession(ctx, session)
+	})
+}
+
+// handle runs a connection until it is closed
+func (g *Gateway) handle(netConn net.Conn) {
+	c := newConn(g, netConn)
+	defer netConn.Close()
+
+	if err := c.logon(); err != nil {
+		if !errors.Is(err, errClosed) {
+			slog.Warn("FIX logon failed", "remote", netConn.RemoteAddr().String(), "error", err)
+		}
+		return
+	}
+	defer g.unregister(c)
+
+	c.logger.Info("FIX session logged on", "heartbeat", c.heartbeat)
+	err := c.run()
+	c.logger.Info("FIX session logged out", "reason", err)
+}
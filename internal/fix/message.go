@@ -0,0 +1,300 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	beginString = "FIX.4.4"
+	soh         = '\x01'
+	// maxBodyLength bounds the messages read, order entry ones are far shorter
+	maxBodyLength = 64 << 10
+	timeFormat    = "20060102-15:04:05.000"
+)
+
+// Tags used by the gateway
+const (
+	tagAccount              = 1
+	tagAvgPx                = 6
+	tagBeginSeqNo           = 7
+	tagBeginString          = 8
+	tagBodyLength           = 9
+	tagCheckSum             = 10
+	tagClOrdID              = 11
+	tagCumQty               = 14
+	tagEndSeqNo             = 16
+	tagExecID               = 17
+	tagLastPx               = 31
+	tagLastQty              = 32
+	tagMsgSeqNum            = 34
+	tagMsgType              = 35
+	tagNewSeqNo             = 36
+	tagOrderID              = 37
+	tagOrderQty             = 38
+	tagOrdStatus            = 39
+	tagOrdType              = 40
+	tagOrigClOrdID          = 41
+	tagPossDupFlag          = 43
+	tagPrice                = 44
+	tagRefSeqNum            = 45
+	tagSenderCompID         = 49
+	tagSendingTime          = 52
+	tagSide                 = 54
+	tagSymbol               = 55
+	tagTargetCompID         = 56
+	tagText                 = 58
+	tagTransactTime         = 60
+	tagEncryptMethod        = 98
+	tagCxlRejReason         = 102
+	tagOrdRejReason         = 103
+	tagHeartBtInt           = 108
+	tagTestReqID            = 112
+	tagOrigSendingTime      = 122
+	tagGapFillFlag          = 123
+	tagResetSeqNumFlag      = 141
+	tagExecType             = 150
+	tagLeavesQty            = 151
+	tagRefTagID             = 371
+	tagRefMsgType           = 372
+	tagSessionRejectReason  = 373
+	tagBusinessRejectReason = 380
+	tagCxlRejResponseTo     = 434
+	tagPassword             = 554
+)
+
+// Message types
+const (
+	msgHeartbeat                 = "0"
+	msgTestRequest               = "1"
+	msgResendRequest             = "2"
+	msgReject                    = "3"
+	msgSequenceReset             = "4"
+	msgLogout                    = "5"
+	msgExecutionReport           = "8"
+	msgOrderCancelReject         = "9"
+	msgLogon                     = "A"
+	msgNewOrderSingle            = "D"
+	msgOrderCancelRequest        = "F"
+	msgOrderCancelReplaceRequest = "G"
+	msgBusinessMessageReject     = "j"
+)
+
+// isAdmin reports if the message type belongs to the session layer, those
+// are never resent but gap filled
+func isAdmin(msgType string) bool {
+	switch msgType {
+	case msgHeartbeat, msgTestRequest, msgResendRequest, msgReject, msgSequenceReset, msgLogout, msgLogon:
+		return true
+	}
+	return false
+}
+
+// headerTags are written right after MsgType in this order
+var headerTags = []int{tagSenderCompID, tagTargetCompID, tagMsgSeqNum, tagPossDupFlag, tagSendingTime, tagOrigSendingTime}
+
+type field struct {
+	tag   int
+	value string
+}
+
+// Message is a FIX message, BeginString, BodyLength and CheckSum are only
+// added when encoding
+type Message struct {
+	fields []field
+}
+
+func NewMessage(msgType string) *Message {
+	return (&Message{}).Set(tagMsgType, msgType)
+}
+
+// Set replaces the value of the tag, or adds it
+func (m *Message) Set(tag int, value string) *Message {
+	for i := range m.fields {
+		if m.fields[i].tag == tag {
+			m.fields[i].value = value
+			return m
+		}
+	}
+	m.fields = append(m.fields, field{tag, value})
+	return m
+}
+
+func (m *Message) SetInt(tag, value int) *Message {
+	return m.Set(tag, strconv.Itoa(value))
+}
+
+func (m *Message) SetDecimal(tag int, value decimal.Decimal) *Message {
+	return m.Set(tag, value.String())
+}
+
+func (m *Message) SetTime(tag int, value time.Time) *Message {
+	return m.Set(tag, value.UTC().Format(timeFormat))
+}
+
+// Get returns the value of the tag, empty when missing
+func (m *Message) Get(tag int) string {
+	for _, f := range m.fields {
+		if f.tag == tag {
+			return f.value
+		}
+	}
+	return ""
+}
+
+func (m *Message) Has(tag int) bool {
+	for _, f := range m.fields {
+		if f.tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Message) Type() string {
+	return m.Get(tagMsgType)
+}
+
+func (m *Message) Int(tag int) (int, error) {
+	return strconv.Atoi(m.Get(tag))
+}
+
+func (m *Message) Decimal(tag int) (decimal.Decimal, error) {
+	return decimal.NewFromString(m.Get(tag))
+}
+
+func (m *Message) Bool(tag int) bool {
+	return m.Get(tag) == "Y"
+}
+
+// Bytes encodes the message with the standard header first, its body length
+// and checksum
+func (m *Message) Bytes() []byte {
+	var body bytes.Buffer
+	write := func(tag int, value string) {
+		body.WriteString(strconv.Itoa(tag))
+		body.WriteByte('=')
+		body.WriteString(value)
+		body.WriteByte(soh)
+	}
+	write(tagMsgType, m.Type())
+	for _, tag := range headerTags {
+		if m.Has(tag) {
+			write(tag, m.Get(tag))
+		}
+	}
+	for _, f := range m.fields {
+		switch f.tag {
+		case tagBeginString, tagBodyLength, tagCheckSum, tagMsgType:
+			continue
+		}
+		if !isHeader(f.tag) {
+			write(f.tag, f.value)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%d=%s%c%d=%d%c", tagBeginString, beginString, soh, tagBodyLength, body.Len(), soh)
+	out.Write(body.Bytes())
+	fmt.Fprintf(&out, "%d=%03d%c", tagCheckSum, checksum(out.Bytes()), soh)
+	return out.Bytes()
+}
+
+func isHeader(tag int) bool {
+	for _, header := range headerTags {
+		if header == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func checksum(data []byte) int {
+	sum := 0
+	for _, b := range data {
+		sum += int(b)
+	}
+	return sum % 256
+}
+
+func (m *Message) String() string {
+	return strings.ReplaceAll(string(m.Bytes()), string(soh), "|")
+}
+
+// errGarbled is a message that can be skipped, the stream is still in sync
+var errGarbled = errors.New("garbled message")
+
+// readMessage reads the next message, framed by its BeginString, BodyLength
+// and CheckSum fields
+func readMessage(r *bufio.Reader) (*Message, error) {
+	begin, err := r.ReadString(soh)
+	if err != nil {
+		return nil, err
+	}
+	if begin != fmt.Sprintf("%d=%s%c", tagBeginString, beginString, soh) {
+		return nil, fmt.Errorf("unexpected BeginString %q", strings.TrimSuffix(begin, string(soh)))
+	}
+	length, err := r.ReadString(soh)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := strings.CutPrefix(strings.TrimSuffix(length, string(soh)), strconv.Itoa(tagBodyLength)+"=")
+	if !ok {
+		return nil, fmt.Errorf("expected BodyLength, got %q", length)
+	}
+	bodyLength, err := strconv.Atoi(value)
+	if err != nil || bodyLength <= 0 || bodyLength > maxBodyLength {
+		return nil, fmt.Errorf("invalid BodyLength %q", value)
+	}
+
+	// The body is followed by the 7 bytes of 10=NNN<SOH>
+	rest := make([]byte, bodyLength+7)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return nil, err
+	}
+	body, trailer := rest[:bodyLength], string(rest[bodyLength:])
+	if !strings.HasPrefix(trailer, strconv.Itoa(tagCheckSum)+"=") || trailer[6] != soh {
+		return nil, fmt.Errorf("expected CheckSum after %d bytes of body", bodyLength)
+	}
+	received, err := strconv.Atoi(trailer[3:6])
+	if err != nil {
+		return nil, fmt.Errorf("invalid CheckSum %q", trailer[3:6])
+	}
+	if received != checksum([]byte(begin+length+string(body))) {
+		return nil, errGarbled
+	}
+
+	return parseBody(body)
+}
+
+// parseMessage decodes a whole encoded message, as kept for resends
+func parseMessage(data []byte) (*Message, error) {
+	return readMessage(bufio.NewReader(bytes.NewReader(data)))
+}
+
+func parseBody(body []byte) (*Message, error) {
+	m := &Message{}
+	for _, raw := range bytes.Split(bytes.TrimSuffix(body, []byte{soh}), []byte{soh}) {
+		tag, value, ok := strings.Cut(string(raw), "=")
+		if !ok {
+			return nil, errGarbled
+		}
+		number, err := strconv.Atoi(tag)
+		if err != nil || number <= 0 {
+			return nil, errGarbled
+		}
+		m.fields = append(m.fields, field{number, value})
+	}
+	if m.Type() == "" {
+		return nil, errGarbled
+	}
+	return m, nil
+}
@@ -0,0 +1,367 @@
+package fix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// OrdRejReason values, tag 103
+const (
+	ordRejUnknownSymbol  = 1
+	ordRejExchangeClosed = 2
+	ordRejExceedsLimit   = 3
+	ordRejDuplicate      = 6
+	ordRejOther          = 99
+)
+
+// CxlRejReason values, tag 102
+const (
+	cxlRejTooLate      = 0
+	cxlRejUnknownOrder = 1
+	cxlRejDuplicate    = 6
+	cxlRejOther        = 99
+)
+
+// CxlRejResponseTo values, tag 434
+const (
+	responseToCancel  = "1"
+	responseToReplace = "2"
+)
+
+// errDuplicate refuses a ClOrdID already used on the session
+var errDuplicate = errors.New("Duplicate ClOrdID")
+
+var sides = map[string]orderbook.OrderType{"1": orderbook.Buy, "2": orderbook.Sell}
+
+func sideCode(side orderbook.OrderType) string {
+	if side == orderbook.Buy {
+		return "1"
+	}
+	return "2"
+}
+
+// missing returns the first of the tags the message lacks, 0 when it has
+// them all
+func missing(msg *Message, tags ...int) int {
+	for _, tag := range tags {
+		if msg.Get(tag) == "" {
+			return tag
+		}
+	}
+	return 0
+}
+
+// newOrderSingle places a limit order, it is acknowledged by the reporter
+// once accepted
+func (c *conn) newOrderSingle(ctx context.Context, msg *Message) {
+	if tag := missing(msg, tagClOrdID, tagAccount, tagSymbol, tagSide, tagOrdType, tagOrderQty, tagPrice); tag > 0 {
+		c.reject(msg, tag, rejectRequiredTagMissing, "Required tag missing")
+		return
+	}
+	order, err := parseOrder(msg)
+	if err != nil {
+		c.send(ctx, rejectedOrder(msg, ordRejOther, err.Error()))
+		return
+	}
+
+	clOrdId := msg.Get(tagClOrdID)
+	_, err = c.gateway.orders.Place(ctx, order, func(tx storage.Tx, created orderbook.OrderBook) error {
+		if err := c.unused(ctx, tx, clOrdId); err != nil {
+			return err
+		}
+		return tx.Fix().CreateOrder(ctx, storage.FixOrder{
+			OrderId:   created.Id,
+			SessionId: c.id,
+			ClOrdId:   clOrdId,
+			Symbol:    msg.Get(tagSymbol),
+			Side:      order.OrderType,
+			Price:     order.Price,
+			OrderQty:  order.Quantity,
+		})
+	})
+	if err != nil {
+		reason, text := c.rejectReason(ctx, err)
+		c.send(ctx, rejectedOrder(msg, reason, text))
+		return
+	}
+	c.gateway.notify()
+}
+
+// parseOrder maps a NewOrderSingle to the REST placement schema, only limit
+// orders are supported
+func parseOrder(msg *Message) (orderbook.PlaceOrderSchema, error) {
+	accountId, err := uuid.Parse(msg.Get(tagAccount))
+	if err != nil {
+		return orderbook.PlaceOrderSchema{}, errors.New("Account must be an account id")
+	}
+	side, ok := sides[msg.Get(tagSide)]
+	if !ok {
+		return orderbook.PlaceOrderSchema{}, errors.New("Side must be 1 (buy) or 2 (sell)")
+	}
+	if msg.Get(tagOrdType) != "2" {
+		return orderbook.PlaceOrderSchema{}, errors.New("OrdType must be 2 (limit)")
+	}
+	quantity, price, err := parseQuantityAndPrice(msg)
+	if err != nil {
+		return orderbook.PlaceOrderSchema{}, err
+	}
+
+	order := orderbook.PlaceOrderSchema{
+		AccountId: accountId,
+		AssetCode: msg.Get(tagSymbol),
+		Quantity:  quantity,
+		Price:     price,
+		OrderType: side,
+	}
+	if err := helper.ValidateInput(&order); err != nil {
+		return orderbook.PlaceOrderSchema{}, err
+	}
+	return order, nil
+}
+
+func parseQuantityAndPrice(msg *Message) (decimal.Decimal, decimal.Decimal, error) {
+	quantity, err := msg.Decimal(tagOrderQty)
+	if err != nil || !quantity.IsPositive() {
+		return decimal.Decimal{}, decimal.Decimal{}, errors.New("OrderQty must be a positive number")
+	}
+	price, err := msg.Decimal(tagPrice)
+	if err != nil || !price.IsPositive() {
+		return decimal.Decimal{}, decimal.Decimal{}, errors.New("Price must be a positive number")
+	}
+	return quantity, price, nil
+}
+
+// unused fails with errDuplicate when the ClOrdID is taken on the session
+func (c *conn) unused(ctx context.Context, tx storage.Tx, clOrdId string) error {
+	_, err := tx.Fix().GetOrderByClOrdId(ctx, c.id, clOrdId)
+	if err == nil {
+		return errDuplicate
+	}
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// rejectReason maps a placement error to its OrdRejReason and text
+func (c *conn) rejectReason(ctx context.Context, err error) (int, string) {
+	if errors.Is(err, errDuplicate) {
+		return ordRejDuplicate, err.Error()
+	}
+	var rejection orderbook.Rejection
+	if !errors.As(err, &rejection) {
+		c.logger.ErrorContext(ctx, "Failed to process FIX order", "error", err)
+		return ordRejOther, "Internal error"
+	}
+	switch rejection.Status {
+	case fiber.StatusNotFound:
+		return ordRejUnknownSymbol, rejection.Message
+	case fiber.StatusConflict:
+		return ordRejExchangeClosed, rejection.Message
+	case fiber.StatusPaymentRequired, fiber.StatusUnprocessableEntity:
+		return ordRejExceedsLimit, rejection.Message
+	}
+	return ordRejOther, rejection.Message
+}
+
+// rejectedOrder is the ExecutionReport of an order refused before entering
+// the book, it has no order id
+func rejectedOrder(msg *Message, reason int, text string) *Message {
+	report := NewMessage(msgExecutionReport).
+		Set(tagOrderID, "NONE").
+		Set(tagClOrdID, msg.Get(tagClOrdID)).
+		Set(tagExecID, uuid.NewString()).
+		Set(tagExecType, "8").
+		Set(tagOrdStatus, "8")
+	for _, tag := range []int{tagAccount, tagSymbol, tagSide, tagOrderQty, tagPrice} {
+		if value := msg.Get(tag); value != "" {
+			report.Set(tag, value)
+		}
+	}
+	return report.
+		Set(tagCumQty, "0").
+		Set(tagLeavesQty, "0").
+		Set(tagAvgPx, "0").
+		SetInt(tagOrdRejReason, reason).
+		Set(tagText, text).
+		SetTime(tagTransactTime, time.Now())
+}
+
+// orderCancelRequest cancels the order the OrigClOrdID designates, the
+// cancel is reported by the reporter under the new ClOrdID
+func (c *conn) orderCancelRequest(ctx context.Context, msg *Message) {
+	if tag := missing(msg, tagClOrdID, tagOrigClOrdID); tag > 0 {
+		c.reject(msg, tag, rejectRequiredTagMissing, "Required tag missing")
+		return
+	}
+	link, ok := c.link(ctx, msg, responseToCancel)
+	if !ok {
+		return
+	}
+
+	clOrdId := msg.Get(tagClOrdID)
+	_, err := c.gateway.orders.Cancel(ctx, link.OrderId, func(tx storage.Tx, canceled orderbook.OrderBook) error {
+		if err := c.unused(ctx, tx, clOrdId); err != nil {
+			return err
+		}
+		current, err := tx.Fix().GetOrder(ctx, canceled.Id)
+		if err != nil {
+			return err
+		}
+		current.OrigClOrdId, current.ClOrdId = current.ClOrdId, clOrdId
+		return tx.Fix().UpdateOrder(ctx, current)
+	})
+	if err != nil {
+		c.cancelRejected(ctx, msg, link, responseToCancel, err)
+		return
+	}
+	c.gateway.notify()
+}
+
+// orderCancelReplaceRequest replaces the order the OrigClOrdID designates
+// with one at the new price and quantity, fills of the original order count
+// towards the new quantity
+func (c *conn) orderCancelReplaceRequest(ctx context.Context, msg *Message) {
+	if tag := missing(msg, tagClOrdID, tagOrigClOrdID, tagOrderQty, tagPrice); tag > 0 {
+		c.reject(msg, tag, rejectRequiredTagMissing, "Required tag missing")
+		return
+	}
+	if ordType := msg.Get(tagOrdType); ordType != "" && ordType != "2" {
+		c.reject(msg, tagOrdType, rejectValueIncorrect, "OrdType must be 2 (limit)")
+		return
+	}
+	link, ok := c.link(ctx, msg, responseToReplace)
+	if !ok {
+		return
+	}
+	quantity, price, err := parseQuantityAndPrice(msg)
+	if err != nil {
+		c.send(ctx, cancelReject(msg, link, responseToReplace, cxlRejOther, err.Error()))
+		return
+	}
+
+	// Replace deducts what the order filled, the orders it replaced filled
+	// what its quantity falls short of the one on the session
+	var carried decimal.Decimal
+	err = c.gateway.store.WithTx(ctx, func(tx storage.Tx) error {
+		order, err := tx.Orders().Get(ctx, link.OrderId)
+		carried = link.OrderQty.Sub(order.TotalQuantity)
+		return err
+	})
+	if err != nil {
+		c.cancelRejected(ctx, msg, link, responseToReplace, err)
+		return
+	}
+
+	clOrdId := msg.Get(tagClOrdID)
+	_, err = c.gateway.orders.Replace(ctx, link.OrderId, price, quantity.Sub(carried), func(tx storage.Tx, canceled, created orderbook.OrderBook) error {
+		if err := c.unused(ctx, tx, clOrdId); err != nil {
+			return err
+		}
+		original, err := tx.Fix().GetOrder(ctx, canceled.Id)
+		if err != nil {
+			return err
+		}
+		original.Replaced = true
+		if err := tx.Fix().UpdateOrder(ctx, original); err != nil {
+			return err
+		}
+		return tx.Fix().CreateOrder(ctx, storage.FixOrder{
+			OrderId:     created.Id,
+			SessionId:   c.id,
+			ClOrdId:     clOrdId,
+			OrigClOrdId: original.ClOrdId,
+			Symbol:      original.Symbol,
+			Side:        original.Side,
+			Price:       price,
+			OrderQty:    quantity,
+		})
+	})
+	if err != nil {
+		c.cancelRejected(ctx, msg, link, responseToReplace, err)
+		return
+	}
+	c.gateway.notify()
+}
+
+// link finds the order placed on the session under the OrigClOrdID, unknown
+// orders are answered with an OrderCancelReject
+func (c *conn) link(ctx context.Context, msg *Message, responseTo string) (storage.FixOrder, bool) {
+	var link storage.FixOrder
+	err := c.gateway.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		link, err = tx.Fix().GetOrderByClOrdId(ctx, c.id, msg.Get(tagOrigClOrdID))
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			c.logger.ErrorContext(ctx, "Failed to look up FIX order", "error", err)
+		}
+		c.send(ctx, cancelReject(msg, storage.FixOrder{}, responseTo, cxlRejUnknownOrder, "Unknown order"))
+		return storage.FixOrder{}, false
+	}
+	return link, true
+}
+
+func (c *conn) cancelRejected(ctx context.Context, msg *Message, link storage.FixOrder, responseTo string, err error) {
+	reason, text := cxlRejOther, ""
+	var rejection orderbook.Rejection
+	switch {
+	case errors.Is(err, errDuplicate):
+		reason, text = cxlRejDuplicate, err.Error()
+	case errors.As(err, &rejection):
+		text = rejection.Message
+		switch rejection.Code {
+		case helper.CodeOrderNotFound:
+			reason = cxlRejUnknownOrder
+		case helper.CodeOrderNotCancelable:
+			// Filled or already canceled orders are not eligible
+			reason = cxlRejTooLate
+		}
+	default:
+		c.logger.ErrorContext(ctx, "Failed to process FIX cancel", "error", err)
+		text = "Internal error"
+	}
+	c.send(ctx, cancelReject(msg, link, responseTo, reason, text))
+}
+
+// cancelReject answers a cancel or replace that failed, link is empty when
+// the order is unknown
+func cancelReject(msg *Message, link storage.FixOrder, responseTo string, reason int, text string) *Message {
+	orderId, status := "NONE", "8"
+	if link.OrderId != uuid.Nil {
+		orderId, status = link.OrderId.String(), ordStatus(link)
+	}
+	return NewMessage(msgOrderCancelReject).
+		Set(tagOrderID, orderId).
+		Set(tagClOrdID, msg.Get(tagClOrdID)).
+		Set(tagOrigClOrdID, msg.Get(tagOrigClOrdID)).
+		Set(tagOrdStatus, status).
+		Set(tagCxlRejResponseTo, responseTo).
+		SetInt(tagCxlRejReason, reason).
+		Set(tagText, text)
+}
+
+// ordStatus is the status of a working order from its fills
+func ordStatus(link storage.FixOrder) string {
+	switch {
+	case link.CumQty.GreaterThanOrEqual(link.OrderQty):
+		return "2"
+	case link.CumQty.IsPositive():
+		return "1"
+	}
+	return "0"
+}
+
+func execId(sequence int64, orderId uuid.UUID) string {
+	return fmt.Sprintf("%d-%s", sequence, orderId)
+}
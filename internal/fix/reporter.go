@@ -0,0 +1,266 @@
+package fix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	reportInterval = 100 * time.Millisecond
+	reportBatch    = 500
+)
+
+// ExecType values, tag 150
+const (
+	execNew      = "0"
+	execCanceled = "4"
+	execReplaced = "5"
+	execTrade    = "F"
+)
+
+// reporter tails the event log and sends the ExecutionReports of the orders
+// placed through the gateway to their session. Each session records the last
+// event it was reported, so reports are neither lost nor duplicated across
+// restarts.
+type reporter struct {
+	gateway *Gateway
+	cursor  int64
+}
+
+// newReporter starts from the session the least up to date
+func newReporter(g *Gateway) (*reporter, error) {
+	ctx := context.Background()
+	r := &reporter{gateway: g}
+	err := g.store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		if r.cursor, err = tx.Events().Last(ctx); err != nil {
+			return err
+		}
+		sessions, err := tx.Fix().ListSessions(ctx)
+		if err != nil {
+			return err
+		}
+		for _, session := range sessions {
+			r.cursor = min(r.cursor, session.LastEvent)
+		}
+		return nil
+	})
+	return r, err
+}
+
+func (r *reporter) run(ctx context.Context) {
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.report(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("Failed to send FIX execution reports", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.gateway.wake:
+		}
+	}
+}
+
+// report sends the reports of the events after the cursor
+func (r *reporter) report(ctx context.Context) error {
+	for {
+		events, err := eventlog.ListEvents(ctx, r.gateway.store, r.cursor, reportBatch)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		ordersOf := make([][]uuid.UUID, len(events))
+		var ids []uuid.UUID
+		for i, event := range events {
+			if ordersOf[i], err = orderIds(event); err != nil {
+				return err
+			}
+			ids = append(ids, ordersOf[i]...)
+		}
+		sessionOf, err := r.sessions(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for i, event := range events {
+			bySession := map[string][]uuid.UUID{}
+			for _, id := range ordersOf[i] {
+				if session, ok := sessionOf[id]; ok {
+					bySession[session] = append(bySession[session], id)
+				}
+			}
+			for _, session := range slices.Sorted(maps.Keys(bySession)) {
+				err := r.gateway.deliver(ctx, session, event.Sequence, func(tx storage.Tx) ([]*Message, error) {
+					return executionReports(ctx, tx, event, bySession[session])
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		last := events[len(events)-1].Sequence
+		if err := r.advance(ctx, last); err != nil {
+			return err
+		}
+		r.cursor = last
+	}
+}
+
+// orderIds returns the orders an event reports on
+func orderIds(event eventlog.Event) ([]uuid.UUID, error) {
+	switch event.Type {
+	case eventlog.OrderAccepted:
+		var payload eventlog.OrderAcceptedPayload
+		err := json.Unmarshal(event.Payload, &payload)
+		return []uuid.UUID{payload.OrderId}, err
+	case eventlog.OrderMatched:
+		var payload eventlog.OrderMatchedPayload
+		err := json.Unmarshal(event.Payload, &payload)
+		return []uuid.UUID{payload.BuyOrderId, payload.SellOrderId}, err
+	case eventlog.OrderCanceled:
+		var payload eventlog.OrderCanceledPayload
+		err := json.Unmarshal(event.Payload, &payload)
+		return []uuid.UUID{payload.OrderId}, err
+	}
+	return nil, nil
+}
+
+// sessions returns the session of the orders placed through the gateway
+func (r *reporter) sessions(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]string, error) {
+	sessionOf := map[uuid.UUID]string{}
+	if len(ids) == 0 {
+		return sessionOf, nil
+	}
+	err := r.gateway.store.WithTx(ctx, func(tx storage.Tx) error {
+		for _, id := range ids {
+			link, err := tx.Fix().GetOrder(ctx, id)
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			sessionOf[id] = link.SessionId
+		}
+		return nil
+	})
+	return sessionOf, err
+}
+
+// advance records every session was reported the events up to sequence
+func (r *reporter) advance(ctx context.Context, sequence int64) error {
+	return r.gateway.store.WithTx(ctx, func(tx storage.Tx) error {
+		sessions, err := tx.Fix().ListSessions(ctx)
+		if err != nil {
+			return err
+		}
+		for _, session := range sessions {
+			if session.LastEvent >= sequence {
+				continue
+			}
+			// Lock the session before updating it
+			session, err := tx.Fix().GetSession(ctx, session.Id)
+			if err != nil {
+				return err
+			}
+			if session.LastEvent < sequence {
+				session.LastEvent = sequence
+				if err := tx.Fix().SaveSession(ctx, session); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// executionReports builds the reports of an event for orders of a single
+// session, updating their executed quantity on fills
+func executionReports(ctx context.Context, tx storage.Tx, event eventlog.Event, ids []uuid.UUID) ([]*Message, error) {
+	var reports []*Message
+	for _, id := range ids {
+		link, err := tx.Fix().GetOrder(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch event.Type {
+		case eventlog.OrderAccepted:
+			execType := execNew
+			if link.OrigClOrdId != "" {
+				// A replacement carries over the fills of the order it replaced
+				original, err := tx.Fix().GetOrderByClOrdId(ctx, link.SessionId, link.OrigClOrdId)
+				if err != nil && !errors.Is(err, storage.ErrNotFound) {
+					return nil, err
+				}
+				if err == nil && original.Replaced {
+					execType = execReplaced
+					link.CumQty, link.AvgPx = original.CumQty, original.AvgPx
+					if err := tx.Fix().UpdateOrder(ctx, link); err != nil {
+						return nil, err
+					}
+				}
+			}
+			reports = append(reports, executionReport(event, link, execType, ordStatus(link)))
+
+		case eventlog.OrderMatched:
+			var payload eventlog.OrderMatchedPayload
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				return nil, err
+			}
+			cumQty := link.CumQty.Add(payload.Quantity)
+			link.AvgPx = link.AvgPx.Mul(link.CumQty).Add(payload.Price.Mul(payload.Quantity)).Div(cumQty)
+			link.CumQty = cumQty
+			if err := tx.Fix().UpdateOrder(ctx, link); err != nil {
+				return nil, err
+			}
+			reports = append(reports, executionReport(event, link, execTrade, ordStatus(link)).
+				SetDecimal(tagLastQty, payload.Quantity).
+				SetDecimal(tagLastPx, payload.Price))
+
+		case eventlog.OrderCanceled:
+			// The replacement is reported instead
+			if link.Replaced {
+				continue
+			}
+			report := executionReport(event, link, execCanceled, "4")
+			reports = append(reports, report.Set(tagLeavesQty, "0"))
+		}
+	}
+	return reports, nil
+}
+
+func executionReport(event eventlog.Event, link storage.FixOrder, execType, status string) *Message {
+	report := NewMessage(msgExecutionReport).
+		Set(tagOrderID, link.OrderId.String()).
+		Set(tagClOrdID, link.ClOrdId)
+	if link.OrigClOrdId != "" {
+		report.Set(tagOrigClOrdID, link.OrigClOrdId)
+	}
+	return report.
+		Set(tagExecID, execId(event.Sequence, link.OrderId)).
+		Set(tagExecType, execType).
+		Set(tagOrdStatus, status).
+		Set(tagSymbol, link.Symbol).
+		Set(tagSide, sideCode(link.Side)).
+		SetDecimal(tagOrderQty, link.OrderQty).
+		SetDecimal(tagPrice, link.Price).
+		SetDecimal(tagCumQty, link.CumQty).
+		SetDecimal(tagLeavesQty, decimal.Max(link.OrderQty.Sub(link.CumQty), decimal.Zero)).
+		SetDecimal(tagAvgPx, link.AvgPx).
+		SetTime(tagTransactTime, event.CreatedAt)
+}
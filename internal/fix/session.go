@@ -0,0 +1,515 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/logging"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/tracing"
+)
+
+const (
+	logonTimeout = 10 * time.Second
+	writeTimeout = 10 * time.Second
+)
+
+// Session level reject reasons, tag 373
+const (
+	rejectRequiredTagMissing = 1
+	rejectValueIncorrect     = 5
+	rejectInvalidMsgType     = 11
+)
+
+// Business reject reasons, tag 380
+const businessRejectUnsupportedMsgType = 3
+
+var (
+	errClosed    = errors.New("connection closed")
+	errLoggedOut = errors.New("logged out")
+)
+
+// conn is the connection of a logged on session. Its messages are read and
+// processed one at a time, in sequence number order.
+type conn struct {
+	gateway   *Gateway
+	net       net.Conn
+	reader    *bufio.Reader
+	logger    *slog.Logger
+	id        string
+	heartbeat time.Duration
+
+	// expected is the next sequence number the session must send,
+	// resendTarget the highest one received while a gap is being resent
+	expected     int
+	resendTarget int
+
+	mu            sync.Mutex
+	lastSent      time.Time
+	lastReceived  time.Time
+	testRequested bool
+	closed        bool
+}
+
+func newConn(g *Gateway, netConn net.Conn) *conn {
+	return &conn{
+		gateway: g,
+		net:     netConn,
+		reader:  bufio.NewReader(netConn),
+		logger:  slog.With("remote", netConn.RemoteAddr().String()),
+	}
+}
+
+// write sends already stored messages, a failed write closes the connection
+func (c *conn) write(data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.net.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := c.net.Write(data); err != nil {
+		c.logger.Warn("FIX write failed, closing the connection", "error", err)
+		c.closeLocked()
+		return
+	}
+	c.lastSent = time.Now()
+}
+
+func (c *conn) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeLocked()
+}
+
+func (c *conn) closeLocked() {
+	if !c.closed {
+		c.closed = true
+		c.net.Close()
+	}
+}
+
+// send delivers messages to the session, they are logged when it fails
+func (c *conn) send(ctx context.Context, messages ...*Message) {
+	err := c.gateway.deliver(ctx, c.id, 0, func(storage.Tx) ([]*Message, error) {
+		return messages, nil
+	})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "Failed to send FIX message", "error", err)
+	}
+}
+
+// logout sends a Logout with the reason and closes the connection
+func (c *conn) logout(text string) {
+	c.send(context.Background(), NewMessage(msgLogout).Set(tagText, text))
+	c.close()
+}
+
+// logon authenticates the session from its first message and answers it,
+// recovering the sequence numbers of its previous connections
+func (c *conn) logon() error {
+	g := c.gateway
+	c.net.SetReadDeadline(time.Now().Add(logonTimeout))
+	msg, err := readMessage(c.reader)
+	if err != nil {
+		return err
+	}
+	c.net.SetReadDeadline(time.Time{})
+
+	if msg.Type() != msgLogon {
+		return fmt.Errorf("expected Logon, got MsgType %s", msg.Type())
+	}
+	id := msg.Get(tagSenderCompID)
+	if id == "" {
+		return errors.New("SenderCompID is missing")
+	}
+	if target := msg.Get(tagTargetCompID); target != g.cfg.CompId {
+		return fmt.Errorf("unknown TargetCompID %q", target)
+	}
+	if msg.Get(tagEncryptMethod) != "0" {
+		return errors.New("EncryptMethod must be 0")
+	}
+	heartbeat, err := msg.Int(tagHeartBtInt)
+	if err != nil || heartbeat <= 0 {
+		return errors.New("HeartBtInt must be a positive number of seconds")
+	}
+	if subtle.ConstantTimeCompare([]byte(msg.Get(tagPassword)), []byte(g.cfg.Password)) != 1 {
+		return fmt.Errorf("invalid password for %s", id)
+	}
+	seq, err := msg.Int(tagMsgSeqNum)
+	if err != nil || seq < 1 {
+		return errors.New("MsgSeqNum is missing")
+	}
+
+	c.id = id
+	c.heartbeat = time.Duration(heartbeat) * time.Second
+	c.logger = c.logger.With("session", id)
+
+	lock := g.lock(id)
+	lock.Lock()
+	defer lock.Unlock()
+	if !g.register(c) {
+		return fmt.Errorf("session %s is already logged on", id)
+	}
+	if err := c.recover(msg, seq); err != nil {
+		g.unregister(c)
+		return err
+	}
+	return nil
+}
+
+// recover loads the session state, resetting it when asked to, and answers
+// the Logon. The session lock must be held.
+func (c *conn) recover(logon *Message, seq int) error {
+	g := c.gateway
+	ctx := context.Background()
+	reset := logon.Bool(tagResetSeqNumFlag)
+
+	var expected int
+	err := g.store.WithTx(ctx, func(tx storage.Tx) error {
+		session, err := tx.Fix().GetSession(ctx, c.id)
+		if errors.Is(err, storage.ErrNotFound) {
+			// New sessions are reported the executions from now on
+			last, err := tx.Events().Last(ctx)
+			if err != nil {
+				return err
+			}
+			session = storage.FixSession{Id: c.id, NextSenderSeq: 1, NextTargetSeq: 1, LastEvent: last}
+		} else if err != nil {
+			return err
+		}
+		if reset {
+			session.NextSenderSeq, session.NextTargetSeq = 1, 1
+			if err := tx.Fix().DeleteMessages(ctx, c.id); err != nil {
+				return err
+			}
+		}
+		expected = session.NextTargetSeq
+		if seq == expected {
+			session.NextTargetSeq++
+		}
+		return tx.Fix().SaveSession(ctx, session)
+	})
+	if err != nil {
+		return err
+	}
+
+	reply := func(messages ...*Message) error {
+		data, err := g.send(ctx, c.id, 0, func(storage.Tx) ([]*Message, error) { return messages, nil })
+		if err != nil {
+			return err
+		}
+		c.write(data)
+		return nil
+	}
+
+	if seq < expected {
+		reply(NewMessage(msgLogout).Set(tagText, fmt.Sprintf("MsgSeqNum too low, expected %d but received %d", expected, seq)))
+		return fmt.Errorf("logon MsgSeqNum %d lower than the expected %d", seq, expected)
+	}
+
+	answer := NewMessage(msgLogon).Set(tagEncryptMethod, "0").SetInt(tagHeartBtInt, int(c.heartbeat/time.Second))
+	if reset {
+		answer.Set(tagResetSeqNumFlag, "Y")
+	}
+	if err := reply(answer); err != nil {
+		return err
+	}
+	c.expected = expected
+	if seq == expected {
+		c.expected++
+	} else {
+		c.resendTarget = seq
+		return reply(resendRequest(expected))
+	}
+	return nil
+}
+
+func resendRequest(from int) *Message {
+	return NewMessage(msgResendRequest).SetInt(tagBeginSeqNo, from).SetInt(tagEndSeqNo, 0)
+}
+
+// run processes the messages of the session until it logs out or the
+// connection is lost
+func (c *conn) run() error {
+	done := make(chan struct{})
+	defer close(done)
+	go c.monitor(done)
+
+	for {
+		msg, err := readMessage(c.reader)
+		if errors.Is(err, errGarbled) {
+			c.logger.Warn("Ignoring garbled FIX message")
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		c.mu.Lock()
+		c.lastReceived = time.Now()
+		c.testRequested = false
+		c.mu.Unlock()
+
+		if err := c.process(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// monitor sends heartbeats when the session is idle, and a TestRequest then
+// disconnects when the counterparty is silent
+func (c *conn) monitor(done <-chan struct{}) {
+	grace := c.heartbeat / 5
+	c.mu.Lock()
+	c.lastReceived = time.Now()
+	c.mu.Unlock()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		c.mu.Lock()
+		silent := time.Since(c.lastReceived)
+		idle := time.Since(c.lastSent)
+		testRequest := silent > c.heartbeat+grace && !c.testRequested
+		if testRequest {
+			c.testRequested = true
+		}
+		c.mu.Unlock()
+
+		switch {
+		case silent > 2*c.heartbeat+grace:
+			c.logger.Warn("FIX session timed out", "silent", silent)
+			c.close()
+			return
+		case testRequest:
+			c.send(context.Background(), NewMessage(msgTestRequest).Set(tagTestReqID, strconv.FormatInt(time.Now().UnixNano(), 10)))
+		case idle >= c.heartbeat:
+			c.send(context.Background(), NewMessage(msgHeartbeat))
+		}
+	}
+}
+
+// process checks the sequence number of a message before handling it. Gaps
+// are resent by the counterparty, the messages after a gap are dropped until
+// then.
+func (c *conn) process(msg *Message) error {
+	seq, err := msg.Int(tagMsgSeqNum)
+	if err != nil {
+		c.logout("MsgSeqNum is missing")
+		return errLoggedOut
+	}
+
+	// A SequenceReset in reset mode applies whatever its sequence number
+	if msg.Type() == msgSequenceReset && !msg.Bool(tagGapFillFlag) {
+		return c.sequenceReset(msg, false)
+	}
+
+	switch {
+	case seq > c.expected:
+		if c.resendTarget < c.expected {
+			c.send(context.Background(), resendRequest(c.expected))
+		}
+		c.resendTarget = max(c.resendTarget, seq)
+		if msg.Type() == msgLogout {
+			return c.answerLogout()
+		}
+		return nil
+	case seq < c.expected:
+		if msg.Bool(tagPossDupFlag) {
+			return nil
+		}
+		c.logout(fmt.Sprintf("MsgSeqNum too low, expected %d but received %d", c.expected, seq))
+		return errLoggedOut
+	}
+
+	switch msg.Type() {
+	case msgSequenceReset:
+		return c.sequenceReset(msg, true)
+	case msgLogout:
+		return c.answerLogout()
+	}
+
+	c.dispatch(msg, seq)
+	c.expected++
+	return c.gateway.received(context.Background(), c.id, c.expected)
+}
+
+func (c *conn) dispatch(msg *Message, seq int) {
+	switch msg.Type() {
+	case msgHeartbeat, msgReject:
+	case msgTestRequest:
+		c.send(context.Background(), NewMessage(msgHeartbeat).Set(tagTestReqID, msg.Get(tagTestReqID)))
+	case msgResendRequest:
+		c.resend(msg)
+	case msgLogon:
+		c.reject(msg, 0, rejectInvalidMsgType, "Session is already logged on")
+	case msgNewOrderSingle, msgOrderCancelRequest, msgOrderCancelReplaceRequest:
+		c.application(msg, seq)
+	default:
+		c.send(context.Background(), NewMessage(msgBusinessMessageReject).
+			Set(tagRefSeqNum, msg.Get(tagMsgSeqNum)).
+			Set(tagRefMsgType, msg.Type()).
+			SetInt(tagBusinessRejectReason, businessRejectUnsupportedMsgType).
+			Set(tagText, "Unsupported MsgType"))
+	}
+}
+
+// application handles an order entry message with the request id and span
+// of the message, as the REST API does for requests
+func (c *conn) application(msg *Message, seq int) {
+	ctx := logging.WithRequestId(context.Background(), fmt.Sprintf("fix:%s:%d", c.id, seq))
+	ctx, span := tracing.Start(ctx, "fix."+messageNames[msg.Type()], tracing.FixSession.String(c.id))
+	defer tracing.End(span, nil)
+
+	switch msg.Type() {
+	case msgNewOrderSingle:
+		c.newOrderSingle(ctx, msg)
+	case msgOrderCancelRequest:
+		c.orderCancelRequest(ctx, msg)
+	case msgOrderCancelReplaceRequest:
+		c.orderCancelReplaceRequest(ctx, msg)
+	}
+}
+
+var messageNames = map[string]string{
+	msgNewOrderSingle:            "NewOrderSingle",
+	msgOrderCancelRequest:        "OrderCancelRequest",
+	msgOrderCancelReplaceRequest: "OrderCancelReplaceRequest",
+}
+
+// reject refuses a malformed message at the session level, tag is the
+// offending one when known
+func (c *conn) reject(msg *Message, tag, reason int, text string) {
+	reject := NewMessage(msgReject).
+		Set(tagRefSeqNum, msg.Get(tagMsgSeqNum)).
+		Set(tagRefMsgType, msg.Type()).
+		SetInt(tagSessionRejectReason, reason).
+		Set(tagText, text)
+	if tag > 0 {
+		reject.SetInt(tagRefTagID, tag)
+	}
+	c.send(context.Background(), reject)
+}
+
+// sequenceReset moves the expected sequence number forward, gap fills only
+// count as a message when they are in sequence
+func (c *conn) sequenceReset(msg *Message, gapFill bool) error {
+	next, err := msg.Int(tagNewSeqNo)
+	if err != nil || next < c.expected {
+		c.reject(msg, tagNewSeqNo, rejectValueIncorrect, fmt.Sprintf("NewSeqNo must be at least %d", c.expected))
+		if gapFill {
+			c.expected++
+			return c.gateway.received(context.Background(), c.id, c.expected)
+		}
+		return nil
+	}
+	c.expected = next
+	return c.gateway.received(context.Background(), c.id, c.expected)
+}
+
+func (c *conn) answerLogout() error {
+	c.send(context.Background(), NewMessage(msgLogout))
+	c.close()
+	return errLoggedOut
+}
+
+// resend answers a ResendRequest with the stored application messages,
+// flagged as possible duplicates, and gap fills in place of the session ones
+func (c *conn) resend(msg *Message) {
+	g := c.gateway
+	begin, err := msg.Int(tagBeginSeqNo)
+	if err != nil || begin < 1 {
+		c.reject(msg, tagBeginSeqNo, rejectValueIncorrect, "BeginSeqNo must be positive")
+		return
+	}
+	end, err := msg.Int(tagEndSeqNo)
+	if err != nil || end < 0 {
+		c.reject(msg, tagEndSeqNo, rejectValueIncorrect, "EndSeqNo must not be negative")
+		return
+	}
+
+	lock := g.lock(c.id)
+	lock.Lock()
+	defer lock.Unlock()
+
+	ctx := context.Background()
+	var next int
+	stored := map[int]storage.FixMessage{}
+	err = g.store.WithTx(ctx, func(tx storage.Tx) error {
+		session, err := tx.Fix().GetSession(ctx, c.id)
+		if err != nil {
+			return err
+		}
+		next = session.NextSenderSeq
+		messages, err := tx.Fix().ListMessages(ctx, c.id, begin, end)
+		if err != nil {
+			return err
+		}
+		for _, message := range messages {
+			stored[message.Seq] = message
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Error("Failed to load FIX messages to resend", "error", err)
+		return
+	}
+	if end == 0 || end >= next {
+		end = next - 1
+	}
+
+	now := time.Now()
+	var out bytes.Buffer
+	gapFrom := 0
+	fillGap := func(to int) {
+		if gapFrom == 0 {
+			return
+		}
+		out.Write(NewMessage(msgSequenceReset).
+			Set(tagSenderCompID, g.cfg.CompId).
+			Set(tagTargetCompID, c.id).
+			SetInt(tagMsgSeqNum, gapFrom).
+			Set(tagPossDupFlag, "Y").
+			SetTime(tagSendingTime, now).
+			Set(tagGapFillFlag, "Y").
+			SetInt(tagNewSeqNo, to).
+			Bytes())
+		gapFrom = 0
+	}
+	for seq := begin; seq <= end; seq++ {
+		message, ok := stored[seq]
+		var resent *Message
+		if ok && !isAdmin(message.Type) {
+			resent, _ = parseMessage(message.Data)
+		}
+		if resent == nil {
+			if gapFrom == 0 {
+				gapFrom = seq
+			}
+			continue
+		}
+		fillGap(seq)
+		resent.Set(tagPossDupFlag, "Y").
+			Set(tagOrigSendingTime, resent.Get(tagSendingTime)).
+			SetTime(tagSendingTime, now)
+		out.Write(resent.Bytes())
+	}
+	fillGap(end + 1)
+	c.write(out.Bytes())
+}
@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type contextKey struct{}
+
+// WithContext attaches ctx to the request, handlers read it back with Context
+func WithContext(c fiber.Ctx, ctx context.Context) {
+	c.Locals(contextKey{}, ctx)
+}
+
+// Context returns the context storage calls of the request must use, it is
+// canceled when the server stops waiting for in-flight requests on shutdown.
+// Requests served without the shutdown middleware get a background context.
+func Context(c fiber.Ctx) context.Context {
+	if ctx, ok := c.Locals(contextKey{}).(context.Context); ok {
+		return ctx
+	}
+	return context.Background()
+}
+
+// Status returns the status the request is answered with once the handler
+// chain returned err, which the error handler turns into the response
+func Status(c fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	return AsError(err).Status
+}
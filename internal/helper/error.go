@@ -0,0 +1,136 @@
+package helper
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v3"
+)
+
+// Code identifies an error in the problem responses, clients match on it
+// rather than on the message, which may change
+type Code string
+
+const (
+	CodeInvalidRequest   Code = "INVALID_REQUEST"
+	CodeInvalidId        Code = "INVALID_ID"
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+	CodeInvalidSignature Code = "INVALID_SIGNATURE"
+	CodeForbidden        Code = "FORBIDDEN"
+	CodeNotFound         Code = "NOT_FOUND"
+
+	CodeAccountNotFound        Code = "ACCOUNT_NOT_FOUND"
+	CodeAssetNotFound          Code = "ASSET_NOT_FOUND"
+	CodeInstrumentNotFound     Code = "INSTRUMENT_NOT_FOUND"
+	CodeOrderNotFound          Code = "ORDER_NOT_FOUND"
+	CodeReconciliationNotFound Code = "RECONCILIATION_NOT_FOUND"
+	CodeFundingNotFound        Code = "FUNDING_NOT_FOUND"
+	CodeTransferNotFound       Code = "TRANSFER_NOT_FOUND"
+
+	CodeInsufficientFunds     Code = "INSUFFICIENT_FUNDS"
+	CodeRiskCheckFailed       Code = "RISK_CHECK_FAILED"
+	CodeInstrumentNotTrading  Code = "INSTRUMENT_NOT_TRADING"
+	CodeInstrumentHalted      Code = "INSTRUMENT_HALTED"
+	CodeOrderNotCancelable    Code = "ORDER_NOT_CANCELABLE"
+	CodeQuantityBelowFilled   Code = "QUANTITY_BELOW_FILLED"
+	CodeInvalidStatusChange   Code = "INVALID_STATUS_CHANGE"
+	CodeIdempotencyKeyInvalid Code = "IDEMPOTENCY_KEY_INVALID"
+	CodeIdempotencyKeyReused  Code = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyKeyPending Code = "IDEMPOTENCY_KEY_IN_PROGRESS"
+
+	CodeUpgradeRequired Code = "UPGRADE_REQUIRED"
+	CodeRateLimited     Code = "RATE_LIMITED"
+	CodeShuttingDown    Code = "SHUTTING_DOWN"
+	CodeInternal        Code = "INTERNAL_ERROR"
+)
+
+// Codes lists every code, for the OpenAPI document
+var Codes = []Code{
+	CodeInvalidRequest, CodeInvalidId, CodeValidationFailed, CodeInvalidSignature, CodeForbidden, CodeNotFound,
+	CodeAccountNotFound, CodeAssetNotFound, CodeInstrumentNotFound, CodeOrderNotFound, CodeReconciliationNotFound, CodeFundingNotFound, CodeTransferNotFound,
+	CodeInsufficientFunds, CodeRiskCheckFailed, CodeInstrumentNotTrading, CodeInstrumentHalted, CodeOrderNotCancelable,
+	CodeQuantityBelowFilled, CodeInvalidStatusChange, CodeIdempotencyKeyInvalid, CodeIdempotencyKeyReused, CodeIdempotencyKeyPending,
+	CodeUpgradeRequired, CodeRateLimited, CodeShuttingDown, CodeInternal,
+}
+
+// Error is a request a service refuses, whichever transport it came from.
+// Status is the HTTP status the REST API answers with, Details are added to
+// the problem document.
+type Error struct {
+	Status  int
+	Code    Code
+	Message string
+	Details map[string]any
+}
+
+func (e Error) Error() string {
+	return e.Message
+}
+
+// NotFound is the error of a missing entity, named like "Account", its code
+// is the name followed by _NOT_FOUND
+func NotFound(entity string) Error {
+	return Error{
+		Status:  fiber.StatusNotFound,
+		Code:    Code(strings.ToUpper(entity) + "_NOT_FOUND"),
+		Message: entity + " not found",
+	}
+}
+
+// Invalid is the error of an input failing validation, the failed rules are
+// detailed per field
+func Invalid(err error) Error {
+	invalid := Error{Status: fiber.StatusUnprocessableEntity, Code: CodeValidationFailed, Message: err.Error()}
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := map[string]string{}
+		for _, fieldErr := range validationErrs {
+			fields[fieldErr.Field()] = fieldErr.Tag()
+		}
+		invalid.Details = map[string]any{"fields": fields}
+	}
+	return invalid
+}
+
+// InvalidId is the error of a path or query parameter that is not a uuid
+func InvalidId(name string) Error {
+	return Error{Status: fiber.StatusBadRequest, Code: CodeInvalidId, Message: name + " must be a uuid"}
+}
+
+// InvalidRequest is the error of a request that cannot be read, like a
+// malformed body or query parameter
+func InvalidRequest(message string) Error {
+	return Error{Status: fiber.StatusBadRequest, Code: CodeInvalidRequest, Message: message}
+}
+
+// AsError returns the service error err is, or wraps, Fiber errors get the
+// code of their status and any other error is an internal error
+func AsError(err error) Error {
+	var serviceErr Error
+	if errors.As(err, &serviceErr) {
+		return serviceErr
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return Error{Status: fiberErr.Code, Code: StatusCode(fiberErr.Code), Message: fiberErr.Message}
+	}
+	return Error{Status: fiber.StatusInternalServerError, Code: CodeInternal, Message: "Internal server error"}
+}
+
+// StatusCode is the code of the errors only known by their status, like a
+// 405 from the router: METHOD_NOT_ALLOWED
+func StatusCode(status int) Code {
+	switch status {
+	case fiber.StatusNotFound:
+		return CodeNotFound
+	case fiber.StatusUpgradeRequired:
+		return CodeUpgradeRequired
+	case fiber.StatusTooManyRequests:
+		return CodeRateLimited
+	case fiber.StatusInternalServerError:
+		return CodeInternal
+	}
+	return Code(strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_")))
+}
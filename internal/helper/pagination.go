@@ -0,0 +1,162 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Pagination is a page of a list, NextCursor fetches the page that follows
+// and is null on the last one
+type Pagination[T any] struct {
+	Size       int     `json:"size"`
+	NextCursor *string `json:"next_cursor"`
+	Items      []T     `json:"items"`
+}
+
+// Page sizes used when the size query is missing and the largest allowed
+var (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// ConfigurePagination sets the default and maximum page sizes
+func ConfigurePagination(defaultSize, maxSize int) {
+	defaultPageSize = defaultSize
+	maxPageSize = maxSize
+}
+
+// cursor is what the opaque cursors hold: the sort of the list and the key
+// of the last item of the previous page
+type cursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	Id    string `json:"i"`
+}
+
+// GetPage reads the page of a list from the size, sort and cursor query
+// parameters, see NewPage
+func GetPage(c fiber.Ctx, sorts ...string) (storage.Page, error) {
+	size := 0
+	if value := c.Query("size"); value != "" {
+		var err error
+		if size, err = strconv.Atoi(value); err != nil {
+			return storage.Page{}, InvalidRequest("size must be an integer")
+		}
+	}
+	return NewPage(size, c.Query("sort"), c.Query("cursor"), sorts...)
+}
+
+// NewPage returns the page of size items following the cursor, the first
+// page when it is empty. A size below 1 is the default page size and sizes
+// are capped at the maximum page size. sort is one of the sorts of the list,
+// the first being the default: a sort field, prefixed with "-" to sort
+// descending. A cursor only fetches pages of the sort it was issued for.
+func NewPage(size int, sort, cursor string, sorts ...string) (storage.Page, error) {
+	if size < 1 {
+		size = defaultPageSize
+	} else if size > maxPageSize {
+		size = maxPageSize
+	}
+	page := storage.Page{Limit: size}
+
+	if cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return storage.Page{}, InvalidRequest("cursor is invalid")
+		}
+		if sort == "" {
+			sort = after.Sort
+		} else if sort != after.Sort {
+			return storage.Page{}, InvalidRequest("cursor was issued for another sort")
+		}
+		page.After = &storage.Key{Value: after.Value, Id: after.Id}
+	}
+
+	if sort == "" {
+		sort = sorts[0]
+	}
+	if !slices.Contains(sorts, sort) {
+		return storage.Page{}, InvalidRequest("sort must be one of " + strings.Join(sorts, ", "))
+	}
+	page.Sort = storage.Sort{Field: strings.TrimPrefix(sort, "-"), Desc: strings.HasPrefix(sort, "-")}
+	if page.After != nil && !validKey(page.Sort.Field, *page.After) {
+		return storage.Page{}, InvalidRequest("cursor is invalid")
+	}
+	return page, nil
+}
+
+// Paginate lists the page with one item more than its size, to know if a
+// page follows, and shows its items
+func Paginate[I storage.Keyed, T any](page storage.Page, list func(storage.Page) ([]I, error), show func(I) (T, error)) (Pagination[T], error) {
+	pagination := Pagination[T]{Size: page.Limit, Items: []T{}}
+
+	next := page
+	next.Limit++
+	items, err := list(next)
+	if err != nil {
+		return pagination, err
+	}
+	if len(items) > page.Limit {
+		items = items[:page.Limit]
+		cursor := encodeCursor(page.Sort, items[len(items)-1].Key(page.Sort.Field))
+		pagination.NextCursor = &cursor
+	}
+
+	for _, item := range items {
+		shown, err := show(item)
+		if err != nil {
+			return pagination, err
+		}
+		pagination.Items = append(pagination.Items, shown)
+	}
+	return pagination, nil
+}
+
+func encodeCursor(sort storage.Sort, key storage.Key) string {
+	value := cursor{Sort: sort.Field, Value: key.Value, Id: key.Id}
+	if sort.Desc {
+		value.Sort = "-" + sort.Field
+	}
+	data, _ := json.Marshal(value)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (cursor, error) {
+	var decoded cursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return decoded, err
+	}
+	err = json.Unmarshal(data, &decoded)
+	return decoded, err
+}
+
+// validKey checks a key read from a cursor, which clients could have made
+// up, holds values of the types of the sort field and of the ids
+func validKey(field string, key storage.Key) bool {
+	if _, err := uuid.Parse(key.Id); err != nil {
+		if _, err := strconv.ParseInt(key.Id, 10, 64); err != nil {
+			return false
+		}
+	}
+	switch field {
+	case storage.SortByCreatedAt:
+		_, err := time.Parse(time.RFC3339Nano, key.Value)
+		return err == nil
+	case storage.SortByPrice:
+		_, err := decimal.NewFromString(key.Value)
+		return err == nil
+	case storage.SortById:
+		return key.Value == key.Id
+	}
+	return true
+}
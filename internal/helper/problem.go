@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"maps"
+	"net/http"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// MIMEApplicationProblemJSON is the content type of the error responses
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ProblemSchema is the RFC 7807 document every error is answered with. Code
+// is stable, Detail is meant for humans. The details of an error, like the
+// failed risk checks, are extra members.
+type ProblemSchema struct {
+	Type      string `json:"type" validate:"required"`
+	Title     string `json:"title" validate:"required"`
+	Status    int    `json:"status" validate:"required"`
+	Detail    string `json:"detail" validate:"required"`
+	Instance  string `json:"instance" validate:"required"`
+	Code      Code   `json:"code" validate:"required"`
+	RequestId string `json:"request_id,omitempty"`
+}
+
+// ErrorHandler is the Fiber error handler, it answers the errors returned by
+// handlers and middlewares with a problem document. The cause of internal
+// errors is logged by the logging middleware, never sent.
+func ErrorHandler(c fiber.Ctx, err error) error {
+	serviceErr := AsError(err)
+	problem := map[string]any{}
+	maps.Copy(problem, serviceErr.Details)
+	maps.Copy(problem, map[string]any{
+		"type":     "about:blank",
+		"title":    http.StatusText(serviceErr.Status),
+		"status":   serviceErr.Status,
+		"detail":   serviceErr.Message,
+		"instance": c.Path(),
+		"code":     serviceErr.Code,
+	})
+	// The logging middleware echoes the request id before the handler runs
+	if id := c.GetRespHeader(fiber.HeaderXRequestID); id != "" {
+		problem["request_id"] = id
+	}
+	return c.Status(serviceErr.Status).JSON(problem, MIMEApplicationProblemJSON)
+}
@@ -0,0 +1,110 @@
+// Package idempotency replays the response of a request retried with the
+// same Idempotency-Key, so a client retrying after a timeout does not place
+// an order twice. Keys are kept in memory, per instance, until they expire.
+package idempotency
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/gofiber/fiber/v3"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+	maxKeyLength   = 255
+)
+
+// response is what is replayed for a key, pending until the first request
+// answers
+type response struct {
+	fingerprint [sha256.Size]byte
+	pending     bool
+	status      int
+	contentType string
+	body        []byte
+	expires     time.Time
+}
+
+type Keys struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	responses map[string]*response
+	swept     time.Time
+}
+
+// New returns keys remembered for ttl after their first response
+func New(ttl time.Duration) *Keys {
+	return &Keys{ttl: ttl, responses: map[string]*response{}}
+}
+
+// Middleware replays the response of a POST with a known Idempotency-Key.
+// Keys are scoped to the route and must come with the same body. Errors
+// and server failures are not remembered, the request may be retried.
+func (k *Keys) Middleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		key := c.Get(Header)
+		if c.Method() != fiber.MethodPost || key == "" {
+			return c.Next()
+		}
+		if len(key) > maxKeyLength {
+			return helper.Error{Status: fiber.StatusBadRequest, Code: helper.CodeIdempotencyKeyInvalid, Message: "Idempotency key is longer than 255 characters"}
+		}
+		key = c.Path() + " " + key
+		fingerprint := sha256.Sum256(c.Body())
+
+		now := time.Now()
+		k.mu.Lock()
+		k.sweep(now)
+		if previous, ok := k.responses[key]; ok {
+			k.mu.Unlock()
+			switch {
+			case previous.fingerprint != fingerprint:
+				return helper.Error{Status: fiber.StatusUnprocessableEntity, Code: helper.CodeIdempotencyKeyReused, Message: "Idempotency key was used with another request"}
+			case previous.pending:
+				return helper.Error{Status: fiber.StatusConflict, Code: helper.CodeIdempotencyKeyPending, Message: "A request with this idempotency key is in progress"}
+			}
+			c.Set(ReplayedHeader, "true")
+			if previous.contentType != "" {
+				c.Set(fiber.HeaderContentType, previous.contentType)
+			}
+			return c.Status(previous.status).Send(previous.body)
+		}
+		current := &response{fingerprint: fingerprint, pending: true}
+		k.responses[key] = current
+		k.mu.Unlock()
+
+		err := c.Next()
+
+		status := helper.Status(c, err)
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		if err != nil || status >= fiber.StatusInternalServerError || status == fiber.StatusTooManyRequests {
+			delete(k.responses, key)
+			return err
+		}
+		current.pending = false
+		current.status = status
+		current.contentType = string(c.Response().Header.ContentType())
+		current.body = append([]byte(nil), c.Response().Body()...)
+		current.expires = time.Now().Add(k.ttl)
+		return nil
+	}
+}
+
+// sweep forgets the expired keys, at most once a minute, k.mu must be held
+func (k *Keys) sweep(now time.Time) {
+	if now.Sub(k.swept) < time.Minute {
+		return
+	}
+	k.swept = now
+	for key, response := range k.responses {
+		if !response.pending && now.After(response.expires) {
+			delete(k.responses, key)
+		}
+	}
+}
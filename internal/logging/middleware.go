@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+const requestIdHeader = "X-Request-ID"
+
+// Middleware gives every request a correlation id, the X-Request-ID header
+// when sent or a new one, echoed on the response and carried by the request
+// context down to the storage calls, and logs the request once answered
+func Middleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		start := time.Now()
+
+		id := c.Get(requestIdHeader)
+		if id == "" || len(id) > 128 {
+			id = uuid.NewString()
+		}
+		c.Set(requestIdHeader, id)
+		ctx := WithRequestId(helper.Context(c), id)
+		helper.WithContext(c, ctx)
+
+		err := c.Next()
+
+		status := helper.Status(c, err)
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []slog.Attr{
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.String("route", c.Route().Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("ip", c.IP()),
+		}
+		if err != nil && status >= fiber.StatusInternalServerError {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		slog.LogAttrs(ctx, level, "Request", attrs...)
+		return err
+	}
+}
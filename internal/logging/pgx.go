@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/tracelog"
+)
+
+// QueryTracer logs every database query with its duration through the
+// default logger, with the request id of the query context
+func QueryTracer() *tracelog.TraceLog {
+	return &tracelog.TraceLog{Logger: pgxLogger{}, LogLevel: tracelog.LogLevelInfo}
+}
+
+type pgxLogger struct{}
+
+func (pgxLogger) Log(ctx context.Context, level tracelog.LogLevel, msg string, data map[string]any) {
+	attrs := make([]slog.Attr, 0, len(data))
+	for key, value := range data {
+		attrs = append(attrs, slog.Any(key, value))
+	}
+	slog.LogAttrs(ctx, slogLevel(level), "Database: "+msg, attrs...)
+}
+
+func slogLevel(level tracelog.LogLevel) slog.Level {
+	switch level {
+	case tracelog.LogLevelTrace, tracelog.LogLevelDebug:
+		return slog.LevelDebug
+	case tracelog.LogLevelInfo:
+		return slog.LevelInfo
+	case tracelog.LogLevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
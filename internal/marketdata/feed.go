@@ -0,0 +1,257 @@
+package marketdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/eventlog"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	pollInterval = 100 * time.Millisecond
+	pollBatch    = 500
+	// tradeBuffer is how many trades a subscriber may lag behind before it
+	// is dropped
+	tradeBuffer = 1024
+)
+
+var subscribersDropped = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "clob_marketdata_subscribers_dropped_total",
+	Help: "Market data subscribers dropped for falling behind",
+})
+
+// ErrSlowSubscriber ends a subscription that fell behind the feed
+var ErrSlowSubscriber = errors.New("subscriber fell behind the market data feed")
+
+// ErrClosed ends the subscriptions when the feed stops
+var ErrClosed = errors.New("market data feed stopped")
+
+// Trade is an execution reported on the event log
+type Trade struct {
+	Sequence int64
+	eventlog.OrderMatchedPayload
+	ExecutedAt time.Time
+}
+
+// Update is sent to the subscribers of an instrument, Trade is set on trade
+// subscriptions while book subscriptions only learn the book changed
+type Update struct {
+	Sequence int64
+	Trade    *Trade
+}
+
+// Subscription receives the updates of an instrument on C until it is closed,
+// the feed stops or the subscriber falls behind, C is then closed and Err
+// tells why
+type Subscription struct {
+	C            <-chan Update
+	updates      chan Update
+	instrumentId uuid.UUID
+	trades       bool
+	feed         *Feed
+	err          error
+}
+
+// Err returns why C was closed, nil when closed by the subscriber
+func (s *Subscription) Err() error {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	return s.err
+}
+
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	s.feed.remove(s, nil)
+}
+
+// Feed tails the event log once and fans trades and book changes out to the
+// subscribers of each instrument. Subscribers only get what happens after
+// they subscribed, book subscribers read the book themselves on each update.
+type Feed struct {
+	store  storage.Store
+	cursor int64
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[*Subscription]struct{}
+	stopped     bool
+}
+
+// New returns a feed starting after the last event of the log
+func New(ctx context.Context, store storage.Store) (*Feed, error) {
+	f := &Feed{store: store, subscribers: map[uuid.UUID]map[*Subscription]struct{}{}}
+	err := store.WithTx(ctx, func(tx storage.Tx) error {
+		var err error
+		f.cursor, err = tx.Events().Last(ctx)
+		return err
+	})
+	return f, err
+}
+
+// Run tails the event log until ctx is done, closing every subscription then
+func (f *Feed) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := f.poll(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("Failed to tail the event log for market data", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			f.stop()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *Feed) SubscribeTrades(instrumentId uuid.UUID) *Subscription {
+	return f.subscribe(instrumentId, true, tradeBuffer)
+}
+
+// SubscribeBook notifies every change of the book of the instrument, changes
+// are coalesced so a subscriber reading the book never falls behind
+func (f *Feed) SubscribeBook(instrumentId uuid.UUID) *Subscription {
+	return f.subscribe(instrumentId, false, 1)
+}
+
+func (f *Feed) subscribe(instrumentId uuid.UUID, trades bool, buffer int) *Subscription {
+	updates := make(chan Update, buffer)
+	s := &Subscription{C: updates, updates: updates, instrumentId: instrumentId, trades: trades, feed: f}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.stopped {
+		s.err = ErrClosed
+		close(updates)
+		return s
+	}
+	if f.subscribers[instrumentId] == nil {
+		f.subscribers[instrumentId] = map[*Subscription]struct{}{}
+	}
+	f.subscribers[instrumentId][s] = struct{}{}
+	return s
+}
+
+// remove closes the subscription with err, f.mu must be held
+func (f *Feed) remove(s *Subscription, err error) {
+	subscribers := f.subscribers[s.instrumentId]
+	if _, ok := subscribers[s]; !ok {
+		return
+	}
+	delete(subscribers, s)
+	if len(subscribers) == 0 {
+		delete(f.subscribers, s.instrumentId)
+	}
+	s.err = err
+	close(s.updates)
+}
+
+func (f *Feed) stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopped = true
+	for _, subscribers := range f.subscribers {
+		for s := range subscribers {
+			f.remove(s, ErrClosed)
+		}
+	}
+}
+
+// poll publishes the events after the cursor
+func (f *Feed) poll(ctx context.Context) error {
+	for {
+		events, err := eventlog.ListEvents(ctx, f.store, f.cursor, pollBatch)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+		for _, event := range events {
+			if err := f.publish(ctx, event); err != nil {
+				return err
+			}
+			f.cursor = event.Sequence
+		}
+	}
+}
+
+// publish sends the updates of an event to the subscribers of its instrument
+func (f *Feed) publish(ctx context.Context, event eventlog.Event) error {
+	update := Update{Sequence: event.Sequence}
+	var instrumentId uuid.UUID
+	switch event.Type {
+	case eventlog.OrderAccepted:
+		var payload eventlog.OrderAcceptedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		instrumentId = payload.InstrumentId
+	case eventlog.OrderMatched:
+		var payload eventlog.OrderMatchedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		instrumentId = payload.InstrumentId
+		update.Trade = &Trade{Sequence: event.Sequence, OrderMatchedPayload: payload, ExecutedAt: event.CreatedAt}
+	case eventlog.OrderCanceled:
+		if f.Subscribers() == 0 {
+			return nil
+		}
+		var payload eventlog.OrderCanceledPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		// The event does not carry the instrument of the order
+		err := f.store.WithTx(ctx, func(tx storage.Tx) error {
+			order, err := tx.Orders().Get(ctx, payload.OrderId)
+			instrumentId = order.InstrumentId
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for s := range f.subscribers[instrumentId] {
+		if s.trades {
+			if update.Trade == nil {
+				continue
+			}
+			select {
+			case s.updates <- update:
+			default:
+				subscribersDropped.Inc()
+				f.remove(s, ErrSlowSubscriber)
+			}
+			continue
+		}
+		// A pending update already tells the book changed
+		select {
+		case s.updates <- Update{Sequence: update.Sequence}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribers returns how many subscriptions are open
+func (f *Feed) Subscribers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	subscribers := 0
+	for _, instrumentSubscribers := range f.subscribers {
+		subscribers += len(instrumentSubscribers)
+	}
+	return subscribers
+}
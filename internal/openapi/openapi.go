@@ -0,0 +1,278 @@
+// Package openapi builds the OpenAPI 3 document of the REST API from the
+// operations each API package declares next to its routes. Request and
+// response schemas are reflected from the Go structs, so they follow the
+// json and validate tags the handlers use.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Operation documents a route. Path uses the router syntax, path parameters
+// (":id") are ids.
+type Operation struct {
+	Method  string
+	Path    string
+	Tag     string
+	Summary string
+	// Admin operations require the X-Admin-Token header
+	Admin bool
+	Query []Parameter
+	// Body is a value of the request body, nil when there is none
+	Body any
+	// Status is the success status, 200 when not set
+	Status int
+	// Response is a value of the success response, nil for no content
+	Response any
+	// Errors are the error statuses the operation answers with
+	Errors []int
+}
+
+// Parameter is a query parameter, Type is a value of its type. Enum lists
+// the values of string parameters taking only some.
+type Parameter struct {
+	Name        string
+	Description string
+	Type        any
+	Enum        []string
+}
+
+// PageParameters are the query parameters of lists paginated with cursors,
+// sorted by one of sorts, the first being the default
+func PageParameters(sorts ...string) []Parameter {
+	return []Parameter{
+		{Name: "size", Description: "Page size, capped at the maximum page size", Type: 0},
+		{Name: "cursor", Description: "The next_cursor of the previous page", Type: ""},
+		{Name: "sort", Description: "Sort field, prefixed with - to sort descending, " + sorts[0] + " by default", Type: "", Enum: sorts},
+	}
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components components                      `json:"components"`
+}
+
+type components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]securityScheme `json:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in"`
+	Name string `json:"name"`
+}
+
+type operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary"`
+	OperationId string                `json:"operationId"`
+	Parameters  []parameter           `json:"parameters,omitempty"`
+	RequestBody *requestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is the subset of the OpenAPI 3.0 schema object the reflected types
+// need
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+const adminSecurity = "adminToken"
+
+var pathParameter = regexp.MustCompile(`:(\w+)`)
+
+// Generator reflects the schemas of the operations, named structs become
+// components shared by every operation using them
+type Generator struct {
+	enums     map[reflect.Type][]any
+	schemas   map[string]*Schema
+	types     map[string]reflect.Type
+	errorBody *Schema
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		enums:   map[reflect.Type][]any{},
+		schemas: map[string]*Schema{},
+		types:   map[string]reflect.Type{},
+	}
+}
+
+// Enum lists the values of a string type, the type is taken from the values
+func Enum[T ~string](g *Generator, values ...T) {
+	enum := make([]any, 0, len(values))
+	for _, value := range values {
+		enum = append(enum, string(value))
+	}
+	g.enums[reflect.TypeFor[T]()] = enum
+}
+
+// Errors sets the body of the error responses, an RFC 7807 problem document
+// reflected from a value of its type
+func (g *Generator) Errors(value any) {
+	g.errorBody = g.schema(reflect.TypeOf(value))
+}
+
+// Generate returns the document of the operations, it panics when two
+// operations share a method and path or two types reflect to the same name
+func (g *Generator) Generate(info Info, operations []Operation) Document {
+	doc := Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   map[string]map[string]operation{},
+		Components: components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]securityScheme{
+				adminSecurity: {Type: "apiKey", In: "header", Name: "X-Admin-Token"},
+			},
+		},
+	}
+	for _, op := range operations {
+		path := pathParameter.ReplaceAllString(op.Path, "{$1}")
+		method := strings.ToLower(op.Method)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]operation{}
+		}
+		if _, ok := doc.Paths[path][method]; ok {
+			panic(fmt.Sprintf("openapi: %s %s documented twice", op.Method, op.Path))
+		}
+		doc.Paths[path][method] = g.operation(op)
+	}
+	return doc
+}
+
+func (g *Generator) operation(op Operation) operation {
+	doc := operation{
+		Summary:     op.Summary,
+		OperationId: operationId(op.Method, op.Path),
+		Responses:   map[string]response{},
+	}
+	if op.Tag != "" {
+		doc.Tags = []string{op.Tag}
+	}
+
+	for _, match := range pathParameter.FindAllStringSubmatch(op.Path, -1) {
+		doc.Parameters = append(doc.Parameters, parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string", Format: "uuid"},
+		})
+	}
+	for _, query := range op.Query {
+		schema := g.schema(reflect.TypeOf(query.Type))
+		if len(query.Enum) > 0 {
+			schema = &Schema{Type: "string"}
+			for _, value := range query.Enum {
+				schema.Enum = append(schema.Enum, value)
+			}
+		}
+		doc.Parameters = append(doc.Parameters, parameter{
+			Name:        query.Name,
+			In:          "query",
+			Description: query.Description,
+			Schema:      schema,
+		})
+	}
+
+	if op.Body != nil {
+		doc.RequestBody = &requestBody{
+			Required: true,
+			Content:  jsonContent(g.schema(reflect.TypeOf(op.Body))),
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := response{Description: http.StatusText(status)}
+	if op.Response != nil {
+		success.Content = jsonContent(g.schema(reflect.TypeOf(op.Response)))
+	}
+	doc.Responses[fmt.Sprint(status)] = success
+
+	errors := op.Errors
+	if op.Admin {
+		doc.Security = []map[string][]string{{adminSecurity: {}}}
+		errors = append([]int{http.StatusForbidden}, errors...)
+	}
+	for _, status := range errors {
+		errorResponse := response{Description: http.StatusText(status)}
+		if g.errorBody != nil {
+			errorResponse.Content = map[string]mediaType{"application/problem+json": {Schema: g.errorBody}}
+		}
+		doc.Responses[fmt.Sprint(status)] = errorResponse
+	}
+	return doc
+}
+
+func jsonContent(schema *Schema) map[string]mediaType {
+	return map[string]mediaType{"application/json": {Schema: schema}}
+}
+
+// operationId is the method followed by the path segments in camel case,
+// parameters prefixed by "By": POST /v1/accounts/:id/charge is
+// postV1AccountsByIdCharge
+func operationId(method, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segment = "by_" + name
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '_' || r == '-' }) {
+			id += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return id
+}
+
+func ref(name string) string {
+	return "#/components/schemas/" + name
+}
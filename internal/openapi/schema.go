@@ -0,0 +1,166 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	timeType          = reflect.TypeFor[time.Time]()
+	uuidType          = reflect.TypeFor[uuid.UUID]()
+	decimalType       = reflect.TypeFor[decimal.Decimal]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+// typeArguments matches the type arguments of a generic type name, e.g.
+// "[github.com/JhonesBR/go-clob/internal/api/account.AccountShowSchema]"
+var typeArguments = regexp.MustCompile(`\[(.*)\]$`)
+
+// schema returns the schema of t, a reference for named structs
+func (g *Generator) schema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		return nullable(g.schema(t.Elem()))
+	}
+	if enum, ok := g.enums[t]; ok {
+		return &Schema{Type: "string", Enum: enum}
+	}
+
+	switch t {
+	case rawMessageType:
+		// Any JSON value
+		return &Schema{}
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case decimalType:
+		// Decimals are strings so they keep their precision
+		return &Schema{Type: "string", Format: "decimal"}
+	}
+	if t.Implements(textMarshalerType) && !t.Implements(jsonMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return g.component(t)
+	}
+	panic(fmt.Sprintf("openapi: unsupported type %s", t))
+}
+
+// component registers the schema of a named struct and returns a reference
+// to it
+func (g *Generator) component(t reflect.Type) *Schema {
+	name := componentName(t)
+	if known, ok := g.types[name]; ok {
+		if known != t {
+			panic(fmt.Sprintf("openapi: %s and %s are both named %s", known, t, name))
+		}
+		return &Schema{Ref: ref(name)}
+	}
+
+	// Registered before reflecting the fields so recursive types end
+	g.types[name] = t
+	g.schemas[name] = g.object(t)
+	return &Schema{Ref: ref(name)}
+}
+
+// object returns the schema of the fields of a struct as encoding/json
+// marshals them, fields validated as required are required
+func (g *Generator) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || len(field.Index) > 1 && !promoted(t, field) {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			// Embedded structs are flattened, their fields are visited next
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldType := field.Type
+		required := slices.Contains(strings.Split(field.Tag.Get("validate"), ","), "required")
+		if required && fieldType.Kind() == reflect.Pointer {
+			// Pointers only tell a required field was sent, it is never null
+			fieldType = fieldType.Elem()
+		}
+		schema.Properties[name] = g.schema(fieldType)
+		if required && !slices.Contains(strings.Split(options, ","), "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+// promoted reports if a field of an embedded struct is marshaled, which is
+// when every struct embedding it is embedded without a json name
+func promoted(t reflect.Type, field reflect.StructField) bool {
+	for i := range field.Index[:len(field.Index)-1] {
+		embedded := t.FieldByIndex(field.Index[:i+1])
+		name, _, _ := strings.Cut(embedded.Tag.Get("json"), ",")
+		if !embedded.Anonymous || name != "" || embedded.Type.Kind() != reflect.Struct {
+			return false
+		}
+	}
+	return true
+}
+
+// componentName is the name of the type, generic types are suffixed with the
+// names of their type arguments: Pagination[account.AccountShowSchema] is
+// PaginationAccountShowSchema
+func componentName(t reflect.Type) string {
+	name, _, _ := strings.Cut(t.Name(), "[")
+	if match := typeArguments.FindStringSubmatch(t.Name()); match != nil {
+		for _, argument := range strings.Split(match[1], ",") {
+			argument = argument[strings.LastIndex(argument, ".")+1:]
+			name += strings.TrimLeft(argument, "*[]")
+		}
+	}
+	return name
+}
+
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		// Siblings of $ref are ignored in OpenAPI 3.0
+		return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+	}
+	schema.Nullable = true
+	return schema
+}
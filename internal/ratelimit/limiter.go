@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Budget is the amount of requests allowed for a key inside a window
+type Budget struct {
+	Limit  int
+	Window time.Duration
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Time
+	RetryAfter time.Duration
+}
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// Limiter is a fixed window counter keyed by an arbitrary string (ip, account...)
+type Limiter struct {
+	budget  Budget
+	mu      sync.Mutex
+	windows map[string]*window
+	calls   int
+	now     func() time.Time
+}
+
+func NewLimiter(budget Budget) *Limiter {
+	return &Limiter{
+		budget:  budget,
+		windows: make(map[string]*window),
+		now:     time.Now,
+	}
+}
+
+func (l *Limiter) Allow(key string) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	// Drop expired windows from time to time to keep memory bounded
+	l.calls++
+	if l.calls%1024 == 0 {
+		l.sweep(now)
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.budget.Window {
+		w = &window{start: now}
+		l.windows[key] = w
+	}
+
+	reset := w.start.Add(l.budget.Window)
+	if w.count >= l.budget.Limit {
+		return Result{
+			Allowed:    false,
+			Limit:      l.budget.Limit,
+			Remaining:  0,
+			Reset:      reset,
+			RetryAfter: reset.Sub(now),
+		}
+	}
+
+	w.count++
+	return Result{
+		Allowed:   true,
+		Limit:     l.budget.Limit,
+		Remaining: l.budget.Limit - w.count,
+		Reset:     reset,
+	}
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.budget.Window {
+			delete(l.windows, key)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// clock is a time source moved forward by hand
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newClock() *clock {
+	return &clock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestLimiterWindow(t *testing.T) {
+	c := newClock()
+	l := NewLimiter(Budget{Limit: 2, Window: time.Second})
+	l.now = c.Now
+
+	for i, remaining := range []int{1, 0} {
+		if result := l.Allow("a"); !result.Allowed || result.Remaining != remaining {
+			t.Fatalf("call %d got %+v, want allowed with %d remaining", i, result, remaining)
+		}
+	}
+
+	c.now = c.now.Add(400 * time.Millisecond)
+	result := l.Allow("a")
+	if result.Allowed || result.RetryAfter != 600*time.Millisecond {
+		t.Fatalf("got %+v over the limit, want refused for 600ms", result)
+	}
+	if result := l.Allow("b"); !result.Allowed {
+		t.Error("another key shares the budget")
+	}
+
+	// The window resets a full window after its first call
+	c.now = c.now.Add(600 * time.Millisecond)
+	if result := l.Allow("a"); !result.Allowed || result.Remaining != 1 {
+		t.Errorf("got %+v after the window, want allowed with 1 remaining", result)
+	}
+}
+
+func TestLimiterSweep(t *testing.T) {
+	c := newClock()
+	l := NewLimiter(Budget{Limit: 1, Window: time.Second})
+	l.now = c.Now
+
+	l.Allow("idle")
+	c.now = c.now.Add(time.Second)
+	for l.calls%1024 != 0 {
+		l.Allow("busy")
+	}
+
+	if _, ok := l.windows["idle"]; ok {
+		t.Error("expired window kept after the sweep")
+	}
+	if _, ok := l.windows["busy"]; !ok {
+		t.Error("current window dropped by the sweep")
+	}
+}
@@ -0,0 +1,157 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/gofiber/fiber/v3"
+)
+
+type Category string
+
+const (
+	Orders  Category = "orders"
+	Cancels Category = "cancels"
+	Reads   Category = "reads"
+)
+
+type Config struct {
+	Orders       Budget
+	Cancels      Budget
+	Reads        Budget
+	OrderToTrade *OrderToTradeConfig
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Orders:  Budget{Limit: 20, Window: time.Second},
+		Cancels: Budget{Limit: 20, Window: time.Second},
+		Reads:   Budget{Limit: 50, Window: time.Second},
+	}
+}
+
+// RateLimiter holds one ip and one account limiter for every category
+type RateLimiter struct {
+	ip           map[Category]*Limiter
+	account      map[Category]*Limiter
+	OrderToTrade *OrderToTradeMonitor
+}
+
+func New(config Config) *RateLimiter {
+	budgets := map[Category]Budget{
+		Orders:  config.Orders,
+		Cancels: config.Cancels,
+		Reads:   config.Reads,
+	}
+
+	rl := &RateLimiter{
+		ip:      make(map[Category]*Limiter),
+		account: make(map[Category]*Limiter),
+	}
+	for category, budget := range budgets {
+		rl.ip[category] = NewLimiter(budget)
+		rl.account[category] = NewLimiter(budget)
+	}
+	if config.OrderToTrade != nil {
+		rl.OrderToTrade = NewOrderToTradeMonitor(*config.OrderToTrade)
+	}
+
+	return rl
+}
+
+type verifiedKey struct{}
+
+// Verify marks the request as sent by a client trusted to act for the account
+// it names, one holding the request signing secret. Other requests could name
+// any account, they only get the budget of their ip.
+func Verify(c fiber.Ctx) {
+	c.Locals(verifiedKey{}, true)
+}
+
+func (rl *RateLimiter) Middleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		category := categorize(c)
+		var accountId string
+		if verified, _ := c.Locals(verifiedKey{}).(bool); verified {
+			accountId = accountFromRequest(c)
+		}
+
+		// The most restrictive of ip and account budgets is reported
+		result := rl.ip[category].Allow("ip:" + c.IP())
+		if result.Allowed && accountId != "" {
+			accountResult := rl.account[category].Allow("account:" + accountId)
+			if !accountResult.Allowed || accountResult.Remaining < result.Remaining {
+				result = accountResult
+			}
+		}
+		setHeaders(c, result)
+
+		if !result.Allowed {
+			return tooManyRequests(c, result.RetryAfter, "Rate limit exceeded")
+		}
+
+		// Accounts spamming orders that never fill are throttled on order
+		// entry, the account is the one the order is placed for
+		if category == Orders && rl.OrderToTrade != nil {
+			if throttled, retryAfter := rl.OrderToTrade.Throttled(orderAccount(c)); throttled {
+				return tooManyRequests(c, retryAfter, "Order to trade ratio exceeded")
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+func categorize(c fiber.Ctx) Category {
+	path := strings.TrimSuffix(c.Path(), "/")
+	if c.Method() == fiber.MethodPost {
+		if path == "/v1/order_book" {
+			return Orders
+		}
+		if strings.HasPrefix(path, "/v1/order_book/") && strings.HasSuffix(path, "/cancel") {
+			return Cancels
+		}
+	}
+
+	// Reads and any other request share the same budget
+	return Reads
+}
+
+func accountFromRequest(c fiber.Ctx) string {
+	if accountId := c.Get("X-Account-Id"); accountId != "" {
+		return accountId
+	}
+	if accountId := c.Query("account_id"); accountId != "" {
+		return accountId
+	}
+	return orderAccount(c)
+}
+
+// orderAccount returns the account_id field of the body of POST requests
+func orderAccount(c fiber.Ctx) string {
+	if c.Method() == fiber.MethodPost && len(c.Body()) > 0 {
+		var body struct {
+			AccountId string `json:"account_id"`
+		}
+		if err := json.Unmarshal(c.Body(), &body); err == nil {
+			return body.AccountId
+		}
+	}
+
+	return ""
+}
+
+func setHeaders(c fiber.Ctx, result Result) {
+	c.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Set("X-RateLimit-Reset", strconv.FormatInt(result.Reset.Unix(), 10))
+}
+
+func tooManyRequests(c fiber.Ctx, retryAfter time.Duration, message string) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	return helper.Error{Status: fiber.StatusTooManyRequests, Code: helper.CodeRateLimited, Message: message}
+}
@@ -0,0 +1,122 @@
+package ratelimit_test
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/ratelimit"
+	"github.com/gofiber/fiber/v3"
+)
+
+// newApp serves GET and POST /v1/order_book behind the rate limiter, requests
+// with the X-Signed header pass as signed
+func newApp(rl *ratelimit.RateLimiter) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: helper.ErrorHandler, ProxyHeader: fiber.HeaderXForwardedFor})
+	app.Use(func(c fiber.Ctx) error {
+		if c.Get("X-Signed") != "" {
+			ratelimit.Verify(c)
+		}
+		return c.Next()
+	})
+	app.Use(rl.Middleware())
+	ok := func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+	app.Get("/v1/order_book", ok)
+	app.Post("/v1/order_book", ok)
+	return app
+}
+
+type request struct {
+	ip      string
+	account string
+	signed  bool
+}
+
+// send returns the status and the X-RateLimit-Remaining header of a read
+func send(t *testing.T, app *fiber.App, r request) (int, int) {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/order_book", nil)
+	req.Header.Set(fiber.HeaderXForwardedFor, r.ip)
+	if r.account != "" {
+		req.Header.Set("X-Account-Id", r.account)
+	}
+	if r.signed {
+		req.Header.Set("X-Signed", "1")
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remaining, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	return resp.StatusCode, remaining
+}
+
+// TestAccountTrustedOnlyWhenSigned spends the account budget of a victim from
+// unsigned requests: they must only count against the ip
+func TestAccountTrustedOnlyWhenSigned(t *testing.T) {
+	config := ratelimit.DefaultConfig()
+	config.Reads = ratelimit.Budget{Limit: 3, Window: time.Minute}
+	app := newApp(ratelimit.New(config))
+
+	spoofed := request{ip: "10.0.0.1", account: "victim"}
+	for i, want := range []int{2, 1, 0} {
+		if status, remaining := send(t, app, spoofed); status != fiber.StatusNoContent || remaining != want {
+			t.Fatalf("spoofed request %d got %d with %d remaining, want %d remaining", i, status, remaining, want)
+		}
+	}
+	if status, _ := send(t, app, spoofed); status != fiber.StatusTooManyRequests {
+		t.Fatalf("spoofed request over the ip budget got %d", status)
+	}
+
+	// The victim still has its whole account budget from another ip
+	victim := request{ip: "10.0.0.2", account: "victim", signed: true}
+	if status, remaining := send(t, app, victim); status != fiber.StatusNoContent || remaining != 2 {
+		t.Fatalf("signed request got %d with %d remaining, want 2 remaining", status, remaining)
+	}
+
+	// Signed requests share the account budget across ips
+	for _, ip := range []string{"10.0.0.3", "10.0.0.4"} {
+		send(t, app, request{ip: ip, account: "victim", signed: true})
+	}
+	if status, _ := send(t, app, request{ip: "10.0.0.5", account: "victim", signed: true}); status != fiber.StatusTooManyRequests {
+		t.Errorf("signed request over the account budget got %d", status)
+	}
+	if status, _ := send(t, app, request{ip: "10.0.0.5", account: "victim"}); status != fiber.StatusNoContent {
+		t.Errorf("unsigned request from an ip with budget got %d", status)
+	}
+}
+
+func TestOrderToTradeThrottlesOrderEntry(t *testing.T) {
+	config := ratelimit.DefaultConfig()
+	config.OrderToTrade = &ratelimit.OrderToTradeConfig{Window: time.Minute, MaxRatio: 1, MinOrders: 2, Penalty: time.Minute}
+	rl := ratelimit.New(config)
+	app := newApp(rl)
+	rl.OrderToTrade.RecordOrder("spammer")
+	rl.OrderToTrade.RecordOrder("spammer")
+
+	place := func(accountId string) int {
+		t.Helper()
+		req := httptest.NewRequest(fiber.MethodPost, "/v1/order_book", strings.NewReader(`{"account_id":"`+accountId+`"}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode == fiber.StatusTooManyRequests && resp.Header.Get(fiber.HeaderRetryAfter) != "60" {
+			t.Errorf("got Retry-After %q, want 60", resp.Header.Get(fiber.HeaderRetryAfter))
+		}
+		return resp.StatusCode
+	}
+
+	if status := place("spammer"); status != fiber.StatusTooManyRequests {
+		t.Errorf("order of a throttled account got %d", status)
+	}
+	if status := place("trader"); status != fiber.StatusNoContent {
+		t.Errorf("order of another account got %d", status)
+	}
+}
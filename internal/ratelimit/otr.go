@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// OrderToTradeConfig configures the order-to-trade ratio monitor. Accounts
+// placing at least MinOrders inside Window with a ratio of orders per trade
+// above MaxRatio are throttled for Penalty.
+type OrderToTradeConfig struct {
+	Window    time.Duration
+	MaxRatio  float64
+	MinOrders int
+	Penalty   time.Duration
+}
+
+func DefaultOrderToTradeConfig() OrderToTradeConfig {
+	return OrderToTradeConfig{
+		Window:    time.Minute,
+		MaxRatio:  50,
+		MinOrders: 100,
+		Penalty:   30 * time.Second,
+	}
+}
+
+type accountActivity struct {
+	start          time.Time
+	orders         int
+	trades         int
+	throttledUntil time.Time
+}
+
+type OrderToTradeMonitor struct {
+	config   OrderToTradeConfig
+	mu       sync.Mutex
+	accounts map[string]*accountActivity
+	calls    int
+	now      func() time.Time
+}
+
+func NewOrderToTradeMonitor(config OrderToTradeConfig) *OrderToTradeMonitor {
+	return &OrderToTradeMonitor{
+		config:   config,
+		accounts: make(map[string]*accountActivity),
+		now:      time.Now,
+	}
+}
+
+func (m *OrderToTradeMonitor) RecordOrder(accountId string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	activity := m.activity(accountId, now)
+	activity.orders++
+
+	// Evaluate the ratio only after the account placed enough orders
+	if activity.orders < m.config.MinOrders {
+		return
+	}
+	trades := activity.trades
+	if trades == 0 {
+		trades = 1
+	}
+	if float64(activity.orders)/float64(trades) > m.config.MaxRatio {
+		activity.throttledUntil = now.Add(m.config.Penalty)
+	}
+}
+
+func (m *OrderToTradeMonitor) RecordTrade(accountId string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.activity(accountId, m.now()).trades++
+}
+
+// Throttled reports if the account is being throttled and for how long
+func (m *OrderToTradeMonitor) Throttled(accountId string) (bool, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	activity, ok := m.accounts[accountId]
+	if !ok {
+		return false, 0
+	}
+
+	now := m.now()
+	if now.Before(activity.throttledUntil) {
+		return true, activity.throttledUntil.Sub(now)
+	}
+	return false, 0
+}
+
+func (m *OrderToTradeMonitor) activity(accountId string, now time.Time) *accountActivity {
+	// Drop idle accounts from time to time to keep memory bounded
+	m.calls++
+	if m.calls%1024 == 0 {
+		m.sweep(now)
+	}
+
+	activity, ok := m.accounts[accountId]
+	if !ok {
+		activity = &accountActivity{start: now}
+		m.accounts[accountId] = activity
+	}
+
+	// Start a new window keeping any active throttle
+	if now.Sub(activity.start) >= m.config.Window {
+		activity.start = now
+		activity.orders = 0
+		activity.trades = 0
+	}
+	return activity
+}
+
+// sweep drops the accounts whose window expired and that are not throttled
+func (m *OrderToTradeMonitor) sweep(now time.Time) {
+	for accountId, activity := range m.accounts {
+		if now.Sub(activity.start) >= m.config.Window && !now.Before(activity.throttledUntil) {
+			delete(m.accounts, accountId)
+		}
+	}
+}
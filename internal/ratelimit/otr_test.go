@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestMonitor() (*OrderToTradeMonitor, *clock) {
+	c := newClock()
+	m := NewOrderToTradeMonitor(OrderToTradeConfig{
+		Window:    time.Minute,
+		MaxRatio:  2,
+		MinOrders: 4,
+		Penalty:   5 * time.Minute,
+	})
+	m.now = c.Now
+	return m, c
+}
+
+// untilSweep records trades for the account until the monitor sweeps
+func untilSweep(m *OrderToTradeMonitor, accountId string) {
+	m.RecordTrade(accountId)
+	for m.calls%1024 != 0 {
+		m.RecordTrade(accountId)
+	}
+}
+
+func TestOrderToTradeThrottle(t *testing.T) {
+	m, c := newTestMonitor()
+
+	// Below MinOrders the ratio is not evaluated
+	for range 3 {
+		m.RecordOrder("spammer")
+	}
+	if throttled, _ := m.Throttled("spammer"); throttled {
+		t.Fatal("throttled before placing MinOrders")
+	}
+
+	m.RecordOrder("spammer")
+	if throttled, retryAfter := m.Throttled("spammer"); !throttled || retryAfter != 5*time.Minute {
+		t.Fatalf("got throttled %v for %s, want throttled for 5m", throttled, retryAfter)
+	}
+
+	// Trades keep the ratio under MaxRatio
+	m.RecordTrade("trader")
+	m.RecordTrade("trader")
+	for range 4 {
+		m.RecordOrder("trader")
+	}
+	if throttled, _ := m.Throttled("trader"); throttled {
+		t.Error("throttled with a ratio of 2")
+	}
+
+	// The throttle outlives the window it was set in
+	c.now = c.now.Add(2 * time.Minute)
+	m.RecordOrder("spammer")
+	if throttled, retryAfter := m.Throttled("spammer"); !throttled || retryAfter != 3*time.Minute {
+		t.Errorf("got throttled %v for %s in the next window, want throttled for 3m", throttled, retryAfter)
+	}
+}
+
+func TestOrderToTradeSweep(t *testing.T) {
+	m, c := newTestMonitor()
+
+	m.RecordOrder("idle")
+	for range 4 {
+		m.RecordOrder("throttled")
+	}
+	c.now = c.now.Add(m.config.Window)
+	untilSweep(m, "busy")
+
+	if _, ok := m.accounts["idle"]; ok {
+		t.Error("idle account kept after the sweep")
+	}
+	if _, ok := m.accounts["busy"]; !ok {
+		t.Error("active account dropped by the sweep")
+	}
+	if throttled, _ := m.Throttled("throttled"); !throttled {
+		t.Error("throttle dropped by the sweep")
+	}
+
+	// Once the throttle ends the account is dropped as any idle one
+	c.now = c.now.Add(m.config.Penalty)
+	untilSweep(m, "busy")
+	if _, ok := m.accounts["throttled"]; ok {
+		t.Error("account kept after its throttle ended")
+	}
+}
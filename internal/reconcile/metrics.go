@@ -0,0 +1,42 @@
+package reconcile
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	balanced = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "clob_reconciliation_balanced",
+		Help: "1 when the last reconciliation found no discrepancy, 0 otherwise.",
+	})
+	discrepancies = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "clob_reconciliation_discrepancies",
+		Help: "Account and asset pairs whose holdings did not match on the last reconciliation.",
+	})
+	assetDifference = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "clob_reconciliation_asset_difference",
+		Help: "Balances plus reserved and held funds minus net deposits per asset on the last reconciliation.",
+	}, []string{"asset"})
+	lastRun = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "clob_reconciliation_last_run_timestamp_seconds",
+		Help: "Unix time of the last successful reconciliation.",
+	})
+	runFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "clob_reconciliation_failures_total",
+		Help: "Reconciliations that could not complete.",
+	})
+)
+
+func observe(report Report) {
+	if report.Balanced {
+		balanced.Set(1)
+	} else {
+		balanced.Set(0)
+	}
+	discrepancies.Set(float64(len(report.Discrepancies)))
+	for _, asset := range report.Assets {
+		assetDifference.WithLabelValues(asset.AssetCode).Set(asset.Difference.InexactFloat64())
+	}
+	lastRun.Set(float64(report.RanAt.Unix()))
+}
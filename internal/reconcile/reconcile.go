@@ -0,0 +1,283 @@
+package reconcile
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// AssetReport compares what accounts hold of an asset, free, reserved by
+// working orders or held by withdrawals in flight, with what entered the
+// exchange
+type AssetReport struct {
+	AssetId     uuid.UUID       `json:"asset_id"`
+	AssetCode   string          `json:"asset_code"`
+	Balances    decimal.Decimal `json:"balances"`
+	Reserved    decimal.Decimal `json:"reserved"`
+	Held        decimal.Decimal `json:"held"`
+	NetDeposits decimal.Decimal `json:"net_deposits"`
+	Difference  decimal.Decimal `json:"difference"`
+}
+
+// Discrepancy is an account holding more (positive difference) or less than
+// its movements and trades add up to
+type Discrepancy struct {
+	AccountId  uuid.UUID       `json:"account_id"`
+	AssetId    uuid.UUID       `json:"asset_id"`
+	AssetCode  string          `json:"asset_code"`
+	Expected   decimal.Decimal `json:"expected"`
+	Actual     decimal.Decimal `json:"actual"`
+	Difference decimal.Decimal `json:"difference"`
+}
+
+type Report struct {
+	RanAt         time.Time     `json:"ran_at"`
+	Balanced      bool          `json:"balanced"`
+	Assets        []AssetReport `json:"assets"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+type key struct {
+	accountId uuid.UUID
+	assetId   uuid.UUID
+}
+
+// Run verifies per asset that balances plus funds reserved by working orders
+// and held by withdrawals not paid out yet equal deposits minus withdrawals and fees, and per account that what it
+// holds matches its movements and trades. Appends are blocked while reading
+// so the view is consistent.
+func Run(ctx context.Context, store storage.Store) (Report, error) {
+	report := Report{RanAt: time.Now()}
+
+	actual := map[key]decimal.Decimal{}
+	expected := map[key]decimal.Decimal{}
+	assets := map[uuid.UUID]*AssetReport{}
+
+	err := store.WithTx(ctx, func(tx storage.Tx) error {
+		if err := tx.Events().Lock(ctx); err != nil {
+			return err
+		}
+
+		asset := func(id uuid.UUID) (*AssetReport, error) {
+			if asset, ok := assets[id]; ok {
+				return asset, nil
+			}
+			stored, err := tx.Assets().Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			assets[id] = &AssetReport{AssetId: id, AssetCode: stored.Code}
+			return assets[id], nil
+		}
+
+		// Free balances
+		balances, err := tx.Balances().List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, balance := range balances {
+			assetReport, err := asset(balance.AssetId)
+			if err != nil {
+				return err
+			}
+			assetReport.Balances = assetReport.Balances.Add(balance.Balance)
+			k := key{balance.AccountId, balance.AssetId}
+			actual[k] = actual[k].Add(balance.Balance)
+		}
+
+		// Funds reserved by working orders, quote for buys and base for sells
+		instruments, err := tx.Instruments().List(ctx)
+		if err != nil {
+			return err
+		}
+		instrumentsById := make(map[uuid.UUID]storage.Instrument, len(instruments))
+		for _, instrument := range instruments {
+			instrumentsById[instrument.Id] = instrument
+		}
+		orders, err := tx.Orders().List(ctx, storage.OrderFilter{Statuses: storage.WorkingStatuses}, storage.Page{})
+		if err != nil {
+			return err
+		}
+		for _, order := range orders {
+			instrument := instrumentsById[order.InstrumentId]
+			assetId := instrument.BaseAssetId
+			reserved := order.TotalQuantity.Sub(order.FilledQuantity)
+			if order.Type == storage.Buy {
+				assetId = instrument.QuoteAssetId
+				reserved = reserved.Mul(order.Price)
+			}
+
+			assetReport, err := asset(assetId)
+			if err != nil {
+				return err
+			}
+			assetReport.Reserved = assetReport.Reserved.Add(reserved)
+			k := key{order.AccountId, assetId}
+			actual[k] = actual[k].Add(reserved)
+		}
+
+		// Funds held by withdrawals, they leave the exchange once paid out
+		withdrawals := storage.FundingWithdrawal
+		held, err := tx.Fundings().List(ctx, storage.FundingFilter{Kind: &withdrawals, Statuses: storage.HoldingStatuses}, storage.Page{})
+		if err != nil {
+			return err
+		}
+		for _, withdrawal := range held {
+			assetReport, err := asset(withdrawal.AssetId)
+			if err != nil {
+				return err
+			}
+			assetReport.Held = assetReport.Held.Add(withdrawal.Amount)
+			k := key{withdrawal.AccountId, withdrawal.AssetId}
+			actual[k] = actual[k].Add(withdrawal.Amount)
+		}
+
+		// Deposits minus withdrawals and fees
+		movements, err := tx.Movements().NetTotals(ctx)
+		if err != nil {
+			return err
+		}
+		for _, movement := range movements {
+			assetReport, err := asset(movement.AssetId)
+			if err != nil {
+				return err
+			}
+			assetReport.NetDeposits = assetReport.NetDeposits.Add(movement.Amount)
+			k := key{movement.AccountId, movement.AssetId}
+			expected[k] = expected[k].Add(movement.Amount)
+		}
+
+		// Trades only move assets between accounts
+		flows, err := tx.Trades().NetFlows(ctx)
+		if err != nil {
+			return err
+		}
+		for _, flow := range flows {
+			if _, err := asset(flow.AssetId); err != nil {
+				return err
+			}
+			k := key{flow.AccountId, flow.AssetId}
+			expected[k] = expected[k].Add(flow.Amount)
+		}
+		return nil
+	})
+	if err != nil {
+		return Report{}, err
+	}
+
+	report.Balanced = true
+	report.Assets = make([]AssetReport, 0, len(assets))
+	for _, asset := range assets {
+		asset.Difference = asset.Balances.Add(asset.Reserved).Add(asset.Held).Sub(asset.NetDeposits)
+		if !asset.Difference.IsZero() {
+			report.Balanced = false
+		}
+		report.Assets = append(report.Assets, *asset)
+	}
+	sort.Slice(report.Assets, func(i, j int) bool {
+		return report.Assets[i].AssetCode < report.Assets[j].AssetCode
+	})
+
+	report.Discrepancies = []Discrepancy{}
+	for k := range union(actual, expected) {
+		difference := actual[k].Sub(expected[k])
+		if difference.IsZero() {
+			continue
+		}
+		report.Balanced = false
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			AccountId:  k.accountId,
+			AssetId:    k.assetId,
+			AssetCode:  assets[k.assetId].AssetCode,
+			Expected:   expected[k],
+			Actual:     actual[k],
+			Difference: difference,
+		})
+	}
+	sort.Slice(report.Discrepancies, func(i, j int) bool {
+		a, b := report.Discrepancies[i], report.Discrepancies[j]
+		if a.AccountId != b.AccountId {
+			return a.AccountId.String() < b.AccountId.String()
+		}
+		return a.AssetCode < b.AssetCode
+	})
+
+	return report, nil
+}
+
+func union(a, b map[key]decimal.Decimal) map[key]struct{} {
+	keys := make(map[key]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
+
+// Reconciler keeps the last report and publishes it as metrics
+type Reconciler struct {
+	store storage.Store
+
+	mu   sync.RWMutex
+	last *Report
+}
+
+func New(store storage.Store) *Reconciler {
+	return &Reconciler{store: store}
+}
+
+func (r *Reconciler) Run(ctx context.Context) (Report, error) {
+	report, err := Run(ctx, r.store)
+	if err != nil {
+		runFailures.Inc()
+		return Report{}, err
+	}
+
+	r.mu.Lock()
+	r.last = &report
+	r.mu.Unlock()
+	observe(report)
+
+	for _, discrepancy := range report.Discrepancies {
+		slog.WarnContext(ctx, "Reconciliation discrepancy", "account_id", discrepancy.AccountId, "asset", discrepancy.AssetCode, "expected", discrepancy.Expected, "actual", discrepancy.Actual)
+	}
+	return report, nil
+}
+
+// Last returns the last report, nil when no reconciliation ran yet
+func (r *Reconciler) Last() *Report {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.last
+}
+
+// Start reconciles every interval until the context is done, the returned
+// channel is closed once it stopped
+func (r *Reconciler) Start(ctx context.Context, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := r.Run(ctx); err != nil {
+					slog.ErrorContext(ctx, "Failed to reconcile balances", "error", err)
+				}
+			}
+		}
+	}()
+	return done
+}
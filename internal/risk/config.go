@@ -0,0 +1,85 @@
+package risk
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Limits applied by the checks, a nil value disables the rule
+type Limits struct {
+	MaxOrderQuantity *decimal.Decimal           `json:"max_order_quantity"`
+	MaxNotional      *decimal.Decimal           `json:"max_notional"`
+	PriceBandPercent *decimal.Decimal           `json:"price_band_percent"`
+	MaxOpenOrders    *int                       `json:"max_open_orders"`
+	MaxPosition      map[string]decimal.Decimal `json:"max_position"`
+}
+
+// Config holds global limits overridden per instrument and then per account
+type Config struct {
+	Global      Limits               `json:"global"`
+	Instruments map[uuid.UUID]Limits `json:"instruments"`
+	Accounts    map[uuid.UUID]Limits `json:"accounts"`
+}
+
+// DefaultConfig has no limits, deployments opt into them with a risk config
+// file such as risk.example.json
+func DefaultConfig() Config {
+	return Config{
+		Instruments: map[uuid.UUID]Limits{},
+		Accounts:    map[uuid.UUID]Limits{},
+	}
+}
+
+// LoadConfig reads a JSON risk config, limits missing from the file are disabled
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+func (c Config) LimitsFor(instrumentId, accountId uuid.UUID) Limits {
+	limits := c.Global.merge(Limits{})
+	if instrumentLimits, ok := c.Instruments[instrumentId]; ok {
+		limits = limits.merge(instrumentLimits)
+	}
+	if accountLimits, ok := c.Accounts[accountId]; ok {
+		limits = limits.merge(accountLimits)
+	}
+	return limits
+}
+
+// merge returns a copy of the limits with the values set on override
+func (l Limits) merge(override Limits) Limits {
+	merged := l
+	if override.MaxOrderQuantity != nil {
+		merged.MaxOrderQuantity = override.MaxOrderQuantity
+	}
+	if override.MaxNotional != nil {
+		merged.MaxNotional = override.MaxNotional
+	}
+	if override.PriceBandPercent != nil {
+		merged.PriceBandPercent = override.PriceBandPercent
+	}
+	if override.MaxOpenOrders != nil {
+		merged.MaxOpenOrders = override.MaxOpenOrders
+	}
+
+	merged.MaxPosition = make(map[string]decimal.Decimal, len(l.MaxPosition)+len(override.MaxPosition))
+	for asset, max := range l.MaxPosition {
+		merged.MaxPosition[asset] = max
+	}
+	for asset, max := range override.MaxPosition {
+		merged.MaxPosition[asset] = max
+	}
+	return merged
+}
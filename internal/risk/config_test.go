@@ -0,0 +1,73 @@
+package risk_test
+
+import (
+	"testing"
+
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestLoadExampleConfig(t *testing.T) {
+	config, err := risk.LoadConfig("../../risk.example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limits := config.LimitsFor(uuid.New(), uuid.New())
+	if limits.PriceBandPercent == nil || !limits.PriceBandPercent.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("got price band %v, want 10", limits.PriceBandPercent)
+	}
+	if limits.MaxOpenOrders == nil || *limits.MaxOpenOrders != 500 {
+		t.Errorf("got max open orders %v, want 500", limits.MaxOpenOrders)
+	}
+
+	// Limits missing from the file are disabled
+	if limits.MaxOrderQuantity != nil || limits.MaxNotional != nil || len(limits.MaxPosition) > 0 {
+		t.Errorf("got limits %+v, want only the price band and max open orders", limits)
+	}
+}
+
+func TestLimitsOverrides(t *testing.T) {
+	instrumentId, accountId := uuid.New(), uuid.New()
+	config := risk.Config{
+		Global: risk.Limits{
+			MaxOrderQuantity: dec("10"),
+			MaxNotional:      dec("1000"),
+			MaxPosition:      map[string]decimal.Decimal{"BTC": decimal.NewFromInt(10)},
+		},
+		Instruments: map[uuid.UUID]risk.Limits{instrumentId: {MaxOrderQuantity: dec("5")}},
+		Accounts: map[uuid.UUID]risk.Limits{accountId: {
+			MaxOrderQuantity: dec("20"),
+			MaxPosition:      map[string]decimal.Decimal{"ETH": decimal.NewFromInt(1)},
+		}},
+	}
+
+	tests := []struct {
+		name         string
+		instrumentId uuid.UUID
+		accountId    uuid.UUID
+		quantity     string
+	}{
+		{name: "global", instrumentId: uuid.New(), accountId: uuid.New(), quantity: "10"},
+		{name: "instrument", instrumentId: instrumentId, accountId: uuid.New(), quantity: "5"},
+		{name: "account over instrument", instrumentId: instrumentId, accountId: accountId, quantity: "20"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limits := config.LimitsFor(test.instrumentId, test.accountId)
+			if !limits.MaxOrderQuantity.Equal(decimal.RequireFromString(test.quantity)) {
+				t.Errorf("got max order quantity %s, want %s", limits.MaxOrderQuantity, test.quantity)
+			}
+			if !limits.MaxNotional.Equal(decimal.NewFromInt(1000)) {
+				t.Errorf("got max notional %s, want the global 1000", limits.MaxNotional)
+			}
+		})
+	}
+
+	// Positions are merged per asset
+	limits := config.LimitsFor(instrumentId, accountId)
+	if len(limits.MaxPosition) != 2 {
+		t.Errorf("got max positions %v, want BTC and ETH", limits.MaxPosition)
+	}
+}
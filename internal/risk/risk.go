@@ -0,0 +1,90 @@
+package risk
+
+import (
+	"context"
+
+	"github.com/JhonesBR/go-clob/internal/tracing"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Order is the information about a new order needed by the pre-trade checks
+type Order struct {
+	AccountId     uuid.UUID
+	InstrumentId  uuid.UUID
+	BaseAssetId   uuid.UUID
+	BaseAssetCode string
+	Side          string
+	Price         decimal.Decimal
+	Quantity      decimal.Decimal
+}
+
+func (o Order) Notional() decimal.Decimal {
+	return o.Price.Mul(o.Quantity)
+}
+
+// State gives the checks access to the market and account state
+type State interface {
+	LastTradePrice(ctx context.Context, instrumentId uuid.UUID) (*decimal.Decimal, error)
+	MidPrice(ctx context.Context, instrumentId uuid.UUID) (*decimal.Decimal, error)
+	OpenOrders(ctx context.Context, accountId uuid.UUID) (int, error)
+	Position(ctx context.Context, accountId, assetId uuid.UUID) (decimal.Decimal, error)
+}
+
+// Rejection is the structured reason of an order refused by a check
+type Rejection struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Limit   string `json:"limit"`
+	Value   string `json:"value"`
+}
+
+// RiskCheck is a pre-trade rule evaluated before an order enters matching.
+// A nil rejection means the order passed the rule.
+type RiskCheck interface {
+	Name() string
+	Check(ctx context.Context, order Order, limits Limits, state State) (*Rejection, error)
+}
+
+type Engine struct {
+	config Config
+	checks []RiskCheck
+}
+
+func NewEngine(config Config, checks ...RiskCheck) *Engine {
+	if len(checks) == 0 {
+		checks = DefaultChecks()
+	}
+	return &Engine{config: config, checks: checks}
+}
+
+func DefaultChecks() []RiskCheck {
+	return []RiskCheck{
+		MaxOrderSize{},
+		MaxNotional{},
+		PriceBand{},
+		MaxOpenOrders{},
+		MaxPosition{},
+	}
+}
+
+// Evaluate runs every check and returns all rejections found
+func (e *Engine) Evaluate(ctx context.Context, order Order, state State) (rejections []Rejection, err error) {
+	ctx, span := tracing.Start(ctx, "risk.Evaluate")
+	defer func() { tracing.End(span, err) }()
+
+	limits := e.config.LimitsFor(order.InstrumentId, order.AccountId)
+
+	rejections = []Rejection{}
+	for _, check := range e.checks {
+		rejection, err := check.Check(ctx, order, limits, state)
+		if err != nil {
+			return nil, err
+		}
+		if rejection != nil {
+			rejections = append(rejections, *rejection)
+		}
+	}
+
+	return rejections, nil
+}
@@ -0,0 +1,129 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+type MaxOrderSize struct{}
+
+func (MaxOrderSize) Name() string { return "max_order_size" }
+
+func (r MaxOrderSize) Check(ctx context.Context, order Order, limits Limits, state State) (*Rejection, error) {
+	if limits.MaxOrderQuantity == nil || order.Quantity.LessThanOrEqual(*limits.MaxOrderQuantity) {
+		return nil, nil
+	}
+	return &Rejection{
+		Rule:    r.Name(),
+		Message: "order quantity is above the maximum allowed",
+		Limit:   limits.MaxOrderQuantity.String(),
+		Value:   order.Quantity.String(),
+	}, nil
+}
+
+type MaxNotional struct{}
+
+func (MaxNotional) Name() string { return "max_notional" }
+
+func (r MaxNotional) Check(ctx context.Context, order Order, limits Limits, state State) (*Rejection, error) {
+	if limits.MaxNotional == nil || order.Notional().LessThanOrEqual(*limits.MaxNotional) {
+		return nil, nil
+	}
+	return &Rejection{
+		Rule:    r.Name(),
+		Message: "order notional is above the maximum allowed",
+		Limit:   limits.MaxNotional.String(),
+		Value:   order.Notional().String(),
+	}, nil
+}
+
+// PriceBand rejects orders priced too far from the last trade (or the mid
+// price when the instrument has not traded yet), protecting from fat fingers
+type PriceBand struct{}
+
+func (PriceBand) Name() string { return "price_band" }
+
+func (r PriceBand) Check(ctx context.Context, order Order, limits Limits, state State) (*Rejection, error) {
+	if limits.PriceBandPercent == nil {
+		return nil, nil
+	}
+
+	reference, err := state.LastTradePrice(ctx, order.InstrumentId)
+	if err != nil {
+		return nil, err
+	}
+	if reference == nil {
+		reference, err = state.MidPrice(ctx, order.InstrumentId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if reference == nil || reference.IsZero() {
+		return nil, nil
+	}
+
+	deviation := order.Price.Sub(*reference).Abs().Div(*reference).Mul(decimal.NewFromInt(100))
+	if deviation.LessThanOrEqual(*limits.PriceBandPercent) {
+		return nil, nil
+	}
+	return &Rejection{
+		Rule:    r.Name(),
+		Message: fmt.Sprintf("order price deviates more than %s%% from reference price %s", limits.PriceBandPercent, reference),
+		Limit:   limits.PriceBandPercent.String(),
+		Value:   deviation.StringFixed(2),
+	}, nil
+}
+
+type MaxOpenOrders struct{}
+
+func (MaxOpenOrders) Name() string { return "max_open_orders" }
+
+func (r MaxOpenOrders) Check(ctx context.Context, order Order, limits Limits, state State) (*Rejection, error) {
+	if limits.MaxOpenOrders == nil {
+		return nil, nil
+	}
+
+	openOrders, err := state.OpenOrders(ctx, order.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if openOrders < *limits.MaxOpenOrders {
+		return nil, nil
+	}
+	return &Rejection{
+		Rule:    r.Name(),
+		Message: "account reached the maximum of open orders",
+		Limit:   strconv.Itoa(*limits.MaxOpenOrders),
+		Value:   strconv.Itoa(openOrders),
+	}, nil
+}
+
+// MaxPosition limits the base asset an account may hold once a buy is filled
+type MaxPosition struct{}
+
+func (MaxPosition) Name() string { return "max_position" }
+
+func (r MaxPosition) Check(ctx context.Context, order Order, limits Limits, state State) (*Rejection, error) {
+	maxPosition, ok := limits.MaxPosition[order.BaseAssetCode]
+	if !ok || order.Side != "buy" {
+		return nil, nil
+	}
+
+	position, err := state.Position(ctx, order.AccountId, order.BaseAssetId)
+	if err != nil {
+		return nil, err
+	}
+	projected := position.Add(order.Quantity)
+	if projected.LessThanOrEqual(maxPosition) {
+		return nil, nil
+	}
+	return &Rejection{
+		Rule:    r.Name(),
+		Message: fmt.Sprintf("resulting %s position is above the maximum allowed", order.BaseAssetCode),
+		Limit:   maxPosition.String(),
+		Value:   projected.String(),
+	}, nil
+}
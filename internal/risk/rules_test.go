@@ -0,0 +1,133 @@
+package risk_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// state is a fixed market and account state, nil prices are missing
+type state struct {
+	lastTrade  *decimal.Decimal
+	mid        *decimal.Decimal
+	openOrders int
+	position   decimal.Decimal
+}
+
+func (s state) LastTradePrice(ctx context.Context, instrumentId uuid.UUID) (*decimal.Decimal, error) {
+	return s.lastTrade, nil
+}
+
+func (s state) MidPrice(ctx context.Context, instrumentId uuid.UUID) (*decimal.Decimal, error) {
+	return s.mid, nil
+}
+
+func (s state) OpenOrders(ctx context.Context, accountId uuid.UUID) (int, error) {
+	return s.openOrders, nil
+}
+
+func (s state) Position(ctx context.Context, accountId, assetId uuid.UUID) (decimal.Decimal, error) {
+	return s.position, nil
+}
+
+func dec(value string) *decimal.Decimal {
+	d := decimal.RequireFromString(value)
+	return &d
+}
+
+func newOrder(side, price, quantity string) risk.Order {
+	return risk.Order{
+		AccountId:     uuid.New(),
+		InstrumentId:  uuid.New(),
+		BaseAssetId:   uuid.New(),
+		BaseAssetCode: "BTC",
+		Side:          side,
+		Price:         decimal.RequireFromString(price),
+		Quantity:      decimal.RequireFromString(quantity),
+	}
+}
+
+type ruleTest struct {
+	name   string
+	order  risk.Order
+	limits risk.Limits
+	state  state
+	// value is the rejected value, empty when the order passes
+	value string
+}
+
+func runRuleTests(t *testing.T, check risk.RiskCheck, tests []ruleTest) {
+	t.Helper()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rejection, err := check.Check(context.Background(), test.order, test.limits, test.state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.value == "" {
+				if rejection != nil {
+					t.Fatalf("got rejection %+v, want none", rejection)
+				}
+				return
+			}
+			if rejection == nil {
+				t.Fatalf("got no rejection, want value %s", test.value)
+			}
+			if rejection.Rule != check.Name() || rejection.Value != test.value {
+				t.Errorf("got rejection %+v, want rule %s with value %s", rejection, check.Name(), test.value)
+			}
+		})
+	}
+}
+
+func TestMaxOrderSize(t *testing.T) {
+	runRuleTests(t, risk.MaxOrderSize{}, []ruleTest{
+		{name: "disabled", order: newOrder("buy", "100", "1000")},
+		{name: "at the limit", order: newOrder("buy", "100", "5"), limits: risk.Limits{MaxOrderQuantity: dec("5")}},
+		{name: "above the limit", order: newOrder("sell", "100", "5.1"), limits: risk.Limits{MaxOrderQuantity: dec("5")}, value: "5.1"},
+	})
+}
+
+func TestMaxNotional(t *testing.T) {
+	runRuleTests(t, risk.MaxNotional{}, []ruleTest{
+		{name: "disabled", order: newOrder("buy", "1000", "1000")},
+		{name: "at the limit", order: newOrder("buy", "100", "5"), limits: risk.Limits{MaxNotional: dec("500")}},
+		{name: "above the limit", order: newOrder("sell", "100", "6"), limits: risk.Limits{MaxNotional: dec("500")}, value: "600"},
+	})
+}
+
+func TestPriceBand(t *testing.T) {
+	band := risk.Limits{PriceBandPercent: dec("10")}
+	runRuleTests(t, risk.PriceBand{}, []ruleTest{
+		{name: "disabled", order: newOrder("buy", "500", "1"), state: state{lastTrade: dec("100")}},
+		{name: "inside the band", order: newOrder("buy", "110", "1"), limits: band, state: state{lastTrade: dec("100")}},
+		{name: "above the band", order: newOrder("buy", "111", "1"), limits: band, state: state{lastTrade: dec("100")}, value: "11.00"},
+		{name: "below the band", order: newOrder("sell", "85", "1"), limits: band, state: state{lastTrade: dec("100")}, value: "15.00"},
+		{name: "last trade before mid", order: newOrder("buy", "110", "1"), limits: band, state: state{lastTrade: dec("100"), mid: dec("200")}},
+		{name: "mid without trades", order: newOrder("buy", "110", "1"), limits: band, state: state{mid: dec("200")}, value: "45.00"},
+		{name: "no reference", order: newOrder("buy", "500", "1"), limits: band},
+	})
+}
+
+func TestMaxOpenOrders(t *testing.T) {
+	max := 3
+	runRuleTests(t, risk.MaxOpenOrders{}, []ruleTest{
+		{name: "disabled", order: newOrder("buy", "100", "1"), state: state{openOrders: 1000}},
+		{name: "below the limit", order: newOrder("buy", "100", "1"), limits: risk.Limits{MaxOpenOrders: &max}, state: state{openOrders: 2}},
+		{name: "at the limit", order: newOrder("buy", "100", "1"), limits: risk.Limits{MaxOpenOrders: &max}, state: state{openOrders: 3}, value: "3"},
+	})
+}
+
+func TestMaxPosition(t *testing.T) {
+	limits := risk.Limits{MaxPosition: map[string]decimal.Decimal{"BTC": decimal.NewFromInt(10)}}
+	runRuleTests(t, risk.MaxPosition{}, []ruleTest{
+		{name: "disabled", order: newOrder("buy", "100", "100")},
+		{name: "other asset", order: newOrder("buy", "100", "100"), limits: risk.Limits{MaxPosition: map[string]decimal.Decimal{"ETH": decimal.NewFromInt(1)}}},
+		{name: "at the limit", order: newOrder("buy", "100", "4"), limits: limits, state: state{position: decimal.NewFromInt(6)}},
+		{name: "above the limit", order: newOrder("buy", "100", "5"), limits: limits, state: state{position: decimal.NewFromInt(6)}, value: "11"},
+		{name: "sells reduce the position", order: newOrder("sell", "100", "5"), limits: limits, state: state{position: decimal.NewFromInt(20)}},
+	})
+}
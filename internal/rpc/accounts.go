@@ -0,0 +1,108 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	clobv1 "github.com/JhonesBR/go-clob/proto/clob/v1"
+	"github.com/google/uuid"
+)
+
+type accountServer struct {
+	*Server
+	clobv1.UnimplementedAccountServiceServer
+}
+
+func (s accountServer) CreateAccount(ctx context.Context, req *clobv1.CreateAccountRequest) (*clobv1.Account, error) {
+	created, err := s.accounts.Create(ctx, account.CreateAccountSchema{Name: req.GetName()})
+	if err != nil {
+		return nil, err
+	}
+	return accountMessage(created), nil
+}
+
+func (s accountServer) GetAccount(ctx context.Context, req *clobv1.GetAccountRequest) (*clobv1.Account, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	found, err := s.accounts.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return accountMessage(found), nil
+}
+
+func (s accountServer) ListAccounts(ctx context.Context, req *clobv1.ListAccountsRequest) (*clobv1.ListAccountsResponse, error) {
+	page, err := helper.NewPage(int(req.GetSize()), req.GetSort(), req.GetCursor(), account.AccountSorts...)
+	if err != nil {
+		return nil, err
+	}
+	pagination, err := s.accounts.List(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	resp := &clobv1.ListAccountsResponse{
+		Size:       int32(pagination.Size),
+		NextCursor: cursor(pagination.NextCursor),
+	}
+	for _, item := range pagination.Items {
+		resp.Accounts = append(resp.Accounts, accountMessage(item))
+	}
+	return resp, nil
+}
+
+// cursor is the next_cursor of a page, empty on the last one
+func cursor(next *string) string {
+	if next == nil {
+		return ""
+	}
+	return *next
+}
+
+func (s accountServer) Deposit(ctx context.Context, req *clobv1.UpdateBalanceRequest) (*clobv1.Balance, error) {
+	return s.updateBalance(ctx, req, s.accounts.Charge)
+}
+
+func (s accountServer) Withdraw(ctx context.Context, req *clobv1.UpdateBalanceRequest) (*clobv1.Balance, error) {
+	return s.updateBalance(ctx, req, s.accounts.Remove)
+}
+
+func (s accountServer) updateBalance(ctx context.Context, req *clobv1.UpdateBalanceRequest, update func(context.Context, uuid.UUID, account.UpdateBalanceSchema) (account.UpdateBalanceResponseSchema, error)) (*clobv1.Balance, error) {
+	id, err := parseId("account_id", req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Missing fields are left nil for the service to refuse them
+	var charge account.UpdateBalanceSchema
+	if req.GetAmount() != "" {
+		amount, err := parseDecimal("amount", req.GetAmount())
+		if err != nil {
+			return nil, err
+		}
+		charge.Amount = &amount
+	}
+	if req.GetAssetCode() != "" {
+		charge.AssetCode = &req.AssetCode
+	}
+
+	updated, err := update(ctx, id, charge)
+	if err != nil {
+		return nil, err
+	}
+	return &clobv1.Balance{AssetCode: *updated.AssetCode, Balance: updated.Balance.String()}, nil
+}
+
+func accountMessage(show account.AccountShowSchema) *clobv1.Account {
+	msg := &clobv1.Account{Id: show.Id, Name: show.Name}
+	for _, balance := range show.Balances {
+		msg.Balances = append(msg.Balances, &clobv1.AccountBalance{
+			AssetId:   balance.AssetId.String(),
+			AssetCode: *balance.AssetCode,
+			Balance:   balance.Balance.String(),
+		})
+	}
+	return msg
+}
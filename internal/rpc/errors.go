@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/marketdata"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus turns service errors into the status of the call, the REST API
+// answers them with the HTTP status they carry. Their code is the reason of
+// an ErrorInfo detail. Unexpected errors are not sent to the client.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var rejection orderbook.Rejection
+	if errors.As(err, &rejection) {
+		return rejectionStatus(rejection)
+	}
+	var serviceErr helper.Error
+	if errors.As(err, &serviceErr) {
+		return withReason(status.New(code(serviceErr.Status), serviceErr.Message), serviceErr.Code).Err()
+	}
+
+	switch {
+	case errors.Is(err, marketdata.ErrSlowSubscriber):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, marketdata.ErrClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, "internal error")
+}
+
+// rejectionStatus refuses an order, risk check failures are detailed as
+// precondition violations
+func rejectionStatus(rejection orderbook.Rejection) error {
+	reasons, _ := rejection.Details["reasons"].([]risk.Rejection)
+	if len(reasons) == 0 {
+		return withReason(status.New(code(rejection.Status), rejection.Message), rejection.Code).Err()
+	}
+
+	st := withReason(status.New(codes.FailedPrecondition, rejection.Message), rejection.Code)
+	failure := &errdetails.PreconditionFailure{}
+	for _, reason := range reasons {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        reason.Rule,
+			Subject:     "order",
+			Description: fmt.Sprintf("%s (limit %s, value %s)", reason.Message, reason.Limit, reason.Value),
+		})
+	}
+	if detailed, err := st.WithDetails(failure); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// withReason details st with the stable code of the error
+func withReason(st *status.Status, reason helper.Code) *status.Status {
+	if reason == "" {
+		return st
+	}
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: string(reason), Domain: "clob"})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// code maps the HTTP status of a service error, what the request asks for is
+// refused in the current state unless it is malformed or missing
+func code(httpStatus int) codes.Code {
+	switch httpStatus {
+	case fiber.StatusBadRequest:
+		return codes.InvalidArgument
+	case fiber.StatusNotFound:
+		return codes.NotFound
+	case fiber.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	}
+	return codes.FailedPrecondition
+}
+
+func parseId(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.UUID{}, status.Errorf(codes.InvalidArgument, "%s must be a uuid", field)
+	}
+	return id, nil
+}
+
+func parseDecimal(field, value string) (decimal.Decimal, error) {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Decimal{}, status.Errorf(codes.InvalidArgument, "%s must be a decimal number", field)
+	}
+	return d, nil
+}
@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/logging"
+	"github.com/JhonesBR/go-clob/internal/tracing"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const requestIdKey = "x-request-id"
+
+var requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "clob_grpc_request_duration_seconds",
+	Help:    "Time to answer gRPC calls per method, until the end of the stream for streaming calls.",
+	Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+}, []string{"method", "code"})
+
+// unaryInterceptor is the gRPC counterpart of the REST middlewares, see observe
+func unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var resp any
+	err := observe(ctx, info.FullMethod, func(ctx context.Context, id string) error {
+		grpc.SetHeader(ctx, metadata.Pairs(requestIdKey, id))
+		var err error
+		resp, err = handler(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func streamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return observe(stream.Context(), info.FullMethod, func(ctx context.Context, id string) error {
+		stream.SetHeader(metadata.Pairs(requestIdKey, id))
+		return handler(srv, contextStream{stream, ctx})
+	})
+}
+
+// observe runs a call with a request id, the x-request-id metadata when sent
+// or a new one, and a server span continuing the trace of the traceparent
+// metadata, then logs it and records its latency. Service errors are turned
+// into statuses here.
+func observe(ctx context.Context, method string, call func(ctx context.Context, id string) error) error {
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	id := ""
+	if values := md.Get(requestIdKey); len(values) > 0 {
+		id = values[0]
+	}
+	if id == "" || len(id) > 128 {
+		id = uuid.NewString()
+	}
+	ctx = logging.WithRequestId(ctx, id)
+
+	service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC, semconv.RPCService(service), semconv.RPCMethod(name)}
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, semconv.ClientAddress(p.Addr.String()))
+	}
+	ctx, span := tracing.StartServer(ctx, method, metadataCarrier(md), attrs...)
+	defer span.End()
+
+	callErr := call(ctx, id)
+	err := toStatus(callErr)
+
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	level := slog.LevelInfo
+	logAttrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if serverError(code) {
+		level = slog.LevelError
+		span.SetStatus(otelcodes.Error, code.String())
+		span.RecordError(callErr, trace.WithStackTrace(false))
+		logAttrs = append(logAttrs, slog.String("error", callErr.Error()))
+	}
+	slog.LogAttrs(ctx, level, "Call", logAttrs...)
+	requestDuration.WithLabelValues(method, code.String()).Observe(time.Since(start).Seconds())
+	return err
+}
+
+// serverError reports the codes that are failures of the server rather than
+// of the call, as 5xx statuses on the REST API
+func serverError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unimplemented:
+		return true
+	}
+	return false
+}
+
+// contextStream carries the call context down to the stream handler
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// metadataCarrier reads propagation fields from the call metadata
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	values := metadata.MD(m).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,193 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/JhonesBR/go-clob/internal/api/instrument"
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/marketdata"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	clobv1 "github.com/JhonesBR/go-clob/proto/clob/v1"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type marketDataServer struct {
+	*Server
+	clobv1.UnimplementedMarketDataServiceServer
+}
+
+func (s marketDataServer) ListInstruments(ctx context.Context, req *clobv1.ListInstrumentsRequest) (*clobv1.ListInstrumentsResponse, error) {
+	instruments, err := s.instruments.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &clobv1.ListInstrumentsResponse{}
+	for _, show := range instruments {
+		resp.Instruments = append(resp.Instruments, &clobv1.Instrument{
+			Id:             show.Id.String(),
+			Symbol:         show.Symbol,
+			BaseAssetId:    show.BaseAssetId.String(),
+			BaseAssetCode:  show.BaseAssetCode,
+			QuoteAssetId:   show.QuoteAssetId.String(),
+			QuoteAssetCode: show.QuoteAssetCode,
+			TradingStatus:  string(show.TradingStatus),
+			CircuitBreaker: circuitBreakerMessage(show.CircuitBreaker),
+		})
+	}
+	return resp, nil
+}
+
+func (s marketDataServer) GetTicker(ctx context.Context, req *clobv1.GetTickerRequest) (*clobv1.Ticker, error) {
+	id, err := parseId("instrument_id", req.GetInstrumentId())
+	if err != nil {
+		return nil, err
+	}
+	ticker, err := s.instruments.Ticker(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return tickerMessage(ticker), nil
+}
+
+func (s marketDataServer) GetBook(ctx context.Context, req *clobv1.GetBookRequest) (*clobv1.Book, error) {
+	id, err := parseId("instrument_id", req.GetInstrumentId())
+	if err != nil {
+		return nil, err
+	}
+	book, err := s.orders.Book(ctx, id, int(max(req.GetDepth(), 0)))
+	if err != nil {
+		return nil, err
+	}
+	return bookMessage(book), nil
+}
+
+func (s marketDataServer) StreamTrades(req *clobv1.StreamTradesRequest, stream grpc.ServerStreamingServer[clobv1.Trade]) error {
+	ctx := stream.Context()
+	id, err := parseId("instrument_id", req.GetInstrumentId())
+	if err != nil {
+		return err
+	}
+	// Fail fast on unknown instruments
+	if _, err := s.instruments.Get(ctx, id); err != nil {
+		return err
+	}
+
+	subscription := s.feed.SubscribeTrades(id)
+	defer subscription.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case update, ok := <-subscription.C:
+			if !ok {
+				return subscription.Err()
+			}
+			if err := stream.Send(tradeMessage(update.Trade)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s marketDataServer) StreamBook(req *clobv1.StreamBookRequest, stream grpc.ServerStreamingServer[clobv1.Book]) error {
+	ctx := stream.Context()
+	id, err := parseId("instrument_id", req.GetInstrumentId())
+	if err != nil {
+		return err
+	}
+	depth := int(max(req.GetDepth(), 0))
+
+	// Subscribe before reading the book so no change is missed in between
+	subscription := s.feed.SubscribeBook(id)
+	defer subscription.Close()
+	var sent int64 = -1
+	send := func() error {
+		book, err := s.orders.Book(ctx, id, depth)
+		if err != nil || book.Sequence == sent {
+			return err
+		}
+		sent = book.Sequence
+		return stream.Send(bookMessage(book))
+	}
+	if err := send(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-subscription.C:
+			if !ok {
+				return subscription.Err()
+			}
+			if err := send(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func circuitBreakerMessage(breaker circuitbreaker.Status) *clobv1.CircuitBreaker {
+	msg := &clobv1.CircuitBreaker{State: string(breaker.State), Reason: breaker.Reason}
+	if breaker.HaltedUntil != nil {
+		msg.HaltedUntil = timestamppb.New(*breaker.HaltedUntil)
+	}
+	return msg
+}
+
+func tickerMessage(ticker instrument.TickerSchema) *clobv1.Ticker {
+	return &clobv1.Ticker{
+		InstrumentId:   ticker.InstrumentId.String(),
+		Symbol:         ticker.Symbol,
+		LastPrice:      optionalDecimal(ticker.LastPrice),
+		BestBid:        optionalDecimal(ticker.BestBid),
+		BestAsk:        optionalDecimal(ticker.BestAsk),
+		Volume_24H:     ticker.Volume24h.String(),
+		TradingStatus:  string(ticker.TradingStatus),
+		CircuitBreaker: circuitBreakerMessage(ticker.CircuitBreaker),
+	}
+}
+
+func bookMessage(book orderbook.BookSchema) *clobv1.Book {
+	return &clobv1.Book{
+		InstrumentId: book.InstrumentId.String(),
+		Bids:         levelMessages(book.Bids),
+		Asks:         levelMessages(book.Asks),
+		Sequence:     book.Sequence,
+	}
+}
+
+func levelMessages(levels []storage.PriceLevel) []*clobv1.PriceLevel {
+	msgs := make([]*clobv1.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		msgs = append(msgs, &clobv1.PriceLevel{
+			Price:    level.Price.String(),
+			Quantity: level.Quantity.String(),
+			Orders:   int32(level.Orders),
+		})
+	}
+	return msgs
+}
+
+func tradeMessage(trade *marketdata.Trade) *clobv1.Trade {
+	return &clobv1.Trade{
+		Sequence:     trade.Sequence,
+		InstrumentId: trade.InstrumentId.String(),
+		BuyOrderId:   trade.BuyOrderId.String(),
+		SellOrderId:  trade.SellOrderId.String(),
+		Price:        trade.Price.String(),
+		Quantity:     trade.Quantity.String(),
+		ExecutedAt:   timestamppb.New(trade.ExecutedAt),
+	}
+}
+
+// optionalDecimal is empty when the value is unknown
+func optionalDecimal(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
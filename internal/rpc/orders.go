@@ -0,0 +1,201 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	clobv1 "github.com/JhonesBR/go-clob/proto/clob/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	orderTypes = map[clobv1.Side]orderbook.OrderType{
+		clobv1.Side_SIDE_BUY:  orderbook.Buy,
+		clobv1.Side_SIDE_SELL: orderbook.Sell,
+	}
+	sides = map[orderbook.OrderType]clobv1.Side{
+		orderbook.Buy:  clobv1.Side_SIDE_BUY,
+		orderbook.Sell: clobv1.Side_SIDE_SELL,
+	}
+	orderStatuses = map[orderbook.OrderStatus]clobv1.OrderStatus{
+		orderbook.Open:            clobv1.OrderStatus_ORDER_STATUS_OPEN,
+		orderbook.PartiallyFilled: clobv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED,
+		orderbook.FullFilled:      clobv1.OrderStatus_ORDER_STATUS_FILLED,
+		orderbook.Canceled:        clobv1.OrderStatus_ORDER_STATUS_CANCELED,
+	}
+	statusesByMessage = map[clobv1.OrderStatus]orderbook.OrderStatus{
+		clobv1.OrderStatus_ORDER_STATUS_OPEN:             orderbook.Open,
+		clobv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED: orderbook.PartiallyFilled,
+		clobv1.OrderStatus_ORDER_STATUS_FILLED:           orderbook.FullFilled,
+		clobv1.OrderStatus_ORDER_STATUS_CANCELED:         orderbook.Canceled,
+	}
+)
+
+type orderServer struct {
+	*Server
+	clobv1.UnimplementedOrderServiceServer
+}
+
+func (s orderServer) PlaceOrder(ctx context.Context, req *clobv1.PlaceOrderRequest) (*clobv1.PlaceOrderResponse, error) {
+	accountId, err := parseId("account_id", req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+	orderType, ok := orderTypes[req.GetSide()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "side must be SIDE_BUY or SIDE_SELL")
+	}
+	quantity, err := parseDecimal("quantity", req.GetQuantity())
+	if err != nil {
+		return nil, err
+	}
+	price, err := parseDecimal("price", req.GetPrice())
+	if err != nil {
+		return nil, err
+	}
+
+	placement, err := s.orders.Place(ctx, orderbook.PlaceOrderSchema{
+		AccountId: accountId,
+		AssetCode: req.GetAssetCode(),
+		Quantity:  quantity,
+		Price:     price,
+		OrderType: orderType,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &clobv1.PlaceOrderResponse{Order: orderMessage(orderbook.NewOrderBookShowSchema(placement.Order))}
+	for _, fill := range placement.Fills {
+		resp.Fills = append(resp.Fills, &clobv1.Fill{
+			OrderId:  fill.Match.Id.String(),
+			Price:    fill.Price.String(),
+			Quantity: fill.Quantity.String(),
+		})
+	}
+	return resp, nil
+}
+
+func (s orderServer) CancelOrder(ctx context.Context, req *clobv1.CancelOrderRequest) (*clobv1.Order, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	canceled, err := s.orders.Cancel(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	return orderMessage(orderbook.NewOrderBookShowSchema(canceled)), nil
+}
+
+func (s orderServer) GetOrder(ctx context.Context, req *clobv1.GetOrderRequest) (*clobv1.Order, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.orders.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return orderMessage(order), nil
+}
+
+func (s orderServer) ListOrders(ctx context.Context, req *clobv1.ListOrdersRequest) (*clobv1.ListOrdersResponse, error) {
+	return listOrders(ctx, req, s.orders.List)
+}
+
+func (s orderServer) ListOrderHistory(ctx context.Context, req *clobv1.ListOrdersRequest) (*clobv1.ListOrdersResponse, error) {
+	return listOrders(ctx, req, s.orders.History)
+}
+
+// listOrders lists the live book or the order history, filtered and
+// paginated alike
+func listOrders(ctx context.Context, req *clobv1.ListOrdersRequest, list func(context.Context, storage.OrderFilter, storage.Page) (helper.Pagination[orderbook.OrderBookShowSchema], error)) (*clobv1.ListOrdersResponse, error) {
+	var filter storage.OrderFilter
+	if req.GetAccountId() != "" {
+		accountId, err := parseId("account_id", req.GetAccountId())
+		if err != nil {
+			return nil, err
+		}
+		filter.AccountId = &accountId
+	}
+	if req.GetInstrumentId() != "" {
+		instrumentId, err := parseId("instrument_id", req.GetInstrumentId())
+		if err != nil {
+			return nil, err
+		}
+		filter.InstrumentId = &instrumentId
+	}
+
+	for _, requested := range req.GetStatuses() {
+		orderStatus, ok := statusesByMessage[requested]
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "statuses must not be unspecified")
+		}
+		filter.Statuses = append(filter.Statuses, orderStatus)
+	}
+	if req.GetSide() != clobv1.Side_SIDE_UNSPECIFIED {
+		orderType, ok := orderTypes[req.GetSide()]
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "side is invalid")
+		}
+		filter.Type = &orderType
+	}
+	if req.GetMinPrice() != "" {
+		minPrice, err := parseDecimal("min_price", req.GetMinPrice())
+		if err != nil {
+			return nil, err
+		}
+		filter.MinPrice = &minPrice
+	}
+	if req.GetMaxPrice() != "" {
+		maxPrice, err := parseDecimal("max_price", req.GetMaxPrice())
+		if err != nil {
+			return nil, err
+		}
+		filter.MaxPrice = &maxPrice
+	}
+	if req.GetCreatedFrom() != nil {
+		createdFrom := req.GetCreatedFrom().AsTime()
+		filter.CreatedFrom = &createdFrom
+	}
+	if req.GetCreatedTo() != nil {
+		createdTo := req.GetCreatedTo().AsTime()
+		filter.CreatedTo = &createdTo
+	}
+
+	page, err := helper.NewPage(int(req.GetSize()), req.GetSort(), req.GetCursor(), orderbook.OrderSorts...)
+	if err != nil {
+		return nil, err
+	}
+	pagination, err := list(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+	resp := &clobv1.ListOrdersResponse{
+		Size:       int32(pagination.Size),
+		NextCursor: cursor(pagination.NextCursor),
+	}
+	for _, item := range pagination.Items {
+		resp.Orders = append(resp.Orders, orderMessage(item))
+	}
+	return resp, nil
+}
+
+func orderMessage(order orderbook.OrderBookShowSchema) *clobv1.Order {
+	return &clobv1.Order{
+		Id:             order.Id.String(),
+		AccountId:      order.AccountId.String(),
+		InstrumentId:   order.InstrumentId.String(),
+		Side:           sides[order.Type],
+		Status:         orderStatuses[order.Status],
+		Price:          order.Price.String(),
+		TotalQuantity:  order.TotalQuantity.String(),
+		FilledQuantity: order.FilledQuantity.String(),
+		CreatedAt:      timestamppb.New(order.CreatedAt),
+	}
+}
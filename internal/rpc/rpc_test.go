@@ -0,0 +1,308 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/marketdata"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/JhonesBR/go-clob/internal/storage/memory"
+	clobv1 "github.com/JhonesBR/go-clob/proto/clob/v1"
+	"github.com/shopspring/decimal"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// harness serves the API over an in-memory connection against an in-memory
+// store with one instrument, BTC/BRL
+type harness struct {
+	t          *testing.T
+	store      *memory.Store
+	feed       *marketdata.Feed
+	accounts   clobv1.AccountServiceClient
+	orders     clobv1.OrderServiceClient
+	marketData clobv1.MarketDataServiceClient
+	instrument string
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	store := memory.NewEmpty()
+	btc := store.AddAsset("BTC", "Bitcoin")
+	brl := store.AddAsset("BRL", "Brazilian Real")
+	instrument := store.AddInstrument(btc, brl)
+
+	breakerConfig := circuitbreaker.DefaultConfig()
+	breakerConfig.ThresholdPercent = decimal.NewFromInt(1_000_000)
+	breaker := circuitbreaker.New(breakerConfig)
+	ctx, stopFeed := context.WithCancel(context.Background())
+	feed, err := marketdata.New(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feedDone := make(chan struct{})
+	go func() {
+		feed.Run(ctx)
+		close(feedDone)
+	}()
+	server := New(store, orderbook.NewService(store, nil, risk.NewEngine(risk.Config{}), breaker), breaker, feed)
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		stopFeed()
+		<-feedDone
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return &harness{
+		t:          t,
+		store:      store,
+		feed:       feed,
+		accounts:   clobv1.NewAccountServiceClient(conn),
+		orders:     clobv1.NewOrderServiceClient(conn),
+		marketData: clobv1.NewMarketDataServiceClient(conn),
+		instrument: instrument.Id.String(),
+	}
+}
+
+// fund creates an account and deposits amount of the asset
+func (h *harness) fund(assetCode, amount string) string {
+	h.t.Helper()
+
+	ctx := context.Background()
+	account, err := h.accounts.CreateAccount(ctx, &clobv1.CreateAccountRequest{Name: "rpc"})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	balance, err := h.accounts.Deposit(ctx, &clobv1.UpdateBalanceRequest{AccountId: account.Id, AssetCode: assetCode, Amount: amount})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	if balance.Balance != amount {
+		h.t.Fatalf("balance %s after depositing %s", balance.Balance, amount)
+	}
+	return account.Id
+}
+
+// subscribed waits until the feed has the given number of subscribers
+func (h *harness) subscribed(subscribers int) {
+	h.t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		if h.feed.Subscribers() == subscribers {
+			return
+		}
+		if time.Now().After(deadline) {
+			h.t.Fatal("stream not subscribed")
+		}
+	}
+}
+
+func expectCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Fatalf("expected %s, got %v", code, err)
+	}
+}
+
+// expectReason checks the stable code the error is detailed with
+func expectReason(t *testing.T, err error, reason helper.Code) {
+	t.Helper()
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Reason == string(reason) {
+			return
+		}
+	}
+	t.Fatalf("expected reason %s, got %v", reason, err)
+}
+
+func TestOrderEntryAndMarketData(t *testing.T) {
+	h := newHarness(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	seller := h.fund("BTC", "2")
+	buyer := h.fund("BRL", "1000")
+
+	books, err := h.marketData.StreamBook(ctx, &clobv1.StreamBookRequest{InstrumentId: h.instrument})
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := books.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Bids) != 0 || len(book.Asks) != 0 {
+		t.Fatalf("expected an empty book, got %v", book)
+	}
+	trades, err := h.marketData.StreamTrades(ctx, &clobv1.StreamTradesRequest{InstrumentId: h.instrument})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.subscribed(2)
+
+	sell, err := h.orders.PlaceOrder(ctx, &clobv1.PlaceOrderRequest{
+		AccountId: seller, AssetCode: "BTC", Side: clobv1.Side_SIDE_SELL, Price: "100", Quantity: "2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sell.Order.Status != clobv1.OrderStatus_ORDER_STATUS_OPEN || len(sell.Fills) != 0 {
+		t.Fatalf("expected a resting sell order, got %v", sell)
+	}
+	book, err = books.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Asks) != 1 || book.Asks[0].Price != "100" || book.Asks[0].Quantity != "2" || book.Asks[0].Orders != 1 {
+		t.Fatalf("expected the sell order on the book, got %v", book)
+	}
+
+	buy, err := h.orders.PlaceOrder(ctx, &clobv1.PlaceOrderRequest{
+		AccountId: buyer, AssetCode: "BTC", Side: clobv1.Side_SIDE_BUY, Price: "100", Quantity: "0.5",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buy.Order.Status != clobv1.OrderStatus_ORDER_STATUS_FILLED || len(buy.Fills) != 1 || buy.Fills[0].OrderId != sell.Order.Id {
+		t.Fatalf("expected the buy order filled against the sell order, got %v", buy)
+	}
+
+	trade, err := trades.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trade.BuyOrderId != buy.Order.Id || trade.SellOrderId != sell.Order.Id || trade.Price != "100" || trade.Quantity != "0.5" {
+		t.Fatalf("unexpected trade %v", trade)
+	}
+	for book.Asks[0].Quantity != "1.5" {
+		if book, err = books.Recv(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	order, err := h.orders.GetOrder(ctx, &clobv1.GetOrderRequest{Id: sell.Order.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != clobv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED || order.FilledQuantity != "0.5" {
+		t.Fatalf("expected the sell order partially filled, got %v", order)
+	}
+	list, err := h.orders.ListOrders(ctx, &clobv1.ListOrdersRequest{AccountId: seller})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.NextCursor != "" || len(list.Orders) != 1 || list.Orders[0].Id != sell.Order.Id {
+		t.Fatalf("expected the sell order only, got %v", list)
+	}
+	list, err = h.orders.ListOrders(ctx, &clobv1.ListOrdersRequest{AccountId: seller, Side: clobv1.Side_SIDE_BUY})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Orders) != 0 {
+		t.Fatalf("expected no buy order of the seller, got %v", list)
+	}
+
+	canceled, err := h.orders.CancelOrder(ctx, &clobv1.CancelOrderRequest{Id: sell.Order.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canceled.Status != clobv1.OrderStatus_ORDER_STATUS_CANCELED {
+		t.Fatalf("expected the sell order canceled, got %v", canceled)
+	}
+	for len(book.Asks) != 0 {
+		if book, err = books.Recv(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = h.orders.CancelOrder(ctx, &clobv1.CancelOrderRequest{Id: sell.Order.Id})
+	expectCode(t, err, codes.FailedPrecondition)
+	expectReason(t, err, helper.CodeOrderNotCancelable)
+
+	// Once archived the canceled order moves from the live book to the history
+	if _, err := orderbook.Archive(ctx, h.store, 100); err != nil {
+		t.Fatal(err)
+	}
+	list, err = h.orders.ListOrders(ctx, &clobv1.ListOrdersRequest{AccountId: seller})
+	if err != nil || len(list.Orders) != 0 {
+		t.Fatalf("expected no live order of the seller, got %v (%v)", list, err)
+	}
+	list, err = h.orders.ListOrderHistory(ctx, &clobv1.ListOrdersRequest{AccountId: seller})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Orders) != 1 || list.Orders[0].Status != clobv1.OrderStatus_ORDER_STATUS_CANCELED {
+		t.Fatalf("expected the canceled sell order in the history, got %v", list)
+	}
+
+	account, err := h.accounts.GetAccount(ctx, &clobv1.GetAccountRequest{Id: seller})
+	if err != nil {
+		t.Fatal(err)
+	}
+	balances := map[string]string{}
+	for _, balance := range account.Balances {
+		balances[balance.AssetCode] = balance.Balance
+	}
+	if balances["BTC"] != "1.5" || balances["BRL"] != "50" {
+		t.Fatalf("unexpected seller balances %v", balances)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	h := newHarness(t)
+	ctx := context.Background()
+	account := h.fund("BRL", "10")
+
+	_, err := h.accounts.GetAccount(ctx, &clobv1.GetAccountRequest{Id: "not-an-id"})
+	expectCode(t, err, codes.InvalidArgument)
+	_, err = h.accounts.GetAccount(ctx, &clobv1.GetAccountRequest{Id: h.instrument})
+	expectCode(t, err, codes.NotFound)
+	_, err = h.accounts.Withdraw(ctx, &clobv1.UpdateBalanceRequest{AccountId: account, AssetCode: "BRL", Amount: "-1"})
+	expectCode(t, err, codes.InvalidArgument)
+
+	_, err = h.orders.PlaceOrder(ctx, &clobv1.PlaceOrderRequest{AccountId: account, AssetCode: "BTC", Price: "1", Quantity: "1"})
+	expectCode(t, err, codes.InvalidArgument)
+	_, err = h.orders.PlaceOrder(ctx, &clobv1.PlaceOrderRequest{
+		AccountId: account, AssetCode: "ETH", Side: clobv1.Side_SIDE_BUY, Price: "1", Quantity: "1",
+	})
+	expectCode(t, err, codes.NotFound)
+	_, err = h.orders.PlaceOrder(ctx, &clobv1.PlaceOrderRequest{
+		AccountId: account, AssetCode: "BTC", Side: clobv1.Side_SIDE_BUY, Price: "100", Quantity: "1",
+	})
+	expectCode(t, err, codes.FailedPrecondition)
+	expectReason(t, err, helper.CodeInsufficientFunds)
+
+	_, err = h.marketData.GetTicker(ctx, &clobv1.GetTickerRequest{InstrumentId: account})
+	expectCode(t, err, codes.NotFound)
+	stream, err := h.marketData.StreamTrades(ctx, &clobv1.StreamTradesRequest{InstrumentId: account})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	expectCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/JhonesBR/go-clob/internal/api/account"
+	"github.com/JhonesBR/go-clob/internal/api/instrument"
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/marketdata"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	clobv1 "github.com/JhonesBR/go-clob/proto/clob/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server is the gRPC API, it goes through the same services as the REST API
+// so both behave the same. Market data streams are fed by the feed shared
+// with the REST API, they end when it stops.
+type Server struct {
+	store  storage.Store
+	grpc   *grpc.Server
+	health *health.Server
+
+	accounts    *account.Service
+	instruments *instrument.Service
+	orders      *orderbook.Service
+	feed        *marketdata.Feed
+}
+
+func New(store storage.Store, orders *orderbook.Service, breaker *circuitbreaker.Breaker, feed *marketdata.Feed) *Server {
+	s := &Server{
+		store: store,
+		grpc: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(unaryInterceptor),
+			grpc.ChainStreamInterceptor(streamInterceptor),
+		),
+		health:      health.NewServer(),
+		accounts:    account.NewService(store),
+		instruments: instrument.NewService(store, breaker),
+		orders:      orders,
+		feed:        feed,
+	}
+
+	clobv1.RegisterAccountServiceServer(s.grpc, accountServer{Server: s})
+	clobv1.RegisterOrderServiceServer(s.grpc, orderServer{Server: s})
+	clobv1.RegisterMarketDataServiceServer(s.grpc, marketDataServer{Server: s})
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	reflection.Register(s.grpc)
+	return s
+}
+
+// Listen accepts connections on address until Shutdown
+func (s *Server) Listen(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve accepts connections on listener until Shutdown
+func (s *Server) Serve(listener net.Listener) error {
+	return s.grpc.Serve(listener)
+}
+
+// Shutdown waits for the calls in flight, they are canceled once ctx is
+// done. Streams only end when the market data feed stops.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		return ctx.Err()
+	}
+}
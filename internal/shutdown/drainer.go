@@ -0,0 +1,93 @@
+package shutdown
+
+import (
+	"context"
+	"sync"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/gofiber/fiber/v3"
+)
+
+// Drainer tracks in-flight requests so shutdown can wait for them, orders
+// being matched included, before the store is closed. Once draining, new
+// requests are refused.
+type Drainer struct {
+	// ctx is given to every request and canceled when the drain deadline
+	// passes, so the storage calls still running give up and roll back
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	draining bool
+	inFlight int
+	idle     chan struct{}
+}
+
+func NewDrainer() *Drainer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Drainer{ctx: ctx, cancel: cancel, idle: make(chan struct{})}
+}
+
+// Middleware tracks the request and attaches the drainer context to it, or
+// answers 503 when the server is shutting down
+func (d *Drainer) Middleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if !d.enter() {
+			c.Set(fiber.HeaderConnection, "close")
+			return helper.Error{Status: fiber.StatusServiceUnavailable, Code: helper.CodeShuttingDown, Message: "Server is shutting down"}
+		}
+		defer d.leave()
+
+		helper.WithContext(c, d.ctx)
+		return c.Next()
+	}
+}
+
+func (d *Drainer) enter() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return false
+	}
+	d.inFlight++
+	return true
+}
+
+func (d *Drainer) leave() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.inFlight--
+	if d.draining && d.inFlight == 0 {
+		close(d.idle)
+	}
+}
+
+// Draining reports whether shutdown started
+func (d *Drainer) Draining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// Drain refuses new requests and waits for the in-flight ones. When ctx is
+// done first their context is canceled and Drain returns the ctx error once
+// they returned.
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.draining {
+		d.draining = true
+		if d.inFlight == 0 {
+			close(d.idle)
+		}
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.idle:
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		<-d.idle
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,873 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type accounts struct {
+	t *tx
+}
+
+func (r accounts) Create(ctx context.Context, name string) (storage.Account, error) {
+	account := storage.Account{Id: uuid.New(), Name: name}
+	r.t.store.accounts[account.Id] = account
+	r.t.onRollback(func() { delete(r.t.store.accounts, account.Id) })
+	return account, nil
+}
+
+func (r accounts) Get(ctx context.Context, id uuid.UUID) (storage.Account, error) {
+	account, ok := r.t.store.accounts[id]
+	if !ok {
+		return storage.Account{}, storage.ErrNotFound
+	}
+	return account, nil
+}
+
+func (r accounts) List(ctx context.Context, page storage.Page) ([]storage.Account, error) {
+	accounts := make([]storage.Account, 0, len(r.t.store.accounts))
+	for _, account := range r.t.store.accounts {
+		accounts = append(accounts, account)
+	}
+	return paginate(accounts, page, storage.SortById, storage.SortByName), nil
+}
+
+type balances struct {
+	t *tx
+}
+
+func (r balances) Get(ctx context.Context, accountId, assetId uuid.UUID) (*decimal.Decimal, error) {
+	if _, ok := r.t.store.assets[assetId]; !ok {
+		return nil, storage.ErrNotFound
+	}
+	balance, ok := r.t.store.balances[balanceKey{accountId, assetId}]
+	if !ok {
+		return nil, nil
+	}
+	value := balance.Balance
+	return &value, nil
+}
+
+func (r balances) Create(ctx context.Context, accountId, assetId uuid.UUID) error {
+	key := balanceKey{accountId, assetId}
+	if _, ok := r.t.store.balances[key]; ok {
+		return nil
+	}
+	if _, ok := r.t.store.accounts[accountId]; !ok {
+		return fmt.Errorf("account %s does not exist", accountId)
+	}
+
+	r.t.store.balances[key] = &storage.AccountBalance{
+		Id:        uuid.New(),
+		AccountId: accountId,
+		AssetId:   assetId,
+		AssetCode: r.t.store.assets[assetId].Code,
+		Balance:   decimal.Zero,
+	}
+	r.t.onRollback(func() { delete(r.t.store.balances, key) })
+	return nil
+}
+
+func (r balances) Update(ctx context.Context, accountId, assetId uuid.UUID, balance decimal.Decimal) error {
+	current, ok := r.t.store.balances[balanceKey{accountId, assetId}]
+	if !ok {
+		return nil
+	}
+	previous := current.Balance
+	current.Balance = balance
+	r.t.onRollback(func() { current.Balance = previous })
+	return nil
+}
+
+func (r balances) ListByAccount(ctx context.Context, accountId uuid.UUID) ([]storage.AccountBalance, error) {
+	balances := []storage.AccountBalance{}
+	for key, balance := range r.t.store.balances {
+		if key.accountId == accountId {
+			balances = append(balances, *balance)
+		}
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].AssetCode < balances[j].AssetCode
+	})
+	return balances, nil
+}
+
+func (r balances) List(ctx context.Context) ([]storage.AccountBalance, error) {
+	balances := []storage.AccountBalance{}
+	for _, balance := range r.t.store.balances {
+		balances = append(balances, *balance)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].AccountId != balances[j].AccountId {
+			return balances[i].AccountId.String() < balances[j].AccountId.String()
+		}
+		return balances[i].AssetCode < balances[j].AssetCode
+	})
+	return balances, nil
+}
+
+type movements struct {
+	t *tx
+}
+
+func (r movements) Create(ctx context.Context, movement storage.Movement) (storage.Movement, error) {
+	movement.Id = uuid.New()
+	movement.CreatedAt = r.t.store.now()
+	r.t.store.movements = append(r.t.store.movements, movement)
+	r.t.onRollback(func() { r.t.store.movements = r.t.store.movements[:len(r.t.store.movements)-1] })
+	return movement, nil
+}
+
+func (r movements) NetTotals(ctx context.Context) ([]storage.AccountAmount, error) {
+	totals := accountAmounts{}
+	for _, movement := range r.t.store.movements {
+		amount := movement.Amount
+		if movement.Kind != storage.Deposit && movement.Kind != storage.TransferIn {
+			amount = amount.Neg()
+		}
+		totals.add(movement.AccountId, movement.AssetId, amount)
+	}
+	return totals.list(), nil
+}
+
+// accountAmounts sums amounts per account and asset
+type accountAmounts map[balanceKey]decimal.Decimal
+
+func (a accountAmounts) add(accountId, assetId uuid.UUID, amount decimal.Decimal) {
+	key := balanceKey{accountId, assetId}
+	a[key] = a[key].Add(amount)
+}
+
+func (a accountAmounts) list() []storage.AccountAmount {
+	amounts := make([]storage.AccountAmount, 0, len(a))
+	for key, amount := range a {
+		amounts = append(amounts, storage.AccountAmount{AccountId: key.accountId, AssetId: key.assetId, Amount: amount})
+	}
+	return amounts
+}
+
+type assets struct {
+	t *tx
+}
+
+func (r assets) Get(ctx context.Context, id uuid.UUID) (storage.Asset, error) {
+	asset, ok := r.t.store.assets[id]
+	if !ok {
+		return storage.Asset{}, storage.ErrNotFound
+	}
+	return asset, nil
+}
+
+func (r assets) GetByCode(ctx context.Context, code string) (storage.Asset, error) {
+	for _, asset := range r.t.store.assets {
+		if asset.Code == code {
+			return asset, nil
+		}
+	}
+	return storage.Asset{}, storage.ErrNotFound
+}
+
+type instruments struct {
+	t *tx
+}
+
+func (r instruments) List(ctx context.Context) ([]storage.Instrument, error) {
+	instruments := make([]storage.Instrument, 0, len(r.t.store.instruments))
+	for _, instrument := range r.t.store.instruments {
+		instruments = append(instruments, *instrument)
+	}
+	sort.Slice(instruments, func(i, j int) bool {
+		if instruments[i].BaseAssetCode != instruments[j].BaseAssetCode {
+			return instruments[i].BaseAssetCode < instruments[j].BaseAssetCode
+		}
+		return instruments[i].QuoteAssetCode < instruments[j].QuoteAssetCode
+	})
+	return instruments, nil
+}
+
+func (r instruments) Get(ctx context.Context, id uuid.UUID) (storage.Instrument, error) {
+	instrument, ok := r.t.store.instruments[id]
+	if !ok {
+		return storage.Instrument{}, storage.ErrNotFound
+	}
+	return *instrument, nil
+}
+
+func (r instruments) GetByBaseAssetCode(ctx context.Context, code string) (storage.Instrument, error) {
+	for _, instrument := range r.t.store.instruments {
+		if instrument.BaseAssetCode == code {
+			return *instrument, nil
+		}
+	}
+	return storage.Instrument{}, storage.ErrNotFound
+}
+
+func (r instruments) UpdateTradingStatus(ctx context.Context, id uuid.UUID, status storage.TradingStatus) error {
+	instrument, ok := r.t.store.instruments[id]
+	if !ok {
+		return nil
+	}
+	previous := instrument.TradingStatus
+	instrument.TradingStatus = status
+	r.t.onRollback(func() { instrument.TradingStatus = previous })
+	return nil
+}
+
+type orders struct {
+	t *tx
+}
+
+func (r orders) Create(ctx context.Context, order storage.Order) (storage.Order, error) {
+	if _, ok := r.t.store.accounts[order.AccountId]; !ok {
+		return storage.Order{}, fmt.Errorf("account %s does not exist", order.AccountId)
+	}
+
+	order.Id = uuid.New()
+	order.CreatedAt = r.t.store.now()
+	stored := order
+	r.t.store.orders[order.Id] = &stored
+	r.t.store.orderIds = append(r.t.store.orderIds, order.Id)
+	r.t.onRollback(func() {
+		delete(r.t.store.orders, order.Id)
+		r.t.store.orderIds = r.t.store.orderIds[:len(r.t.store.orderIds)-1]
+	})
+	return order, nil
+}
+
+func (r orders) Get(ctx context.Context, id uuid.UUID) (storage.Order, error) {
+	order, ok := r.t.store.orders[id]
+	if !ok {
+		if order, ok = r.t.store.history[id]; !ok {
+			return storage.Order{}, storage.ErrNotFound
+		}
+	}
+	return *order, nil
+}
+
+func (r orders) List(ctx context.Context, filter storage.OrderFilter, page storage.Page) ([]storage.Order, error) {
+	return paginate(r.filter(filter), page, storage.SortByCreatedAt, storage.SortByPrice), nil
+}
+
+func (r orders) History(ctx context.Context, filter storage.OrderFilter, page storage.Page) ([]storage.Order, error) {
+	archived := filterOrders(r.t.store.history, r.t.store.historyIds, filter)
+	return paginate(archived, page, storage.SortByCreatedAt, storage.SortByPrice), nil
+}
+
+func (r orders) Archive(ctx context.Context, limit int) (int, error) {
+	store := r.t.store
+	previousIds, previousHistoryIds := store.orderIds, store.historyIds
+
+	var live, archived []uuid.UUID
+	for _, id := range store.orderIds {
+		if len(archived) < limit && slices.Contains(storage.FinalStatuses, store.orders[id].Status) {
+			archived = append(archived, id)
+			continue
+		}
+		live = append(live, id)
+	}
+	for _, id := range archived {
+		store.history[id] = store.orders[id]
+		delete(store.orders, id)
+	}
+	store.orderIds = live
+	store.historyIds = append(slices.Clip(store.historyIds), archived...)
+
+	r.t.onRollback(func() {
+		for _, id := range archived {
+			store.orders[id] = store.history[id]
+			delete(store.history, id)
+		}
+		store.orderIds, store.historyIds = previousIds, previousHistoryIds
+	})
+	return len(archived), nil
+}
+
+func (r orders) Count(ctx context.Context, filter storage.OrderFilter) (int, error) {
+	return len(r.filter(filter)), nil
+}
+
+func (r orders) ListWorking(ctx context.Context, instrumentId uuid.UUID) ([]storage.Order, error) {
+	return r.filter(storage.OrderFilter{InstrumentId: &instrumentId, Statuses: storage.WorkingStatuses}), nil
+}
+
+func (r orders) ListCompatible(ctx context.Context, order storage.Order) ([]storage.Order, error) {
+	side := storage.Sell
+	if order.Type == storage.Sell {
+		side = storage.Buy
+	}
+
+	var compatible []storage.Order
+	for _, candidate := range r.filter(storage.OrderFilter{InstrumentId: &order.InstrumentId, Type: &side, Statuses: storage.WorkingStatuses}) {
+		if (side == storage.Sell && candidate.Price.LessThanOrEqual(order.Price)) ||
+			(side == storage.Buy && candidate.Price.GreaterThanOrEqual(order.Price)) {
+			compatible = append(compatible, candidate)
+		}
+	}
+
+	// Best price first, the filter already returns them in time priority
+	sort.SliceStable(compatible, func(i, j int) bool {
+		if side == storage.Sell {
+			return compatible[i].Price.LessThan(compatible[j].Price)
+		}
+		return compatible[i].Price.GreaterThan(compatible[j].Price)
+	})
+	return compatible, nil
+}
+
+func (r orders) BestPrices(ctx context.Context, instrumentId uuid.UUID) (*decimal.Decimal, *decimal.Decimal, error) {
+	var bestBid, bestAsk *decimal.Decimal
+	for _, order := range r.filter(storage.OrderFilter{InstrumentId: &instrumentId, Statuses: storage.WorkingStatuses}) {
+		price := order.Price
+		if order.Type == storage.Buy && (bestBid == nil || price.GreaterThan(*bestBid)) {
+			bestBid = &price
+		}
+		if order.Type == storage.Sell && (bestAsk == nil || price.LessThan(*bestAsk)) {
+			bestAsk = &price
+		}
+	}
+	return bestBid, bestAsk, nil
+}
+
+func (r orders) Levels(ctx context.Context, instrumentId uuid.UUID, side storage.OrderType, limit int) ([]storage.PriceLevel, error) {
+	levels := []storage.PriceLevel{}
+	index := map[string]int{}
+	for _, order := range r.filter(storage.OrderFilter{InstrumentId: &instrumentId, Type: &side, Statuses: storage.WorkingStatuses}) {
+		i, ok := index[order.Price.String()]
+		if !ok {
+			i = len(levels)
+			index[order.Price.String()] = i
+			levels = append(levels, storage.PriceLevel{Price: order.Price})
+		}
+		levels[i].Quantity = levels[i].Quantity.Add(order.TotalQuantity.Sub(order.FilledQuantity))
+		levels[i].Orders++
+	}
+
+	// Best price first
+	sort.Slice(levels, func(i, j int) bool {
+		if side == storage.Sell {
+			return levels[i].Price.LessThan(levels[j].Price)
+		}
+		return levels[i].Price.GreaterThan(levels[j].Price)
+	})
+	if limit > 0 && len(levels) > limit {
+		levels = levels[:limit]
+	}
+	return levels, nil
+}
+
+func (r orders) UpdateFill(ctx context.Context, id uuid.UUID, filledQuantity decimal.Decimal, status storage.OrderStatus) error {
+	order, ok := r.t.store.orders[id]
+	if !ok {
+		return nil
+	}
+	previous := *order
+	order.FilledQuantity = filledQuantity
+	order.Status = status
+	r.t.onRollback(func() { *order = previous })
+	return nil
+}
+
+func (r orders) UpdateStatus(ctx context.Context, id uuid.UUID, status storage.OrderStatus) error {
+	order, ok := r.t.store.orders[id]
+	if !ok {
+		return nil
+	}
+	previous := order.Status
+	order.Status = status
+	r.t.onRollback(func() { order.Status = previous })
+	return nil
+}
+
+// filter returns the matching live orders in creation order
+func (r orders) filter(filter storage.OrderFilter) []storage.Order {
+	return filterOrders(r.t.store.orders, r.t.store.orderIds, filter)
+}
+
+// filterOrders returns the orders of ids matching the filter, in the order of ids
+func filterOrders(stored map[uuid.UUID]*storage.Order, ids []uuid.UUID, filter storage.OrderFilter) []storage.Order {
+	orders := []storage.Order{}
+	for _, id := range ids {
+		order := stored[id]
+		if filter.AccountId != nil && order.AccountId != *filter.AccountId {
+			continue
+		}
+		if filter.InstrumentId != nil && order.InstrumentId != *filter.InstrumentId {
+			continue
+		}
+		if filter.Type != nil && order.Type != *filter.Type {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, order.Status) {
+			continue
+		}
+		if filter.MinPrice != nil && order.Price.LessThan(*filter.MinPrice) ||
+			filter.MaxPrice != nil && order.Price.GreaterThan(*filter.MaxPrice) {
+			continue
+		}
+		if filter.CreatedFrom != nil && order.CreatedAt.Before(*filter.CreatedFrom) ||
+			filter.CreatedTo != nil && !order.CreatedAt.Before(*filter.CreatedTo) {
+			continue
+		}
+		orders = append(orders, *order)
+	}
+	return orders
+}
+
+type trades struct {
+	t *tx
+}
+
+func (r trades) Create(ctx context.Context, trade storage.Trade) (storage.Trade, error) {
+	trade.Id = uuid.New()
+	trade.CreatedAt = r.t.store.now()
+	r.t.store.trades = append(r.t.store.trades, trade)
+	r.t.onRollback(func() { r.t.store.trades = r.t.store.trades[:len(r.t.store.trades)-1] })
+	return trade, nil
+}
+
+func (r trades) Last(ctx context.Context, instrumentId uuid.UUID) (*storage.Trade, error) {
+	for i := len(r.t.store.trades) - 1; i >= 0; i-- {
+		if r.t.store.trades[i].InstrumentId == instrumentId {
+			trade := r.t.store.trades[i]
+			return &trade, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r trades) VolumeSince(ctx context.Context, instrumentId uuid.UUID, since time.Time) (decimal.Decimal, error) {
+	volume := decimal.Zero
+	for _, trade := range r.t.store.trades {
+		if trade.InstrumentId == instrumentId && !trade.CreatedAt.Before(since) {
+			volume = volume.Add(trade.Quantity)
+		}
+	}
+	return volume, nil
+}
+
+func (r trades) NetFlows(ctx context.Context) ([]storage.AccountAmount, error) {
+	flows := accountAmounts{}
+	for _, trade := range r.t.store.trades {
+		instrument := r.t.store.instruments[trade.InstrumentId]
+		buyer := r.t.store.order(trade.BuyOrderId).AccountId
+		seller := r.t.store.order(trade.SellOrderId).AccountId
+		notional := trade.Quantity.Mul(trade.Price)
+
+		flows.add(buyer, instrument.BaseAssetId, trade.Quantity)
+		flows.add(buyer, instrument.QuoteAssetId, notional.Neg())
+		flows.add(seller, instrument.BaseAssetId, trade.Quantity.Neg())
+		flows.add(seller, instrument.QuoteAssetId, notional)
+	}
+	return flows.list(), nil
+}
+
+type events struct {
+	t *tx
+}
+
+// Append stores the event right away, units of work are serialized so
+// sequences are committed in order
+func (r events) Append(ctx context.Context, event storage.Event) error {
+	event.Sequence = int64(len(r.t.store.events)) + 1
+	event.CreatedAt = r.t.store.now()
+	r.t.store.events = append(r.t.store.events, event)
+	r.t.onRollback(func() { r.t.store.events = r.t.store.events[:len(r.t.store.events)-1] })
+	return nil
+}
+
+// Lock does nothing, units of work are already serialized
+func (r events) Lock(ctx context.Context) error {
+	return nil
+}
+
+func (r events) List(ctx context.Context, after, upTo int64, limit int) ([]storage.Event, error) {
+	events := []storage.Event{}
+	for _, event := range r.t.store.events {
+		if event.Sequence <= after || (upTo >= 0 && event.Sequence > upTo) {
+			continue
+		}
+		if len(events) == limit {
+			break
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (r events) ListByOrder(ctx context.Context, orderId uuid.UUID) ([]storage.Event, error) {
+	events := []storage.Event{}
+	for _, event := range r.t.store.events {
+		if event.AggregateId == orderId {
+			events = append(events, event)
+			continue
+		}
+		// Matches are appended on the instrument and replacements on the new
+		// order, their payload names the order
+		if event.Type != "order_matched" && event.Type != "order_accepted" {
+			continue
+		}
+		var payload struct {
+			BuyOrderId  uuid.UUID  `json:"buy_order_id"`
+			SellOrderId uuid.UUID  `json:"sell_order_id"`
+			Replaces    *uuid.UUID `json:"replaces"`
+		}
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		matched := event.Type == "order_matched" && (payload.BuyOrderId == orderId || payload.SellOrderId == orderId)
+		replaced := event.Type == "order_accepted" && payload.Replaces != nil && *payload.Replaces == orderId
+		if matched || replaced {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (r events) Last(ctx context.Context) (int64, error) {
+	return int64(len(r.t.store.events)), nil
+}
+
+func (r events) SaveSnapshot(ctx context.Context, snapshot storage.Snapshot) error {
+	for _, existing := range r.t.store.snapshots {
+		if existing.Sequence == snapshot.Sequence {
+			return nil
+		}
+	}
+
+	snapshot.CreatedAt = r.t.store.now()
+	r.t.store.snapshots = append(r.t.store.snapshots, snapshot)
+	sort.Slice(r.t.store.snapshots, func(i, j int) bool {
+		return r.t.store.snapshots[i].Sequence < r.t.store.snapshots[j].Sequence
+	})
+	r.t.onRollback(func() {
+		r.t.store.snapshots = slices.DeleteFunc(r.t.store.snapshots, func(s storage.Snapshot) bool {
+			return s.Sequence == snapshot.Sequence
+		})
+	})
+	return nil
+}
+
+func (r events) LatestSnapshot(ctx context.Context, atOrBefore int64) (*storage.Snapshot, error) {
+	for i := len(r.t.store.snapshots) - 1; i >= 0; i-- {
+		snapshot := r.t.store.snapshots[i]
+		if atOrBefore < 0 || snapshot.Sequence <= atOrBefore {
+			return &snapshot, nil
+		}
+	}
+	return nil, nil
+}
+
+type audit struct {
+	t *tx
+}
+
+func (r audit) Append(ctx context.Context, entry storage.AuditEntry) (storage.AuditEntry, error) {
+	entry.Id = int64(len(r.t.store.audit)) + 1
+	entry.CreatedAt = r.t.store.now()
+	r.t.store.audit = append(r.t.store.audit, entry)
+	r.t.onRollback(func() { r.t.store.audit = r.t.store.audit[:len(r.t.store.audit)-1] })
+	return entry, nil
+}
+
+func (r audit) List(ctx context.Context, filter storage.AuditFilter, page storage.Page) ([]storage.AuditEntry, error) {
+	return paginate(r.filter(filter), page, storage.SortById), nil
+}
+
+func (r audit) filter(filter storage.AuditFilter) []storage.AuditEntry {
+	matches := func(value string, want *string) bool {
+		return want == nil || value == *want
+	}
+	entries := []storage.AuditEntry{}
+	for _, entry := range r.t.store.audit {
+		if matches(entry.Actor, filter.Actor) && matches(entry.Action, filter.Action) &&
+			matches(entry.EntityType, filter.EntityType) && matches(entry.EntityId, filter.EntityId) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+type fix struct {
+	t *tx
+}
+
+func (r fix) GetSession(ctx context.Context, id string) (storage.FixSession, error) {
+	session, ok := r.t.store.fixSessions[id]
+	if !ok {
+		return storage.FixSession{}, storage.ErrNotFound
+	}
+	return session, nil
+}
+
+func (r fix) ListSessions(ctx context.Context) ([]storage.FixSession, error) {
+	sessions := make([]storage.FixSession, 0, len(r.t.store.fixSessions))
+	for _, session := range r.t.store.fixSessions {
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Id < sessions[j].Id })
+	return sessions, nil
+}
+
+func (r fix) SaveSession(ctx context.Context, session storage.FixSession) error {
+	previous, existed := r.t.store.fixSessions[session.Id]
+	session.UpdatedAt = r.t.store.now()
+	r.t.store.fixSessions[session.Id] = session
+	r.t.onRollback(func() {
+		if existed {
+			r.t.store.fixSessions[session.Id] = previous
+		} else {
+			delete(r.t.store.fixSessions, session.Id)
+		}
+	})
+	return nil
+}
+
+func (r fix) AppendMessage(ctx context.Context, message storage.FixMessage) error {
+	message.CreatedAt = r.t.store.now()
+	messages := r.t.store.fixMessages[message.SessionId]
+	r.t.store.fixMessages[message.SessionId] = append(messages, message)
+	r.t.onRollback(func() { r.t.store.fixMessages[message.SessionId] = messages })
+	return nil
+}
+
+func (r fix) ListMessages(ctx context.Context, sessionId string, from, to int) ([]storage.FixMessage, error) {
+	messages := []storage.FixMessage{}
+	for _, message := range r.t.store.fixMessages[sessionId] {
+		if message.Seq >= from && (to == 0 || message.Seq <= to) {
+			messages = append(messages, message)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Seq < messages[j].Seq })
+	return messages, nil
+}
+
+func (r fix) DeleteMessages(ctx context.Context, sessionId string) error {
+	messages := r.t.store.fixMessages[sessionId]
+	delete(r.t.store.fixMessages, sessionId)
+	r.t.onRollback(func() { r.t.store.fixMessages[sessionId] = messages })
+	return nil
+}
+
+func (r fix) CreateOrder(ctx context.Context, order storage.FixOrder) error {
+	if _, err := r.GetOrderByClOrdId(ctx, order.SessionId, order.ClOrdId); err == nil {
+		return fmt.Errorf("duplicate client order id %s", order.ClOrdId)
+	}
+	order.CreatedAt = r.t.store.now()
+	r.t.store.fixOrders[order.OrderId] = order
+	r.t.onRollback(func() { delete(r.t.store.fixOrders, order.OrderId) })
+	return nil
+}
+
+func (r fix) GetOrder(ctx context.Context, orderId uuid.UUID) (storage.FixOrder, error) {
+	order, ok := r.t.store.fixOrders[orderId]
+	if !ok {
+		return storage.FixOrder{}, storage.ErrNotFound
+	}
+	return order, nil
+}
+
+func (r fix) GetOrderByClOrdId(ctx context.Context, sessionId, clOrdId string) (storage.FixOrder, error) {
+	for _, order := range r.t.store.fixOrders {
+		if order.SessionId == sessionId && order.ClOrdId == clOrdId {
+			return order, nil
+		}
+	}
+	return storage.FixOrder{}, storage.ErrNotFound
+}
+
+func (r fix) UpdateOrder(ctx context.Context, order storage.FixOrder) error {
+	previous, ok := r.t.store.fixOrders[order.OrderId]
+	if !ok {
+		return nil
+	}
+	r.t.store.fixOrders[order.OrderId] = order
+	r.t.onRollback(func() { r.t.store.fixOrders[order.OrderId] = previous })
+	return nil
+}
+
+type fundings struct {
+	t *tx
+}
+
+func (r fundings) Create(ctx context.Context, funding storage.Funding) (storage.Funding, error) {
+	if _, ok := r.t.store.accounts[funding.AccountId]; !ok {
+		return storage.Funding{}, fmt.Errorf("account %s does not exist", funding.AccountId)
+	}
+	asset, ok := r.t.store.assets[funding.AssetId]
+	if !ok {
+		return storage.Funding{}, fmt.Errorf("asset %s does not exist", funding.AssetId)
+	}
+
+	funding.Id = uuid.New()
+	funding.AssetCode = asset.Code
+	funding.CreatedAt = r.t.store.now()
+	funding.UpdatedAt = funding.CreatedAt
+	stored := funding
+	r.t.store.fundings[funding.Id] = &stored
+	r.t.store.fundingIds = append(r.t.store.fundingIds, funding.Id)
+	r.t.onRollback(func() {
+		delete(r.t.store.fundings, funding.Id)
+		r.t.store.fundingIds = r.t.store.fundingIds[:len(r.t.store.fundingIds)-1]
+	})
+	return funding, nil
+}
+
+func (r fundings) Get(ctx context.Context, id uuid.UUID) (storage.Funding, error) {
+	funding, ok := r.t.store.fundings[id]
+	if !ok {
+		return storage.Funding{}, storage.ErrNotFound
+	}
+	return *funding, nil
+}
+
+func (r fundings) List(ctx context.Context, filter storage.FundingFilter, page storage.Page) ([]storage.Funding, error) {
+	fundings := []storage.Funding{}
+	for _, id := range r.t.store.fundingIds {
+		funding := r.t.store.fundings[id]
+		if filter.AccountId != nil && funding.AccountId != *filter.AccountId {
+			continue
+		}
+		if filter.Kind != nil && funding.Kind != *filter.Kind {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, funding.Status) {
+			continue
+		}
+		fundings = append(fundings, *funding)
+	}
+	return paginate(fundings, page, storage.SortByCreatedAt), nil
+}
+
+func (r fundings) Update(ctx context.Context, funding storage.Funding) (storage.Funding, error) {
+	stored, ok := r.t.store.fundings[funding.Id]
+	if !ok {
+		return storage.Funding{}, storage.ErrNotFound
+	}
+	previous := *stored
+	stored.Status = funding.Status
+	stored.Reference = funding.Reference
+	stored.Reason = funding.Reason
+	stored.ReviewedBy = funding.ReviewedBy
+	stored.UpdatedAt = r.t.store.now()
+	r.t.onRollback(func() { *stored = previous })
+	return *stored, nil
+}
+
+type transfers struct {
+	t *tx
+}
+
+func (r transfers) Create(ctx context.Context, transfer storage.Transfer) (storage.Transfer, error) {
+	for _, accountId := range []uuid.UUID{transfer.FromAccountId, transfer.ToAccountId} {
+		if _, ok := r.t.store.accounts[accountId]; !ok {
+			return storage.Transfer{}, fmt.Errorf("account %s does not exist", accountId)
+		}
+	}
+	asset, ok := r.t.store.assets[transfer.AssetId]
+	if !ok {
+		return storage.Transfer{}, fmt.Errorf("asset %s does not exist", transfer.AssetId)
+	}
+	if transfer.IdempotencyKey != "" {
+		if _, err := r.GetByIdempotencyKey(ctx, transfer.FromAccountId, transfer.IdempotencyKey); err == nil {
+			return storage.Transfer{}, storage.ErrDuplicate
+		}
+	}
+
+	transfer.Id = uuid.New()
+	transfer.AssetCode = asset.Code
+	transfer.CreatedAt = r.t.store.now()
+	r.t.store.transfers = append(r.t.store.transfers, transfer)
+	r.t.onRollback(func() { r.t.store.transfers = r.t.store.transfers[:len(r.t.store.transfers)-1] })
+	return transfer, nil
+}
+
+func (r transfers) Get(ctx context.Context, id uuid.UUID) (storage.Transfer, error) {
+	for _, transfer := range r.t.store.transfers {
+		if transfer.Id == id {
+			return transfer, nil
+		}
+	}
+	return storage.Transfer{}, storage.ErrNotFound
+}
+
+func (r transfers) GetByIdempotencyKey(ctx context.Context, fromAccountId uuid.UUID, key string) (storage.Transfer, error) {
+	for _, transfer := range r.t.store.transfers {
+		if transfer.FromAccountId == fromAccountId && transfer.IdempotencyKey == key {
+			return transfer, nil
+		}
+	}
+	return storage.Transfer{}, storage.ErrNotFound
+}
+
+func (r transfers) List(ctx context.Context, filter storage.TransferFilter, page storage.Page) ([]storage.Transfer, error) {
+	transfers := []storage.Transfer{}
+	for _, transfer := range r.t.store.transfers {
+		if filter.AccountId != nil && transfer.FromAccountId != *filter.AccountId && transfer.ToAccountId != *filter.AccountId {
+			continue
+		}
+		transfers = append(transfers, transfer)
+	}
+	return paginate(transfers, page, storage.SortByCreatedAt), nil
+}
+
+// paginate sorts the items like the postgres store does and returns the page
+// of them. fields are the sort fields of the list, the first is the default.
+func paginate[T storage.Keyed](items []T, page storage.Page, fields ...string) []T {
+	field := page.Sort.Field
+	if !slices.Contains(fields, field) {
+		field = fields[0]
+	}
+	compare := func(a, b storage.Key) int {
+		order := cmp.Or(compareValues(field, a.Value, b.Value), compareIds(a.Id, b.Id))
+		if page.Sort.Desc {
+			return -order
+		}
+		return order
+	}
+
+	slices.SortFunc(items, func(a, b T) int {
+		return compare(a.Key(field), b.Key(field))
+	})
+	if page.After != nil {
+		items = slices.DeleteFunc(items, func(item T) bool {
+			return compare(item.Key(field), *page.After) <= 0
+		})
+	}
+	if page.Limit > 0 && len(items) > page.Limit {
+		items = items[:page.Limit]
+	}
+	return items
+}
+
+// compareValues compares two values of the sort field, as written in keys
+func compareValues(field, a, b string) int {
+	switch field {
+	case storage.SortByCreatedAt:
+		aTime, _ := time.Parse(time.RFC3339Nano, a)
+		bTime, _ := time.Parse(time.RFC3339Nano, b)
+		return aTime.Compare(bTime)
+	case storage.SortByPrice:
+		aPrice, _ := decimal.NewFromString(a)
+		bPrice, _ := decimal.NewFromString(b)
+		return aPrice.Cmp(bPrice)
+	case storage.SortById:
+		return compareIds(a, b)
+	}
+	return strings.Compare(a, b)
+}
+
+// compareIds orders ids like postgres does, they are uuids, all of the same
+// length, or integers, shorter ones being smaller
+func compareIds(a, b string) int {
+	return cmp.Or(cmp.Compare(len(a), len(b)), strings.Compare(a, b))
+}
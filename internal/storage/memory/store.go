@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+)
+
+type balanceKey struct {
+	accountId uuid.UUID
+	assetId   uuid.UUID
+}
+
+// Store keeps everything in memory. Units of work are serialized and undone
+// on rollback, giving the same guarantees as the Postgres store.
+type Store struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	assets      map[uuid.UUID]storage.Asset
+	accounts    map[uuid.UUID]storage.Account
+	balances    map[balanceKey]*storage.AccountBalance
+	instruments map[uuid.UUID]*storage.Instrument
+	orders      map[uuid.UUID]*storage.Order
+	orderIds    []uuid.UUID
+	history     map[uuid.UUID]*storage.Order
+	historyIds  []uuid.UUID
+	trades      []storage.Trade
+	movements   []storage.Movement
+	events      []storage.Event
+	snapshots   []storage.Snapshot
+	audit       []storage.AuditEntry
+	fixSessions map[string]storage.FixSession
+	fixMessages map[string][]storage.FixMessage
+	fixOrders   map[uuid.UUID]storage.FixOrder
+	fundings    map[uuid.UUID]*storage.Funding
+	fundingIds  []uuid.UUID
+	transfers   []storage.Transfer
+}
+
+// New returns a store seeded like the initial migration, with the BTC and BRL
+// assets and the BTC/BRL instrument
+func New() *Store {
+	s := NewEmpty()
+	btc := s.AddAsset("BTC", "Bitcoin")
+	brl := s.AddAsset("BRL", "Brazilian Real")
+	s.AddInstrument(btc, brl)
+	return s
+}
+
+func NewEmpty() *Store {
+	return &Store{
+		now:         time.Now,
+		assets:      make(map[uuid.UUID]storage.Asset),
+		accounts:    make(map[uuid.UUID]storage.Account),
+		balances:    make(map[balanceKey]*storage.AccountBalance),
+		instruments: make(map[uuid.UUID]*storage.Instrument),
+		orders:      make(map[uuid.UUID]*storage.Order),
+		history:     make(map[uuid.UUID]*storage.Order),
+		fixSessions: make(map[string]storage.FixSession),
+		fixMessages: make(map[string][]storage.FixMessage),
+		fixOrders:   make(map[uuid.UUID]storage.FixOrder),
+		fundings:    make(map[uuid.UUID]*storage.Funding),
+	}
+}
+
+// SetClock replaces the time source used for created_at values
+func (s *Store) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+func (s *Store) AddAsset(code, name string) storage.Asset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset := storage.Asset{Id: uuid.New(), Code: code, Name: name}
+	s.assets[asset.Id] = asset
+	return asset
+}
+
+func (s *Store) AddInstrument(base, quote storage.Asset) storage.Instrument {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instrument := storage.Instrument{
+		Id:             uuid.New(),
+		BaseAssetId:    base.Id,
+		BaseAssetCode:  base.Code,
+		QuoteAssetId:   quote.Id,
+		QuoteAssetCode: quote.Code,
+		TradingStatus:  storage.TradingOpen,
+	}
+	s.instruments[instrument.Id] = &instrument
+	return instrument
+}
+
+// order returns the order of id, live or archived
+func (s *Store) order(id uuid.UUID) *storage.Order {
+	if order, ok := s.orders[id]; ok {
+		return order
+	}
+	return s.history[id]
+}
+
+func (s *Store) WithTx(ctx context.Context, fn func(tx storage.Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := &tx{store: s}
+	if err := fn(t); err != nil {
+		t.rollback()
+		return err
+	}
+	return nil
+}
+
+func (s *Store) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (s *Store) Close() {}
+
+type tx struct {
+	store *Store
+	undo  []func()
+}
+
+func (t *tx) onRollback(undo func()) {
+	t.undo = append(t.undo, undo)
+}
+
+func (t *tx) rollback() {
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.undo[i]()
+	}
+	t.undo = nil
+}
+
+func (t *tx) Accounts() storage.AccountRepository       { return accounts{t} }
+func (t *tx) Balances() storage.BalanceRepository       { return balances{t} }
+func (t *tx) Assets() storage.AssetRepository           { return assets{t} }
+func (t *tx) Instruments() storage.InstrumentRepository { return instruments{t} }
+func (t *tx) Orders() storage.OrderRepository           { return orders{t} }
+func (t *tx) Trades() storage.TradeRepository           { return trades{t} }
+func (t *tx) Movements() storage.MovementRepository     { return movements{t} }
+func (t *tx) Events() storage.EventRepository           { return events{t} }
+func (t *tx) Audit() storage.AuditRepository            { return audit{t} }
+func (t *tx) Fix() storage.FixRepository                { return fix{t} }
+func (t *tx) Fundings() storage.FundingRepository       { return fundings{t} }
+func (t *tx) Transfers() storage.TransferRepository     { return transfers{t} }
@@ -0,0 +1,331 @@
+package storage
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Asset struct {
+	Id   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+	Name string    `json:"name"`
+}
+
+type Account struct {
+	Id   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type AccountBalance struct {
+	Id        uuid.UUID       `json:"id"`
+	AccountId uuid.UUID       `json:"account_id"`
+	AssetId   uuid.UUID       `json:"asset_id"`
+	AssetCode string          `json:"asset_code"`
+	Balance   decimal.Decimal `json:"balance"`
+}
+
+type TradingStatus string
+
+const (
+	TradingPreOpen    TradingStatus = "pre_open"
+	TradingOpen       TradingStatus = "open"
+	TradingAuction    TradingStatus = "auction"
+	TradingHalted     TradingStatus = "halted"
+	TradingCancelOnly TradingStatus = "cancel_only"
+	TradingClosed     TradingStatus = "closed"
+)
+
+var tradingStatusTransitions = map[TradingStatus][]TradingStatus{
+	TradingPreOpen:    {TradingOpen, TradingAuction, TradingClosed},
+	TradingOpen:       {TradingAuction, TradingHalted, TradingCancelOnly, TradingClosed},
+	TradingAuction:    {TradingOpen, TradingHalted, TradingClosed},
+	TradingHalted:     {TradingOpen, TradingAuction, TradingCancelOnly, TradingClosed},
+	TradingCancelOnly: {TradingOpen, TradingHalted, TradingClosed},
+	TradingClosed:     {TradingPreOpen},
+}
+
+func (s TradingStatus) Valid() bool {
+	_, ok := tradingStatusTransitions[s]
+	return ok
+}
+
+func (s TradingStatus) AcceptsOrders() bool {
+	return s == TradingOpen || s == TradingAuction
+}
+
+// Matches reports if incoming orders are matched, during auctions they only rest
+func (s TradingStatus) Matches() bool {
+	return s == TradingOpen
+}
+
+func (s TradingStatus) AcceptsCancels() bool {
+	return s != TradingClosed
+}
+
+func (s TradingStatus) CanTransitionTo(next TradingStatus) bool {
+	for _, allowed := range tradingStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Instrument is stored with the codes of its assets
+type Instrument struct {
+	Id             uuid.UUID     `json:"id"`
+	BaseAssetId    uuid.UUID     `json:"base_asset_id"`
+	BaseAssetCode  string        `json:"base_asset_code"`
+	QuoteAssetId   uuid.UUID     `json:"quote_asset_id"`
+	QuoteAssetCode string        `json:"quote_asset_code"`
+	TradingStatus  TradingStatus `json:"trading_status"`
+}
+
+type OrderType string
+
+const (
+	Buy  OrderType = "buy"
+	Sell OrderType = "sell"
+)
+
+type OrderStatus string
+
+const (
+	Open            OrderStatus = "open"
+	PartiallyFilled OrderStatus = "partially_filled"
+	FullFilled      OrderStatus = "full_filled"
+	Canceled        OrderStatus = "canceled"
+)
+
+// WorkingStatuses are the statuses of orders resting on the book
+var WorkingStatuses = []OrderStatus{Open, PartiallyFilled}
+
+// FinalStatuses are the statuses of orders that will not change anymore,
+// they are moved to the order history
+var FinalStatuses = []OrderStatus{FullFilled, Canceled}
+
+type Order struct {
+	Id             uuid.UUID       `json:"id"`
+	AccountId      uuid.UUID       `json:"account_id"`
+	InstrumentId   uuid.UUID       `json:"instrument_id"`
+	Type           OrderType       `json:"type"`
+	Status         OrderStatus     `json:"status"`
+	Price          decimal.Decimal `json:"price"`
+	TotalQuantity  decimal.Decimal `json:"total_quantity"`
+	FilledQuantity decimal.Decimal `json:"filled_quantity"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// PriceLevel is the working quantity of the orders resting at a price
+type PriceLevel struct {
+	Price    decimal.Decimal `json:"price"`
+	Quantity decimal.Decimal `json:"quantity"`
+	Orders   int             `json:"orders"`
+}
+
+type Trade struct {
+	Id           uuid.UUID       `json:"id"`
+	InstrumentId uuid.UUID       `json:"instrument_id"`
+	BuyOrderId   uuid.UUID       `json:"buy_order_id"`
+	SellOrderId  uuid.UUID       `json:"sell_order_id"`
+	Price        decimal.Decimal `json:"price"`
+	Quantity     decimal.Decimal `json:"quantity"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+type MovementKind string
+
+const (
+	Deposit    MovementKind = "deposit"
+	Withdrawal MovementKind = "withdrawal"
+	Fee        MovementKind = "fee"
+	// TransferIn and TransferOut are the two sides of a transfer between accounts
+	TransferIn  MovementKind = "transfer_in"
+	TransferOut MovementKind = "transfer_out"
+)
+
+// Movement is money entering or leaving the exchange or moved between its
+// accounts, the amount is always positive
+type Movement struct {
+	Id        uuid.UUID       `json:"id"`
+	AccountId uuid.UUID       `json:"account_id"`
+	AssetId   uuid.UUID       `json:"asset_id"`
+	Kind      MovementKind    `json:"kind"`
+	Amount    decimal.Decimal `json:"amount"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// AccountAmount is an amount of an asset aggregated per account
+type AccountAmount struct {
+	AccountId uuid.UUID       `json:"account_id"`
+	AssetId   uuid.UUID       `json:"asset_id"`
+	Amount    decimal.Decimal `json:"amount"`
+}
+
+type Event struct {
+	Sequence    int64     `json:"sequence"`
+	Type        string    `json:"type"`
+	AggregateId uuid.UUID `json:"aggregate_id"`
+	Payload     []byte    `json:"payload"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Snapshot struct {
+	Sequence  int64     `json:"sequence"`
+	State     []byte    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditEntry records a state changing action: who (actor) did what (action)
+// to which entity, with the entity as JSON before and after it (null when it
+// did not exist)
+type AuditEntry struct {
+	Id         int64     `json:"id"`
+	Actor      string    `json:"actor"`
+	Action     string    `json:"action"`
+	EntityType string    `json:"entity_type"`
+	EntityId   string    `json:"entity_id"`
+	Before     []byte    `json:"before"`
+	After      []byte    `json:"after"`
+	RequestId  string    `json:"request_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AuditFilter struct {
+	Actor      *string
+	Action     *string
+	EntityType *string
+	EntityId   *string
+}
+
+// FixSession is the state of a FIX session, keyed by the comp id of the
+// counterparty, so sequence numbers survive reconnects and restarts
+type FixSession struct {
+	Id string `json:"id"`
+	// NextSenderSeq is the sequence number of the next message sent
+	NextSenderSeq int `json:"next_sender_seq"`
+	// NextTargetSeq is the sequence number expected from the counterparty
+	NextTargetSeq int `json:"next_target_seq"`
+	// LastEvent is the last event log sequence reported on the session
+	LastEvent int64     `json:"last_event"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FixMessage is a message sent on a session, kept to answer resend requests
+type FixMessage struct {
+	SessionId string    `json:"session_id"`
+	Seq       int       `json:"seq"`
+	Type      string    `json:"type"`
+	Data      []byte    `json:"data"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FixOrder links an order to the session and client order id it was placed
+// with. OrderQty, CumQty and AvgPx are the order as the client sees it, they
+// carry over to the order replacing it.
+type FixOrder struct {
+	OrderId     uuid.UUID       `json:"order_id"`
+	SessionId   string          `json:"session_id"`
+	ClOrdId     string          `json:"cl_ord_id"`
+	OrigClOrdId string          `json:"orig_cl_ord_id"`
+	Symbol      string          `json:"symbol"`
+	Side        OrderType       `json:"side"`
+	Price       decimal.Decimal `json:"price"`
+	OrderQty    decimal.Decimal `json:"order_qty"`
+	CumQty      decimal.Decimal `json:"cum_qty"`
+	AvgPx       decimal.Decimal `json:"avg_px"`
+	// Replaced orders were canceled by a replace request
+	Replaced  bool      `json:"replaced"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FundingKind string
+
+const (
+	FundingDeposit    FundingKind = "deposit"
+	FundingWithdrawal FundingKind = "withdrawal"
+)
+
+type FundingStatus string
+
+const (
+	// FundingRequested is a funding received and not screened yet
+	FundingRequested       FundingStatus = "requested"
+	FundingPendingApproval FundingStatus = "pending_approval"
+	FundingApproved        FundingStatus = "approved"
+	// FundingProcessing is a funding submitted to the rail, waiting to settle
+	FundingProcessing FundingStatus = "processing"
+	FundingCompleted  FundingStatus = "completed"
+	FundingRejected   FundingStatus = "rejected"
+	FundingFailed     FundingStatus = "failed"
+)
+
+var fundingStatusTransitions = map[FundingStatus][]FundingStatus{
+	FundingRequested:       {FundingPendingApproval, FundingApproved},
+	FundingPendingApproval: {FundingApproved, FundingRejected},
+	FundingApproved:        {FundingProcessing},
+	FundingProcessing:      {FundingCompleted, FundingFailed},
+	FundingCompleted:       {},
+	FundingRejected:        {},
+	FundingFailed:          {},
+}
+
+func (s FundingStatus) Valid() bool {
+	_, ok := fundingStatusTransitions[s]
+	return ok
+}
+
+func (s FundingStatus) CanTransitionTo(next FundingStatus) bool {
+	return slices.Contains(fundingStatusTransitions[s], next)
+}
+
+// HoldingStatuses are the statuses of withdrawals still holding their amount,
+// taken from the balance on request and not yet paid out nor released
+var HoldingStatuses = []FundingStatus{FundingRequested, FundingPendingApproval, FundingApproved, FundingProcessing}
+
+// Funding is a deposit or withdrawal moving through its approval and the
+// custody rail. Reference is its id on the rail once submitted, Reason why it
+// was rejected or failed and ReviewedBy the operator who approved or rejected it.
+type Funding struct {
+	Id         uuid.UUID       `json:"id"`
+	AccountId  uuid.UUID       `json:"account_id"`
+	AssetId    uuid.UUID       `json:"asset_id"`
+	AssetCode  string          `json:"asset_code"`
+	Kind       FundingKind     `json:"kind"`
+	Status     FundingStatus   `json:"status"`
+	Amount     decimal.Decimal `json:"amount"`
+	Reference  string          `json:"reference"`
+	Reason     string          `json:"reason"`
+	ReviewedBy string          `json:"reviewed_by"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+type FundingFilter struct {
+	AccountId *uuid.UUID
+	Kind      *FundingKind
+	Statuses  []FundingStatus
+}
+
+// Transfer moves an amount of an asset from an account to another. The
+// IdempotencyKey, empty when the request did not send one, is unique per
+// source account.
+type Transfer struct {
+	Id             uuid.UUID       `json:"id"`
+	FromAccountId  uuid.UUID       `json:"from_account_id"`
+	ToAccountId    uuid.UUID       `json:"to_account_id"`
+	AssetId        uuid.UUID       `json:"asset_id"`
+	AssetCode      string          `json:"asset_code"`
+	Amount         decimal.Decimal `json:"amount"`
+	Memo           string          `json:"memo"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// TransferFilter selects transfers, AccountId matches either side
+type TransferFilter struct {
+	AccountId *uuid.UUID
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"strconv"
+	"time"
+)
+
+// Sort fields of the lists, each list supports some of them. Ties are broken
+// by id so the order of a list is total and its pages stable.
+const (
+	SortById        = "id"
+	SortByName      = "name"
+	SortByCreatedAt = "created_at"
+	SortByPrice     = "price"
+)
+
+type Sort struct {
+	// Field is one of the sort fields, the list default when empty
+	Field string
+	Desc  bool
+}
+
+// Key is the position of an item in a sorted list: its sort field value, as
+// text, and its id
+type Key struct {
+	Value string
+	Id    string
+}
+
+// Page selects up to Limit items of a list in the Sort order, the ones after
+// the After key when it is set. A Limit of 0 selects every item.
+type Page struct {
+	Sort  Sort
+	Limit int
+	After *Key
+}
+
+// Keyed is an item of a sorted list
+type Keyed interface {
+	// Key returns the position of the item in a list sorted on the field
+	Key(field string) Key
+}
+
+func (a Account) Key(field string) Key {
+	key := Key{Value: a.Id.String(), Id: a.Id.String()}
+	if field == SortByName {
+		key.Value = a.Name
+	}
+	return key
+}
+
+func (o Order) Key(field string) Key {
+	key := Key{Value: o.CreatedAt.UTC().Format(time.RFC3339Nano), Id: o.Id.String()}
+	if field == SortByPrice {
+		key.Value = o.Price.String()
+	}
+	return key
+}
+
+// Key of an audit entry, its log is only sorted by id
+func (e AuditEntry) Key(string) Key {
+	id := strconv.FormatInt(e.Id, 10)
+	return Key{Value: id, Id: id}
+}
+
+// Key of a funding, fundings are only sorted by creation time
+func (f Funding) Key(string) Key {
+	return Key{Value: f.CreatedAt.UTC().Format(time.RFC3339Nano), Id: f.Id.String()}
+}
+
+// Key of a transfer, transfers are only sorted by creation time
+func (t Transfer) Key(string) Key {
+	return Key{Value: t.CreatedAt.UTC().Format(time.RFC3339Nano), Id: t.Id.String()}
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+type accounts struct {
+	tx pgx.Tx
+}
+
+func (r accounts) Create(ctx context.Context, name string) (storage.Account, error) {
+	account := storage.Account{Name: name}
+	err := r.tx.QueryRow(ctx, "INSERT INTO accounts (name) VALUES ($1) RETURNING id", name).Scan(&account.Id)
+	return account, err
+}
+
+func (r accounts) Get(ctx context.Context, id uuid.UUID) (storage.Account, error) {
+	var account storage.Account
+	err := r.tx.QueryRow(ctx, "SELECT id, name FROM accounts WHERE id = $1", id).Scan(&account.Id, &account.Name)
+	return account, notFound(err)
+}
+
+var accountSorts = sortable{
+	fields:       map[string]string{storage.SortById: "uuid", storage.SortByName: "text"},
+	defaultField: storage.SortById,
+	idType:       "uuid",
+}
+
+func (r accounts) List(ctx context.Context, page storage.Page) ([]storage.Account, error) {
+	query, args := paginate("SELECT id, name FROM accounts", "", nil, page, accountSorts)
+	rows, err := r.tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []storage.Account{}
+	for rows.Next() {
+		var account storage.Account
+		if err := rows.Scan(&account.Id, &account.Name); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+type balances struct {
+	tx pgx.Tx
+}
+
+func (r balances) Get(ctx context.Context, accountId, assetId uuid.UUID) (*decimal.Decimal, error) {
+	var balance decimal.Decimal
+	query := "SELECT balance FROM account_balances WHERE account_id = $1 AND asset_id = $2 FOR UPDATE"
+	err := r.tx.QueryRow(ctx, query, accountId, assetId).Scan(&balance)
+	if err == nil {
+		return &balance, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	// No balance row to lock, tell a missing asset from a missing balance
+	var exists bool
+	if err := r.tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM assets WHERE id = $1)", assetId).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+	return nil, nil
+}
+
+func (r balances) Create(ctx context.Context, accountId, assetId uuid.UUID) error {
+	query := `
+		INSERT INTO account_balances (account_id, asset_id, balance) VALUES ($1, $2, 0)
+		ON CONFLICT (account_id, asset_id) DO NOTHING
+	`
+	_, err := r.tx.Exec(ctx, query, accountId, assetId)
+	return err
+}
+
+func (r balances) Update(ctx context.Context, accountId, assetId uuid.UUID, balance decimal.Decimal) error {
+	_, err := r.tx.Exec(ctx, "UPDATE account_balances SET balance = $1 WHERE account_id = $2 AND asset_id = $3", balance, accountId, assetId)
+	return err
+}
+
+func (r balances) ListByAccount(ctx context.Context, accountId uuid.UUID) ([]storage.AccountBalance, error) {
+	query := `
+		SELECT ab.id, ab.account_id, ab.asset_id, assets.code, ab.balance
+		FROM account_balances ab
+		INNER JOIN assets ON assets.id = ab.asset_id
+		WHERE ab.account_id = $1
+		ORDER BY assets.code
+	`
+	rows, err := r.tx.Query(ctx, query, accountId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := []storage.AccountBalance{}
+	for rows.Next() {
+		var balance storage.AccountBalance
+		if err := rows.Scan(&balance.Id, &balance.AccountId, &balance.AssetId, &balance.AssetCode, &balance.Balance); err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+	return balances, rows.Err()
+}
+
+func (r balances) List(ctx context.Context) ([]storage.AccountBalance, error) {
+	query := `
+		SELECT ab.id, ab.account_id, ab.asset_id, assets.code, ab.balance
+		FROM account_balances ab
+		INNER JOIN assets ON assets.id = ab.asset_id
+		ORDER BY ab.account_id, assets.code
+	`
+	rows, err := r.tx.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := []storage.AccountBalance{}
+	for rows.Next() {
+		var balance storage.AccountBalance
+		if err := rows.Scan(&balance.Id, &balance.AccountId, &balance.AssetId, &balance.AssetCode, &balance.Balance); err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+	return balances, rows.Err()
+}
+
+type movements struct {
+	tx pgx.Tx
+}
+
+func (r movements) Create(ctx context.Context, movement storage.Movement) (storage.Movement, error) {
+	query := `
+		INSERT INTO movements (account_id, asset_id, kind, amount)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err := r.tx.QueryRow(ctx, query, movement.AccountId, movement.AssetId, movement.Kind, movement.Amount).Scan(&movement.Id, &movement.CreatedAt)
+	return movement, err
+}
+
+func (r movements) NetTotals(ctx context.Context) ([]storage.AccountAmount, error) {
+	query := `
+		SELECT account_id, asset_id, SUM(CASE WHEN kind IN ('deposit', 'transfer_in') THEN amount ELSE -amount END)
+		FROM movements
+		GROUP BY account_id, asset_id
+	`
+	return queryAccountAmounts(ctx, r.tx, query)
+}
+
+func queryAccountAmounts(ctx context.Context, tx pgx.Tx, query string) ([]storage.AccountAmount, error) {
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	amounts := []storage.AccountAmount{}
+	for rows.Next() {
+		var amount storage.AccountAmount
+		if err := rows.Scan(&amount.AccountId, &amount.AssetId, &amount.Amount); err != nil {
+			return nil, err
+		}
+		amounts = append(amounts, amount)
+	}
+	return amounts, rows.Err()
+}
+
+type assets struct {
+	tx pgx.Tx
+}
+
+func (r assets) Get(ctx context.Context, id uuid.UUID) (storage.Asset, error) {
+	var asset storage.Asset
+	err := r.tx.QueryRow(ctx, "SELECT id, code, name FROM assets WHERE id = $1", id).Scan(&asset.Id, &asset.Code, &asset.Name)
+	return asset, notFound(err)
+}
+
+func (r assets) GetByCode(ctx context.Context, code string) (storage.Asset, error) {
+	var asset storage.Asset
+	err := r.tx.QueryRow(ctx, "SELECT id, code, name FROM assets WHERE code = $1", code).Scan(&asset.Id, &asset.Code, &asset.Name)
+	return asset, notFound(err)
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/jackc/pgx/v5"
+)
+
+type audit struct {
+	tx pgx.Tx
+}
+
+func (r audit) Append(ctx context.Context, entry storage.AuditEntry) (storage.AuditEntry, error) {
+	query := `
+		INSERT INTO audit_log (actor, action, entity_type, entity_id, before, after, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	err := r.tx.QueryRow(ctx, query, entry.Actor, entry.Action, entry.EntityType, entry.EntityId, entry.Before, entry.After, entry.RequestId).Scan(&entry.Id, &entry.CreatedAt)
+	return entry, err
+}
+
+var auditSorts = sortable{
+	fields:       map[string]string{storage.SortById: "bigint"},
+	defaultField: storage.SortById,
+	idType:       "bigint",
+}
+
+func (r audit) List(ctx context.Context, filter storage.AuditFilter, page storage.Page) ([]storage.AuditEntry, error) {
+	where, args := auditFilter(filter)
+	query, args := paginate(`
+		SELECT id, actor, action, entity_type, entity_id, before, after, request_id, created_at
+		FROM audit_log`, where, args, page, auditSorts)
+	rows, err := r.tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []storage.AuditEntry{}
+	for rows.Next() {
+		var entry storage.AuditEntry
+		if err := rows.Scan(&entry.Id, &entry.Actor, &entry.Action, &entry.EntityType, &entry.EntityId, &entry.Before, &entry.After, &entry.RequestId, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// auditFilter builds a parameterized WHERE clause for the filter
+func auditFilter(filter storage.AuditFilter) (string, []any) {
+	var conditions []string
+	var args []any
+	add := func(column string, value *string) {
+		if value != nil {
+			args = append(args, *value)
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+		}
+	}
+	add("actor", filter.Actor)
+	add("action", filter.Action)
+	add("entity_type", filter.EntityType)
+	add("entity_id", filter.EntityId)
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
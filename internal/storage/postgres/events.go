@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+type trades struct {
+	tx pgx.Tx
+}
+
+func (r trades) Create(ctx context.Context, trade storage.Trade) (storage.Trade, error) {
+	query := `
+		INSERT INTO trades (instrument_id, buy_order_id, sell_order_id, price, quantity, created_at)
+		VALUES ($1, $2, $3, $4, $5, clock_timestamp())
+		RETURNING id, created_at
+	`
+	err := r.tx.QueryRow(ctx, query, trade.InstrumentId, trade.BuyOrderId, trade.SellOrderId, trade.Price, trade.Quantity).Scan(&trade.Id, &trade.CreatedAt)
+	return trade, err
+}
+
+// Last orders by the clock at insert, trades of one unit of work would all
+// share NOW(), and by id when the clock did not move between two of them
+func (r trades) Last(ctx context.Context, instrumentId uuid.UUID) (*storage.Trade, error) {
+	var trade storage.Trade
+	query := `
+		SELECT id, instrument_id, buy_order_id, sell_order_id, price, quantity, created_at
+		FROM trades
+		WHERE instrument_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+	err := r.tx.QueryRow(ctx, query, instrumentId).Scan(&trade.Id, &trade.InstrumentId, &trade.BuyOrderId, &trade.SellOrderId, &trade.Price, &trade.Quantity, &trade.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &trade, nil
+}
+
+func (r trades) VolumeSince(ctx context.Context, instrumentId uuid.UUID, since time.Time) (decimal.Decimal, error) {
+	var volume decimal.Decimal
+	err := r.tx.QueryRow(ctx, "SELECT COALESCE(SUM(quantity), 0) FROM trades WHERE instrument_id = $1 AND created_at >= $2", instrumentId, since).Scan(&volume)
+	return volume, err
+}
+
+// NetFlows reads the accounts of the orders from all_orders, the orders of a
+// trade may have been archived since
+func (r trades) NetFlows(ctx context.Context) ([]storage.AccountAmount, error) {
+	query := `
+		SELECT account_id, asset_id, SUM(amount)
+		FROM (
+			SELECT buy_orders.account_id, instruments.base_asset_id AS asset_id, trades.quantity AS amount
+			FROM trades
+			INNER JOIN instruments ON instruments.id = trades.instrument_id
+			INNER JOIN all_orders buy_orders ON buy_orders.id = trades.buy_order_id
+			UNION ALL
+			SELECT buy_orders.account_id, instruments.quote_asset_id, -trades.quantity * trades.price
+			FROM trades
+			INNER JOIN instruments ON instruments.id = trades.instrument_id
+			INNER JOIN all_orders buy_orders ON buy_orders.id = trades.buy_order_id
+			UNION ALL
+			SELECT sell_orders.account_id, instruments.base_asset_id, -trades.quantity
+			FROM trades
+			INNER JOIN instruments ON instruments.id = trades.instrument_id
+			INNER JOIN all_orders sell_orders ON sell_orders.id = trades.sell_order_id
+			UNION ALL
+			SELECT sell_orders.account_id, instruments.quote_asset_id, trades.quantity * trades.price
+			FROM trades
+			INNER JOIN instruments ON instruments.id = trades.instrument_id
+			INNER JOIN all_orders sell_orders ON sell_orders.id = trades.sell_order_id
+		) flows
+		GROUP BY account_id, asset_id
+	`
+	return queryAccountAmounts(ctx, r.tx, query)
+}
+
+type events struct {
+	tx pgx.Tx
+	// queued are the events appended by the unit of work
+	queued *[]storage.Event
+}
+
+// Append queues the event, it is inserted by flushEvents right before the
+// transaction commits
+func (r events) Append(ctx context.Context, event storage.Event) error {
+	*r.queued = append(*r.queued, event)
+	return nil
+}
+
+func (r events) Lock(ctx context.Context) error {
+	return lockEvents(ctx, r.tx)
+}
+
+// lockEvents takes the lock ordering the commits of events until the
+// transaction ends
+func lockEvents(ctx context.Context, tx pgx.Tx) error {
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('events'))")
+	return err
+}
+
+// flushEvents inserts the events queued by the unit of work under the events
+// lock, so the transactions appending events commit in sequence order and a
+// reader never sees a sequence before the ones committing below it. The lock
+// is the last one the transaction takes and is held only until its commit,
+// so it cannot deadlock with the row locks taken by the unit of work.
+func flushEvents(ctx context.Context, tx pgx.Tx, events []storage.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := lockEvents(ctx, tx); err != nil {
+		return err
+	}
+	for _, event := range events {
+		query := "INSERT INTO events (type, aggregate_id, payload) VALUES ($1, $2, $3)"
+		if _, err := tx.Exec(ctx, query, event.Type, event.AggregateId, event.Payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r events) List(ctx context.Context, after, upTo int64, limit int) ([]storage.Event, error) {
+	query := `
+		SELECT sequence, type, aggregate_id, payload, created_at
+		FROM events
+		WHERE sequence > $1 AND ($2 < 0 OR sequence <= $2)
+		ORDER BY sequence ASC
+		LIMIT $3
+	`
+	return r.query(ctx, query, after, upTo, limit)
+}
+
+func (r events) ListByOrder(ctx context.Context, orderId uuid.UUID) ([]storage.Event, error) {
+	// Matches are appended on the instrument and replacements on the new
+	// order, their payload names the order
+	query := `
+		SELECT sequence, type, aggregate_id, payload, created_at
+		FROM events
+		WHERE aggregate_id = $1
+			OR type = 'order_matched' AND payload->>'buy_order_id' = $2
+			OR type = 'order_matched' AND payload->>'sell_order_id' = $2
+			OR type = 'order_accepted' AND payload->>'replaces' = $2
+		ORDER BY sequence ASC
+	`
+	return r.query(ctx, query, orderId, orderId.String())
+}
+
+func (r events) query(ctx context.Context, query string, args ...any) ([]storage.Event, error) {
+	rows, err := r.tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []storage.Event{}
+	for rows.Next() {
+		var event storage.Event
+		if err := rows.Scan(&event.Sequence, &event.Type, &event.AggregateId, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r events) Last(ctx context.Context) (int64, error) {
+	var sequence int64
+	err := r.tx.QueryRow(ctx, "SELECT COALESCE(MAX(sequence), 0) FROM events").Scan(&sequence)
+	return sequence, err
+}
+
+func (r events) SaveSnapshot(ctx context.Context, snapshot storage.Snapshot) error {
+	query := "INSERT INTO snapshots (sequence, state) VALUES ($1, $2) ON CONFLICT (sequence) DO NOTHING"
+	_, err := r.tx.Exec(ctx, query, snapshot.Sequence, snapshot.State)
+	return err
+}
+
+func (r events) LatestSnapshot(ctx context.Context, atOrBefore int64) (*storage.Snapshot, error) {
+	var snapshot storage.Snapshot
+	query := `
+		SELECT sequence, state, created_at
+		FROM snapshots
+		WHERE $1 < 0 OR sequence <= $1
+		ORDER BY sequence DESC
+		LIMIT 1
+	`
+	err := r.tx.QueryRow(ctx, query, atOrBefore).Scan(&snapshot.Sequence, &snapshot.State, &snapshot.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type fix struct {
+	tx pgx.Tx
+}
+
+const fixSessionColumns = "id, next_sender_seq, next_target_seq, last_event, updated_at"
+
+func scanFixSession(row pgx.Row) (storage.FixSession, error) {
+	var session storage.FixSession
+	err := row.Scan(&session.Id, &session.NextSenderSeq, &session.NextTargetSeq, &session.LastEvent, &session.UpdatedAt)
+	return session, err
+}
+
+func (r fix) GetSession(ctx context.Context, id string) (storage.FixSession, error) {
+	query := "SELECT " + fixSessionColumns + " FROM fix_sessions WHERE id = $1 FOR UPDATE"
+	session, err := scanFixSession(r.tx.QueryRow(ctx, query, id))
+	return session, notFound(err)
+}
+
+func (r fix) ListSessions(ctx context.Context) ([]storage.FixSession, error) {
+	rows, err := r.tx.Query(ctx, "SELECT "+fixSessionColumns+" FROM fix_sessions ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []storage.FixSession{}
+	for rows.Next() {
+		session, err := scanFixSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+func (r fix) SaveSession(ctx context.Context, session storage.FixSession) error {
+	query := `
+		INSERT INTO fix_sessions (id, next_sender_seq, next_target_seq, last_event)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET
+			next_sender_seq = EXCLUDED.next_sender_seq,
+			next_target_seq = EXCLUDED.next_target_seq,
+			last_event = EXCLUDED.last_event,
+			updated_at = NOW()
+	`
+	_, err := r.tx.Exec(ctx, query, session.Id, session.NextSenderSeq, session.NextTargetSeq, session.LastEvent)
+	return err
+}
+
+func (r fix) AppendMessage(ctx context.Context, message storage.FixMessage) error {
+	query := "INSERT INTO fix_messages (session_id, seq, type, data) VALUES ($1, $2, $3, $4)"
+	_, err := r.tx.Exec(ctx, query, message.SessionId, message.Seq, message.Type, message.Data)
+	return err
+}
+
+func (r fix) ListMessages(ctx context.Context, sessionId string, from, to int) ([]storage.FixMessage, error) {
+	query := `
+		SELECT session_id, seq, type, data, created_at
+		FROM fix_messages
+		WHERE session_id = $1 AND seq >= $2 AND ($3 = 0 OR seq <= $3)
+		ORDER BY seq
+	`
+	rows, err := r.tx.Query(ctx, query, sessionId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []storage.FixMessage{}
+	for rows.Next() {
+		var message storage.FixMessage
+		if err := rows.Scan(&message.SessionId, &message.Seq, &message.Type, &message.Data, &message.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+func (r fix) DeleteMessages(ctx context.Context, sessionId string) error {
+	_, err := r.tx.Exec(ctx, "DELETE FROM fix_messages WHERE session_id = $1", sessionId)
+	return err
+}
+
+const fixOrderColumns = "order_id, session_id, cl_ord_id, orig_cl_ord_id, symbol, side, price, order_qty, cum_qty, avg_px, replaced, created_at"
+
+func scanFixOrder(row pgx.Row) (storage.FixOrder, error) {
+	var order storage.FixOrder
+	err := row.Scan(&order.OrderId, &order.SessionId, &order.ClOrdId, &order.OrigClOrdId, &order.Symbol, &order.Side, &order.Price, &order.OrderQty, &order.CumQty, &order.AvgPx, &order.Replaced, &order.CreatedAt)
+	return order, notFound(err)
+}
+
+func (r fix) CreateOrder(ctx context.Context, order storage.FixOrder) error {
+	query := `
+		INSERT INTO fix_orders (order_id, session_id, cl_ord_id, orig_cl_ord_id, symbol, side, price, order_qty, cum_qty, avg_px, replaced)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.tx.Exec(ctx, query, order.OrderId, order.SessionId, order.ClOrdId, order.OrigClOrdId, order.Symbol, order.Side, order.Price, order.OrderQty, order.CumQty, order.AvgPx, order.Replaced)
+	return err
+}
+
+func (r fix) GetOrder(ctx context.Context, orderId uuid.UUID) (storage.FixOrder, error) {
+	return scanFixOrder(r.tx.QueryRow(ctx, "SELECT "+fixOrderColumns+" FROM fix_orders WHERE order_id = $1 FOR UPDATE", orderId))
+}
+
+func (r fix) GetOrderByClOrdId(ctx context.Context, sessionId, clOrdId string) (storage.FixOrder, error) {
+	query := "SELECT " + fixOrderColumns + " FROM fix_orders WHERE session_id = $1 AND cl_ord_id = $2 FOR UPDATE"
+	return scanFixOrder(r.tx.QueryRow(ctx, query, sessionId, clOrdId))
+}
+
+func (r fix) UpdateOrder(ctx context.Context, order storage.FixOrder) error {
+	query := `
+		UPDATE fix_orders
+		SET cl_ord_id = $2, orig_cl_ord_id = $3, order_qty = $4, cum_qty = $5, avg_px = $6, replaced = $7
+		WHERE order_id = $1
+	`
+	_, err := r.tx.Exec(ctx, query, order.OrderId, order.ClOrdId, order.OrigClOrdId, order.OrderQty, order.CumQty, order.AvgPx, order.Replaced)
+	return err
+}
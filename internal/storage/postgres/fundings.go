@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const fundingColumns = `
+	SELECT fundings.id, fundings.account_id, fundings.asset_id, assets.code, fundings.kind, fundings.status, fundings.amount,
+		fundings.reference, fundings.reason, fundings.reviewed_by, fundings.created_at, fundings.updated_at
+	FROM fundings
+	INNER JOIN assets ON assets.id = fundings.asset_id
+`
+
+type fundings struct {
+	tx pgx.Tx
+}
+
+func (r fundings) Create(ctx context.Context, funding storage.Funding) (storage.Funding, error) {
+	query := `
+		INSERT INTO fundings (account_id, asset_id, kind, status, amount)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.tx.QueryRow(ctx, query, funding.AccountId, funding.AssetId, funding.Kind, funding.Status, funding.Amount).
+		Scan(&funding.Id, &funding.CreatedAt, &funding.UpdatedAt)
+	return funding, err
+}
+
+func (r fundings) Get(ctx context.Context, id uuid.UUID) (storage.Funding, error) {
+	funding, err := scanFunding(r.tx.QueryRow(ctx, fundingColumns+" WHERE fundings.id = $1 FOR UPDATE OF fundings", id))
+	return funding, notFound(err)
+}
+
+var fundingSorts = sortable{
+	fields:       map[string]string{storage.SortByCreatedAt: "timestamp"},
+	defaultField: storage.SortByCreatedAt,
+	idType:       "uuid",
+}
+
+func (r fundings) List(ctx context.Context, filter storage.FundingFilter, page storage.Page) ([]storage.Funding, error) {
+	where, args := fundingFilter(filter)
+	// Filtered and sorted as a subquery so its columns are not ambiguous with the join
+	query, args := paginate("SELECT * FROM ("+fundingColumns+") fundings", where, args, page, fundingSorts)
+	rows, err := r.tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fundings := []storage.Funding{}
+	for rows.Next() {
+		funding, err := scanFunding(rows)
+		if err != nil {
+			return nil, err
+		}
+		fundings = append(fundings, funding)
+	}
+	return fundings, rows.Err()
+}
+
+func (r fundings) Update(ctx context.Context, funding storage.Funding) (storage.Funding, error) {
+	query := `
+		UPDATE fundings
+		SET status = $1, reference = $2, reason = $3, reviewed_by = $4, updated_at = NOW()
+		WHERE id = $5
+		RETURNING updated_at
+	`
+	err := r.tx.QueryRow(ctx, query, funding.Status, funding.Reference, funding.Reason, funding.ReviewedBy, funding.Id).Scan(&funding.UpdatedAt)
+	return funding, notFound(err)
+}
+
+func scanFunding(row pgx.Row) (storage.Funding, error) {
+	var funding storage.Funding
+	err := row.Scan(
+		&funding.Id, &funding.AccountId, &funding.AssetId, &funding.AssetCode, &funding.Kind, &funding.Status, &funding.Amount,
+		&funding.Reference, &funding.Reason, &funding.ReviewedBy, &funding.CreatedAt, &funding.UpdatedAt,
+	)
+	return funding, err
+}
+
+// fundingFilter builds a parameterized WHERE clause for the filter
+func fundingFilter(filter storage.FundingFilter) (string, []any) {
+	var conditions []string
+	var args []any
+	if filter.AccountId != nil {
+		args = append(args, *filter.AccountId)
+		conditions = append(conditions, fmt.Sprintf("account_id = $%d", len(args)))
+	}
+	if filter.Kind != nil {
+		args = append(args, string(*filter.Kind))
+		conditions = append(conditions, fmt.Sprintf("kind = $%d", len(args)))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		args = append(args, statuses)
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,316 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+const instrumentColumns = `
+	SELECT instruments.id, instruments.base_asset_id, base_assets.code, instruments.quote_asset_id, quote_assets.code, instruments.trading_status
+	FROM instruments
+	INNER JOIN assets base_assets ON base_assets.id = instruments.base_asset_id
+	INNER JOIN assets quote_assets ON quote_assets.id = instruments.quote_asset_id
+`
+
+type instruments struct {
+	tx pgx.Tx
+}
+
+func (r instruments) List(ctx context.Context) ([]storage.Instrument, error) {
+	rows, err := r.tx.Query(ctx, instrumentColumns+" ORDER BY base_assets.code, quote_assets.code")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	instruments := []storage.Instrument{}
+	for rows.Next() {
+		instrument, err := scanInstrument(rows)
+		if err != nil {
+			return nil, err
+		}
+		instruments = append(instruments, instrument)
+	}
+	return instruments, rows.Err()
+}
+
+func (r instruments) Get(ctx context.Context, id uuid.UUID) (storage.Instrument, error) {
+	instrument, err := scanInstrument(r.tx.QueryRow(ctx, instrumentColumns+" WHERE instruments.id = $1 FOR UPDATE OF instruments", id))
+	return instrument, notFound(err)
+}
+
+func (r instruments) GetByBaseAssetCode(ctx context.Context, code string) (storage.Instrument, error) {
+	instrument, err := scanInstrument(r.tx.QueryRow(ctx, instrumentColumns+" WHERE base_assets.code = $1", code))
+	return instrument, notFound(err)
+}
+
+func (r instruments) UpdateTradingStatus(ctx context.Context, id uuid.UUID, status storage.TradingStatus) error {
+	_, err := r.tx.Exec(ctx, "UPDATE instruments SET trading_status = $1 WHERE id = $2", status, id)
+	return err
+}
+
+func scanInstrument(row pgx.Row) (storage.Instrument, error) {
+	var instrument storage.Instrument
+	err := row.Scan(&instrument.Id, &instrument.BaseAssetId, &instrument.BaseAssetCode, &instrument.QuoteAssetId, &instrument.QuoteAssetCode, &instrument.TradingStatus)
+	return instrument, err
+}
+
+const orderColumns = "id, account_id, instrument_id, type, status, price, total_quantity, filled_quantity, created_at"
+
+type orders struct {
+	tx pgx.Tx
+}
+
+func (r orders) Create(ctx context.Context, order storage.Order) (storage.Order, error) {
+	query := `
+		INSERT INTO order_book (account_id, instrument_id, type, status, price, total_quantity, filled_quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	err := r.tx.QueryRow(
+		ctx,
+		query,
+		order.AccountId,
+		order.InstrumentId,
+		order.Type,
+		order.Status,
+		order.Price,
+		order.TotalQuantity,
+		order.FilledQuantity,
+	).Scan(&order.Id, &order.CreatedAt)
+	return order, err
+}
+
+func (r orders) Get(ctx context.Context, id uuid.UUID) (storage.Order, error) {
+	order, err := scanOrder(r.tx.QueryRow(ctx, "SELECT "+orderColumns+" FROM order_book WHERE id = $1 FOR UPDATE", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Archived orders never change, there is nothing to lock
+		order, err = scanOrder(r.tx.QueryRow(ctx, "SELECT "+orderColumns+" FROM order_history WHERE id = $1", id))
+	}
+	return order, notFound(err)
+}
+
+var orderSorts = sortable{
+	fields:       map[string]string{storage.SortByCreatedAt: "timestamp", storage.SortByPrice: "numeric"},
+	defaultField: storage.SortByCreatedAt,
+	idType:       "uuid",
+}
+
+func (r orders) List(ctx context.Context, filter storage.OrderFilter, page storage.Page) ([]storage.Order, error) {
+	where, args := orderFilter(filter)
+	query, args := paginate("SELECT "+orderColumns+" FROM order_book", where, args, page, orderSorts)
+	return r.query(ctx, query, args...)
+}
+
+func (r orders) History(ctx context.Context, filter storage.OrderFilter, page storage.Page) ([]storage.Order, error) {
+	where, args := orderFilter(filter)
+	query, args := paginate("SELECT "+orderColumns+" FROM order_history", where, args, page, orderSorts)
+	return r.query(ctx, query, args...)
+}
+
+// Archive skips the final orders locked by others, they are moved next time
+func (r orders) Archive(ctx context.Context, limit int) (int, error) {
+	query := `
+		WITH archived AS (
+			DELETE FROM order_book
+			WHERE id IN (
+				SELECT id
+				FROM order_book
+				WHERE status IN ('full_filled', 'canceled')
+				ORDER BY created_at ASC
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + orderColumns + `
+		)
+		INSERT INTO order_history (` + orderColumns + `)
+		SELECT ` + orderColumns + ` FROM archived
+	`
+	tag, err := r.tx.Exec(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (r orders) Count(ctx context.Context, filter storage.OrderFilter) (int, error) {
+	where, args := orderFilter(filter)
+	var total int
+	err := r.tx.QueryRow(ctx, "SELECT COUNT(*) FROM order_book "+where, args...).Scan(&total)
+	return total, err
+}
+
+func (r orders) ListWorking(ctx context.Context, instrumentId uuid.UUID) ([]storage.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM order_book
+		WHERE instrument_id = $1 AND status IN ('open', 'partially_filled')
+		ORDER BY created_at ASC
+		FOR UPDATE
+	`
+	return r.query(ctx, query, instrumentId)
+}
+
+func (r orders) ListCompatible(ctx context.Context, order storage.Order) ([]storage.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM order_book
+		WHERE
+			instrument_id = $1
+			AND type = 'sell'
+			AND status IN ('open', 'partially_filled')
+			AND price <= $2
+		ORDER BY
+			price ASC,
+			created_at ASC
+		FOR UPDATE SKIP LOCKED
+	`
+	if order.Type == storage.Sell {
+		query = `
+			SELECT ` + orderColumns + `
+			FROM order_book
+			WHERE
+				instrument_id = $1
+				AND type = 'buy'
+				AND status IN ('open', 'partially_filled')
+				AND price >= $2
+			ORDER BY
+				price DESC,
+				created_at ASC
+			FOR UPDATE SKIP LOCKED
+		`
+	}
+	return r.query(ctx, query, order.InstrumentId, order.Price)
+}
+
+func (r orders) BestPrices(ctx context.Context, instrumentId uuid.UUID) (*decimal.Decimal, *decimal.Decimal, error) {
+	var bestBid, bestAsk *decimal.Decimal
+	query := `
+		SELECT
+			MAX(price) FILTER (WHERE type = 'buy'),
+			MIN(price) FILTER (WHERE type = 'sell')
+		FROM order_book
+		WHERE instrument_id = $1 AND status IN ('open', 'partially_filled')
+	`
+	err := r.tx.QueryRow(ctx, query, instrumentId).Scan(&bestBid, &bestAsk)
+	return bestBid, bestAsk, err
+}
+
+func (r orders) Levels(ctx context.Context, instrumentId uuid.UUID, side storage.OrderType, limit int) ([]storage.PriceLevel, error) {
+	order := "DESC"
+	if side == storage.Sell {
+		order = "ASC"
+	}
+	query := `
+		SELECT price, SUM(total_quantity - filled_quantity), COUNT(*)
+		FROM order_book
+		WHERE instrument_id = $1 AND type = $2 AND status IN ('open', 'partially_filled')
+		GROUP BY price
+		ORDER BY price ` + order + `
+		LIMIT NULLIF($3, 0)
+	`
+	rows, err := r.tx.Query(ctx, query, instrumentId, side, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := []storage.PriceLevel{}
+	for rows.Next() {
+		var level storage.PriceLevel
+		if err := rows.Scan(&level.Price, &level.Quantity, &level.Orders); err != nil {
+			return nil, err
+		}
+		levels = append(levels, level)
+	}
+	return levels, rows.Err()
+}
+
+func (r orders) UpdateFill(ctx context.Context, id uuid.UUID, filledQuantity decimal.Decimal, status storage.OrderStatus) error {
+	_, err := r.tx.Exec(ctx, "UPDATE order_book SET filled_quantity = $1, status = $2 WHERE id = $3", filledQuantity, status, id)
+	return err
+}
+
+func (r orders) UpdateStatus(ctx context.Context, id uuid.UUID, status storage.OrderStatus) error {
+	_, err := r.tx.Exec(ctx, "UPDATE order_book SET status = $1 WHERE id = $2", status, id)
+	return err
+}
+
+func (r orders) query(ctx context.Context, query string, args ...any) ([]storage.Order, error) {
+	rows, err := r.tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []storage.Order{}
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+func scanOrder(row pgx.Row) (storage.Order, error) {
+	var order storage.Order
+	err := row.Scan(&order.Id, &order.AccountId, &order.InstrumentId, &order.Type, &order.Status, &order.Price, &order.TotalQuantity, &order.FilledQuantity, &order.CreatedAt)
+	return order, err
+}
+
+// orderFilter builds a parameterized WHERE clause for the filter
+func orderFilter(filter storage.OrderFilter) (string, []any) {
+	var conditions []string
+	var args []any
+	if filter.AccountId != nil {
+		args = append(args, *filter.AccountId)
+		conditions = append(conditions, fmt.Sprintf("account_id = $%d", len(args)))
+	}
+	if filter.InstrumentId != nil {
+		args = append(args, *filter.InstrumentId)
+		conditions = append(conditions, fmt.Sprintf("instrument_id = $%d", len(args)))
+	}
+	if filter.Type != nil {
+		args = append(args, string(*filter.Type))
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		args = append(args, statuses)
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", len(args)))
+	}
+	if filter.MinPrice != nil {
+		args = append(args, *filter.MinPrice)
+		conditions = append(conditions, fmt.Sprintf("price >= $%d", len(args)))
+	}
+	if filter.MaxPrice != nil {
+		args = append(args, *filter.MaxPrice)
+		conditions = append(conditions, fmt.Sprintf("price <= $%d", len(args)))
+	}
+	// created_at is a timestamp without time zone holding UTC times
+	if filter.CreatedFrom != nil {
+		args = append(args, filter.CreatedFrom.UTC())
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.CreatedTo != nil {
+		args = append(args, filter.CreatedTo.UTC())
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+)
+
+// sortable describes how a list is sorted: the column type of each sort
+// field, the default field and the type of the id breaking ties
+type sortable struct {
+	fields       map[string]string
+	defaultField string
+	idType       string
+}
+
+// paginate completes the query, which ends with its FROM clause, with the
+// filter WHERE clause (empty when there is none) and the keyset pagination of
+// the page. Sort fields are checked against the list before they are written
+// in the query, values are always parameters.
+func paginate(query, where string, args []any, page storage.Page, list sortable) (string, []any) {
+	field := page.Sort.Field
+	fieldType, ok := list.fields[field]
+	if !ok {
+		field = list.defaultField
+		fieldType = list.fields[field]
+	}
+	direction, operator := "ASC", ">"
+	if page.Sort.Desc {
+		direction, operator = "DESC", "<"
+	}
+
+	if page.After != nil {
+		var condition string
+		if field == storage.SortById {
+			args = append(args, page.After.Id)
+			condition = fmt.Sprintf("id %s $%d::%s", operator, len(args), list.idType)
+		} else {
+			args = append(args, page.After.Value, page.After.Id)
+			condition = fmt.Sprintf(
+				"(%s, id) %s ($%d::%s, $%d::%s)",
+				field, operator, len(args)-1, fieldType, len(args), list.idType,
+			)
+		}
+		if where == "" {
+			where = "WHERE " + condition
+		} else {
+			where += " AND " + condition
+		}
+	}
+
+	order := fmt.Sprintf("ORDER BY %s %s, id %s", field, direction, direction)
+	if field == storage.SortById {
+		order = "ORDER BY id " + direction
+	}
+	query = fmt.Sprintf("%s %s %s", query, where, order)
+	if page.Limit > 0 {
+		args = append(args, page.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	return query, args
+}
@@ -0,0 +1,33 @@
+// Package postgrestest gives tests a store on the PostgreSQL database of
+// TEST_DATABASE_URL. Races between units of work only show there, the memory
+// store serializes them.
+package postgrestest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/JhonesBR/go-clob/internal/config"
+	"github.com/JhonesBR/go-clob/internal/db"
+	"github.com/JhonesBR/go-clob/internal/storage/postgres"
+)
+
+// Store connects to the database and migrates it, skipping the test when
+// TEST_DATABASE_URL is not set
+func Store(t *testing.T) *postgres.Store {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	pool, err := db.NewConnection(config.Database{URL: url, MaxConns: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	if _, err := db.MigrateUp(context.Background(), pool); err != nil {
+		t.Fatal(err)
+	}
+	return postgres.New(pool)
+}
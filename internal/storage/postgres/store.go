@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Store struct {
+	pool *pgxpool.Pool
+}
+
+func New(pool *pgxpool.Pool) *Store {
+	return &Store{pool: pool}
+}
+
+func (s *Store) Pool() *pgxpool.Pool {
+	return s.pool
+}
+
+func (s *Store) WithTx(ctx context.Context, fn func(tx storage.Tx) error) error {
+	pgxTx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer pgxTx.Rollback(ctx)
+
+	t := &tx{tx: pgxTx}
+	if err := fn(t); err != nil {
+		return err
+	}
+	if err := flushEvents(ctx, pgxTx, t.events); err != nil {
+		return err
+	}
+	return pgxTx.Commit(ctx)
+}
+
+func (s *Store) Ping(ctx context.Context) error {
+	return s.pool.Ping(ctx)
+}
+
+func (s *Store) Close() {
+	s.pool.Close()
+}
+
+type tx struct {
+	tx pgx.Tx
+	// events appended by the unit of work, inserted when it commits
+	events []storage.Event
+}
+
+func (t *tx) Accounts() storage.AccountRepository       { return accounts{t.tx} }
+func (t *tx) Balances() storage.BalanceRepository       { return balances{t.tx} }
+func (t *tx) Assets() storage.AssetRepository           { return assets{t.tx} }
+func (t *tx) Instruments() storage.InstrumentRepository { return instruments{t.tx} }
+func (t *tx) Orders() storage.OrderRepository           { return orders{t.tx} }
+func (t *tx) Trades() storage.TradeRepository           { return trades{t.tx} }
+func (t *tx) Movements() storage.MovementRepository     { return movements{t.tx} }
+func (t *tx) Events() storage.EventRepository           { return events{t.tx, &t.events} }
+func (t *tx) Audit() storage.AuditRepository            { return audit{t.tx} }
+func (t *tx) Fix() storage.FixRepository                { return fix{t.tx} }
+func (t *tx) Fundings() storage.FundingRepository       { return fundings{t.tx} }
+func (t *tx) Transfers() storage.TransferRepository     { return transfers{t.tx} }
+
+// uniqueViolation is the SQLSTATE of an insert breaking a unique index
+const uniqueViolation = "23505"
+
+// notFound translates the pgx missing row error to the storage one
+func notFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const transferColumns = `
+	SELECT transfers.id, transfers.from_account_id, transfers.to_account_id, transfers.asset_id, assets.code,
+		transfers.amount, transfers.memo, transfers.idempotency_key, transfers.created_at
+	FROM transfers
+	INNER JOIN assets ON assets.id = transfers.asset_id
+`
+
+type transfers struct {
+	tx pgx.Tx
+}
+
+func (r transfers) Create(ctx context.Context, transfer storage.Transfer) (storage.Transfer, error) {
+	query := `
+		INSERT INTO transfers (from_account_id, to_account_id, asset_id, amount, memo, idempotency_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	err := r.tx.QueryRow(ctx, query, transfer.FromAccountId, transfer.ToAccountId, transfer.AssetId, transfer.Amount, transfer.Memo, transfer.IdempotencyKey).
+		Scan(&transfer.Id, &transfer.CreatedAt)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == "transfers_idempotency_key_idx" {
+		return transfer, storage.ErrDuplicate
+	}
+	return transfer, err
+}
+
+func (r transfers) Get(ctx context.Context, id uuid.UUID) (storage.Transfer, error) {
+	transfer, err := scanTransfer(r.tx.QueryRow(ctx, transferColumns+" WHERE transfers.id = $1", id))
+	return transfer, notFound(err)
+}
+
+func (r transfers) GetByIdempotencyKey(ctx context.Context, fromAccountId uuid.UUID, key string) (storage.Transfer, error) {
+	query := transferColumns + " WHERE transfers.from_account_id = $1 AND transfers.idempotency_key = $2"
+	transfer, err := scanTransfer(r.tx.QueryRow(ctx, query, fromAccountId, key))
+	return transfer, notFound(err)
+}
+
+var transferSorts = sortable{
+	fields:       map[string]string{storage.SortByCreatedAt: "timestamp"},
+	defaultField: storage.SortByCreatedAt,
+	idType:       "uuid",
+}
+
+func (r transfers) List(ctx context.Context, filter storage.TransferFilter, page storage.Page) ([]storage.Transfer, error) {
+	var where string
+	var args []any
+	if filter.AccountId != nil {
+		where, args = "WHERE (from_account_id = $1 OR to_account_id = $1)", []any{*filter.AccountId}
+	}
+	// Filtered and sorted as a subquery so its columns are not ambiguous with the join
+	query, args := paginate("SELECT * FROM ("+transferColumns+") transfers", where, args, page, transferSorts)
+	rows, err := r.tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := []storage.Transfer{}
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, rows.Err()
+}
+
+func scanTransfer(row pgx.Row) (storage.Transfer, error) {
+	var transfer storage.Transfer
+	err := row.Scan(
+		&transfer.Id, &transfer.FromAccountId, &transfer.ToAccountId, &transfer.AssetId, &transfer.AssetCode,
+		&transfer.Amount, &transfer.Memo, &transfer.IdempotencyKey, &transfer.CreatedAt,
+	)
+	return transfer, err
+}
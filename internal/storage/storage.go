@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// ErrDuplicate is a record taking a key that must be unique and is already
+// taken, like an idempotency key used by a concurrent unit of work
+var ErrDuplicate = errors.New("duplicate")
+
+// Store opens units of work, every repository access happens inside one
+type Store interface {
+	// WithTx runs fn inside a transaction, committed when fn returns nil and
+	// rolled back otherwise
+	WithTx(ctx context.Context, fn func(tx Tx) error) error
+	Ping(ctx context.Context) error
+	Close()
+}
+
+type Tx interface {
+	Accounts() AccountRepository
+	Balances() BalanceRepository
+	Assets() AssetRepository
+	Instruments() InstrumentRepository
+	Orders() OrderRepository
+	Trades() TradeRepository
+	Movements() MovementRepository
+	Events() EventRepository
+	Audit() AuditRepository
+	Fix() FixRepository
+	Fundings() FundingRepository
+	Transfers() TransferRepository
+}
+
+type AccountRepository interface {
+	Create(ctx context.Context, name string) (Account, error)
+	Get(ctx context.Context, id uuid.UUID) (Account, error)
+	// List returns a page of the accounts sorted by id or name
+	List(ctx context.Context, page Page) ([]Account, error)
+}
+
+type BalanceRepository interface {
+	// Get locks the balance until the end of the transaction, so it can be
+	// updated from what was read, and returns nil when the account has no
+	// balance row for the asset
+	Get(ctx context.Context, accountId, assetId uuid.UUID) (*decimal.Decimal, error)
+	// Create adds a zero balance, doing nothing when the account already has one
+	Create(ctx context.Context, accountId, assetId uuid.UUID) error
+	Update(ctx context.Context, accountId, assetId uuid.UUID, balance decimal.Decimal) error
+	ListByAccount(ctx context.Context, accountId uuid.UUID) ([]AccountBalance, error)
+	// List returns every balance of every account
+	List(ctx context.Context) ([]AccountBalance, error)
+}
+
+type AssetRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (Asset, error)
+	GetByCode(ctx context.Context, code string) (Asset, error)
+}
+
+type InstrumentRepository interface {
+	List(ctx context.Context) ([]Instrument, error)
+	// Get locks the instrument until the end of the transaction
+	Get(ctx context.Context, id uuid.UUID) (Instrument, error)
+	GetByBaseAssetCode(ctx context.Context, code string) (Instrument, error)
+	UpdateTradingStatus(ctx context.Context, id uuid.UUID, status TradingStatus) error
+}
+
+// OrderFilter selects orders, prices bounds are inclusive and CreatedTo is
+// exclusive
+type OrderFilter struct {
+	AccountId    *uuid.UUID
+	InstrumentId *uuid.UUID
+	Type         *OrderType
+	Statuses     []OrderStatus
+	MinPrice     *decimal.Decimal
+	MaxPrice     *decimal.Decimal
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+}
+
+// OrderRepository holds the live book, the working orders and the final
+// ones not archived yet, and the order history the final ones are moved to
+type OrderRepository interface {
+	Create(ctx context.Context, order Order) (Order, error)
+	// Get locks the order until the end of the transaction, archived orders
+	// are final and read from the history
+	Get(ctx context.Context, id uuid.UUID) (Order, error)
+	// List returns a page of the live orders matching the filter sorted by
+	// creation time or price
+	List(ctx context.Context, filter OrderFilter, page Page) ([]Order, error)
+	// History returns a page of the archived orders matching the filter,
+	// sorted like List
+	History(ctx context.Context, filter OrderFilter, page Page) ([]Order, error)
+	// Archive moves up to limit final orders, oldest first, from the live
+	// book to the history and returns how many it moved
+	Archive(ctx context.Context, limit int) (int, error)
+	Count(ctx context.Context, filter OrderFilter) (int, error)
+	// ListWorking returns and locks the working orders of the instrument in time priority
+	ListWorking(ctx context.Context, instrumentId uuid.UUID) ([]Order, error)
+	// ListCompatible returns and locks the orders that can match the given one
+	// in price then time priority, skipping orders locked by others
+	ListCompatible(ctx context.Context, order Order) ([]Order, error)
+	// BestPrices returns the highest working bid and lowest working ask
+	BestPrices(ctx context.Context, instrumentId uuid.UUID) (*decimal.Decimal, *decimal.Decimal, error)
+	// Levels returns the working quantity of a side of the book aggregated per
+	// price, best price first, up to limit levels (all of them when 0)
+	Levels(ctx context.Context, instrumentId uuid.UUID, side OrderType, limit int) ([]PriceLevel, error)
+	UpdateFill(ctx context.Context, id uuid.UUID, filledQuantity decimal.Decimal, status OrderStatus) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status OrderStatus) error
+}
+
+type TradeRepository interface {
+	Create(ctx context.Context, trade Trade) (Trade, error)
+	// Last returns nil when the instrument has not traded yet
+	Last(ctx context.Context, instrumentId uuid.UUID) (*Trade, error)
+	VolumeSince(ctx context.Context, instrumentId uuid.UUID, since time.Time) (decimal.Decimal, error)
+	// NetFlows returns what trades added to (or removed from) each account per asset
+	NetFlows(ctx context.Context) ([]AccountAmount, error)
+}
+
+type MovementRepository interface {
+	Create(ctx context.Context, movement Movement) (Movement, error)
+	// NetTotals returns deposits and transfers in minus withdrawals, fees and
+	// transfers out per account and asset
+	NetTotals(ctx context.Context) ([]AccountAmount, error)
+}
+
+type EventRepository interface {
+	// Append adds the event to the log with the next global sequence number
+	// when the unit of work commits, so sequences are committed in order
+	Append(ctx context.Context, event Event) error
+	// Lock waits for the units of work committing events and blocks new ones
+	// until the end of this one, so every change read is consistent. The unit
+	// of work must not lock rows after it, committing ones may hold them.
+	Lock(ctx context.Context) error
+	// List returns up to limit events after the sequence, upTo < 0 means no upper bound
+	List(ctx context.Context, after, upTo int64, limit int) ([]Event, error)
+	// ListByOrder returns the events of the order in sequence: the ones it
+	// is the aggregate of, the order_matched events it took part in and the
+	// order_accepted event of the order replacing it
+	ListByOrder(ctx context.Context, orderId uuid.UUID) ([]Event, error)
+	// Last returns the sequence of the last event, 0 when the log is empty
+	Last(ctx context.Context) (int64, error)
+	SaveSnapshot(ctx context.Context, snapshot Snapshot) error
+	// LatestSnapshot returns the last snapshot at or before the sequence (any
+	// when negative), nil when there is none
+	LatestSnapshot(ctx context.Context, atOrBefore int64) (*Snapshot, error)
+}
+
+// AuditRepository is append-only, entries are never updated nor deleted
+type AuditRepository interface {
+	Append(ctx context.Context, entry AuditEntry) (AuditEntry, error)
+	// List returns a page of the entries matching the filter sorted by id
+	List(ctx context.Context, filter AuditFilter, page Page) ([]AuditEntry, error)
+}
+
+// FixRepository keeps the FIX gateway sessions, the messages sent on them and
+// the orders placed through them
+type FixRepository interface {
+	// GetSession locks the session until the end of the transaction
+	GetSession(ctx context.Context, id string) (FixSession, error)
+	ListSessions(ctx context.Context) ([]FixSession, error)
+	// SaveSession creates or updates the session
+	SaveSession(ctx context.Context, session FixSession) error
+	AppendMessage(ctx context.Context, message FixMessage) error
+	// ListMessages returns the messages of the session with sequence numbers
+	// from from to to inclusive in order, to 0 means no upper bound
+	ListMessages(ctx context.Context, sessionId string, from, to int) ([]FixMessage, error)
+	DeleteMessages(ctx context.Context, sessionId string) error
+	CreateOrder(ctx context.Context, order FixOrder) error
+	// GetOrder and GetOrderByClOrdId lock the order link until the end of the
+	// transaction
+	GetOrder(ctx context.Context, orderId uuid.UUID) (FixOrder, error)
+	GetOrderByClOrdId(ctx context.Context, sessionId, clOrdId string) (FixOrder, error)
+	UpdateOrder(ctx context.Context, order FixOrder) error
+}
+
+// FundingRepository keeps the deposits and withdrawals, stored with the code
+// of their asset
+type FundingRepository interface {
+	Create(ctx context.Context, funding Funding) (Funding, error)
+	// Get locks the funding until the end of the transaction
+	Get(ctx context.Context, id uuid.UUID) (Funding, error)
+	// List returns a page of the fundings matching the filter sorted by
+	// creation time
+	List(ctx context.Context, filter FundingFilter, page Page) ([]Funding, error)
+	// Update saves the status, reference, reason and reviewer of the funding
+	Update(ctx context.Context, funding Funding) (Funding, error)
+}
+
+// TransferRepository keeps the transfers between accounts, stored with the
+// code of their asset
+type TransferRepository interface {
+	// Create returns ErrDuplicate when the source account already made a
+	// transfer with the idempotency key
+	Create(ctx context.Context, transfer Transfer) (Transfer, error)
+	Get(ctx context.Context, id uuid.UUID) (Transfer, error)
+	// GetByIdempotencyKey returns the transfer out of the account made with
+	// the key
+	GetByIdempotencyKey(ctx context.Context, fromAccountId uuid.UUID, key string) (Transfer, error)
+	// List returns a page of the transfers matching the filter sorted by
+	// creation time
+	List(ctx context.Context, filter TransferFilter, page Page) ([]Transfer, error)
+}
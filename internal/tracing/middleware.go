@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"strconv"
+
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/gofiber/fiber/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware starts a server span per request, continuing the trace of the
+// traceparent header when sent, and carries it in the request context so
+// handler and database spans become its children
+func Middleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(helper.Context(c), headers{c})
+		ctx, span := tracer.Start(ctx, c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+				semconv.ClientAddress(c.IP()),
+			),
+		)
+		defer span.End()
+		helper.WithContext(c, ctx)
+
+		err := c.Next()
+
+		// Spans are named by route pattern, unmatched paths keep the method only
+		status := helper.Status(c, err)
+		if route := c.Route().Path; status != fiber.StatusNotFound || route != "/" || c.Path() == "/" {
+			span.SetName(c.Method() + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, strconv.Itoa(status))
+			if err != nil {
+				span.RecordError(err)
+			}
+		}
+		return err
+	}
+}
+
+// headers reads and writes propagation fields on the request headers
+type headers struct {
+	c fiber.Ctx
+}
+
+func (h headers) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h headers) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h headers) Keys() []string {
+	keys := make([]string, 0, h.c.Request().Header.Len())
+	for key := range h.c.GetReqHeaders() {
+		keys = append(keys, key)
+	}
+	return keys
+}
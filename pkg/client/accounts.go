@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func (c *Client) CreateAccount(ctx context.Context, name string) (Account, error) {
+	var account Account
+	err := c.do(ctx, http.MethodPost, "/v1/accounts", nil, map[string]string{"name": name}, &account)
+	return account, err
+}
+
+func (c *Client) GetAccount(ctx context.Context, id uuid.UUID) (Account, error) {
+	var account Account
+	err := c.do(ctx, http.MethodGet, "/v1/accounts/"+id.String(), nil, nil, &account)
+	return account, err
+}
+
+// ListAccounts returns a page of accounts sorted by id (the default) or name
+func (c *Client) ListAccounts(ctx context.Context, options PageOptions) (Page[Account], error) {
+	var accounts Page[Account]
+	err := c.do(ctx, http.MethodGet, "/v1/accounts", pageQuery(options), nil, &accounts)
+	return accounts, err
+}
+
+// Accounts iterates over the accounts from the page options select
+func (c *Client) Accounts(ctx context.Context, options PageOptions) iter.Seq2[Account, error] {
+	return paginate(options, func(options PageOptions) (Page[Account], error) {
+		return c.ListAccounts(ctx, options)
+	})
+}
+
+// Deposit adds amount of the asset to the balance of the account right away,
+// RequestDeposit goes through approval and custody instead
+func (c *Client) Deposit(ctx context.Context, accountId uuid.UUID, assetCode string, amount decimal.Decimal) (BalanceUpdate, error) {
+	return c.updateBalance(ctx, accountId, "charge", assetCode, amount)
+}
+
+// Withdraw removes amount of the asset from the balance of the account right
+// away, RequestWithdrawal goes through approval and custody instead
+func (c *Client) Withdraw(ctx context.Context, accountId uuid.UUID, assetCode string, amount decimal.Decimal) (BalanceUpdate, error) {
+	return c.updateBalance(ctx, accountId, "remove", assetCode, amount)
+}
+
+func (c *Client) updateBalance(ctx context.Context, accountId uuid.UUID, operation, assetCode string, amount decimal.Decimal) (BalanceUpdate, error) {
+	body := struct {
+		AssetCode string          `json:"asset_code"`
+		Amount    decimal.Decimal `json:"amount"`
+	}{assetCode, amount}
+	var balance BalanceUpdate
+	err := c.do(ctx, http.MethodPost, "/v1/accounts/"+accountId.String()+"/"+operation, nil, body, &balance)
+	return balance, err
+}
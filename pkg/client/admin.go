@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// The admin endpoints need the admin token, see WithAdminToken
+
+func (c *Client) UpdateTradingStatus(ctx context.Context, instrumentId uuid.UUID, update TradingStatusUpdate) (TradingStatusChange, error) {
+	var change TradingStatusChange
+	err := c.do(ctx, http.MethodPost, "/v1/admin/instruments/"+instrumentId.String()+"/status", nil, update, &change)
+	return change, err
+}
+
+// ListAuditLog returns a page of the audit log sorted by id, newest first by
+// default
+func (c *Client) ListAuditLog(ctx context.Context, filter AuditFilter, options PageOptions) (Page[AuditEntry], error) {
+	query := pageQuery(options)
+	for key, value := range map[string]string{
+		"actor":       filter.Actor,
+		"action":      filter.Action,
+		"entity_type": filter.EntityType,
+		"entity_id":   filter.EntityId,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	var entries Page[AuditEntry]
+	err := c.do(ctx, http.MethodGet, "/v1/admin/audit", query, nil, &entries)
+	return entries, err
+}
+
+// AuditLog iterates over the audit entries matching filter from the page
+// options select
+func (c *Client) AuditLog(ctx context.Context, filter AuditFilter, options PageOptions) iter.Seq2[AuditEntry, error] {
+	return paginate(options, func(options PageOptions) (Page[AuditEntry], error) {
+		return c.ListAuditLog(ctx, filter, options)
+	})
+}
+
+// ListEvents returns up to limit events of the log after the sequence
+func (c *Client) ListEvents(ctx context.Context, after int64, limit int) (EventList, error) {
+	query := url.Values{"after": {strconv.FormatInt(after, 10)}}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	var events EventList
+	err := c.do(ctx, http.MethodGet, "/v1/admin/events", query, nil, &events)
+	return events, err
+}
+
+// Events iterates over the events of the log after the sequence, fetching
+// limit events at a time, until the end of the log
+func (c *Client) Events(ctx context.Context, after int64, limit int) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		for {
+			events, err := c.ListEvents(ctx, after, limit)
+			if err != nil {
+				yield(Event{}, err)
+				return
+			}
+			for _, event := range events.Items {
+				if !yield(event, nil) {
+					return
+				}
+				after = event.Sequence
+			}
+			if len(events.Items) < events.Limit {
+				return
+			}
+		}
+	}
+}
+
+// State rebuilds the book and balances from the whole event log
+func (c *Client) State(ctx context.Context) (State, error) {
+	var state State
+	err := c.do(ctx, http.MethodGet, "/v1/admin/events/state", nil, nil, &state)
+	return state, err
+}
+
+// StateAt rebuilds the book and balances as they were at the sequence
+func (c *Client) StateAt(ctx context.Context, sequence int64) (State, error) {
+	query := url.Values{"sequence": {strconv.FormatInt(sequence, 10)}}
+	var state State
+	err := c.do(ctx, http.MethodGet, "/v1/admin/events/state", query, nil, &state)
+	return state, err
+}
+
+// TakeSnapshot snapshots the state rebuilt from the event log and returns
+// its sequence
+func (c *Client) TakeSnapshot(ctx context.Context) (int64, error) {
+	var snapshot struct {
+		Sequence int64 `json:"sequence"`
+	}
+	err := c.do(ctx, http.MethodPost, "/v1/admin/events/snapshots", nil, nil, &snapshot)
+	return snapshot.Sequence, err
+}
+
+// LastReconciliation returns the report of the last reconciliation, a 404
+// *Error when none ran yet
+func (c *Client) LastReconciliation(ctx context.Context) (Reconciliation, error) {
+	var report Reconciliation
+	err := c.do(ctx, http.MethodGet, "/v1/admin/reconciliation", nil, nil, &report)
+	return report, err
+}
+
+func (c *Client) RunReconciliation(ctx context.Context) (Reconciliation, error) {
+	var report Reconciliation
+	err := c.do(ctx, http.MethodPost, "/v1/admin/reconciliation", nil, nil, &report)
+	return report, err
+}
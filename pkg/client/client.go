@@ -0,0 +1,225 @@
+// Package client is a Go client of the CLOB REST API.
+//
+// Requests are retried on network errors, 429 and 502 to 504 responses with
+// exponential backoff, honoring Retry-After. POST requests carry an
+// Idempotency-Key, the same on every retry, so a retried order is placed
+// once. Requests are signed when the client has the signing secret of the
+// server. Paginated lists come with iterators and market data is streamed
+// over WebSockets.
+//
+//	c, err := client.New("http://localhost:8000")
+//	account, err := c.CreateAccount(ctx, "desk")
+//	for order, err := range c.Orders(ctx, client.OrderFilter{AccountId: &account.Id}, 100) {
+//		...
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JhonesBR/go-clob/pkg/signing"
+	"github.com/google/uuid"
+)
+
+const (
+	// IdempotencyKeyHeader is honored by the server on POST requests
+	IdempotencyKeyHeader = "Idempotency-Key"
+	adminTokenHeader     = "X-Admin-Token"
+	userAgent            = "go-clob-client"
+)
+
+type Client struct {
+	baseURL       *url.URL
+	http          *http.Client
+	adminToken    string
+	signingSecret []byte
+	retries       int
+	backoff       time.Duration
+	maxBackoff    time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with client instead of a client with a
+// 30s timeout
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) { c.http = client }
+}
+
+// WithAdminToken authenticates the admin endpoints
+func WithAdminToken(token string) Option {
+	return func(c *Client) { c.adminToken = token }
+}
+
+// WithSigningSecret signs every request, the server requires it when it is
+// configured with a signing secret
+func WithSigningSecret(secret string) Option {
+	return func(c *Client) { c.signingSecret = []byte(secret) }
+}
+
+// WithRetries retries a request up to retries times, waiting backoff before
+// the first retry and doubling up to maxBackoff. 0 retries disables them.
+func WithRetries(retries int, backoff, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+		c.maxBackoff = maxBackoff
+	}
+}
+
+// New returns a client of the API served at baseURL, e.g.
+// http://localhost:8000
+func New(baseURL string, options ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("client: base url must be http or https, got %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    parsed,
+		http:       &http.Client{Timeout: 30 * time.Second},
+		retries:    3,
+		backoff:    100 * time.Millisecond,
+		maxBackoff: 5 * time.Second,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c, nil
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey makes the POST request sent with ctx use key instead of
+// a random one, so it can be retried across restarts of the caller
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// do sends a request and decodes the JSON response into out, when not nil
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	key := ""
+	if method == http.MethodPost {
+		key, _ = ctx.Value(idempotencyKey{}).(string)
+		if key == "" {
+			key = uuid.NewString()
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, path, query, payload, key)
+		if err == nil {
+			err = decode(resp, out)
+		}
+		wait, retry := c.retryAfter(ctx, attempt, resp, err)
+		if !retry {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// send sends one attempt of a request, signed at the time it is sent
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, payload []byte, key string) (*http.Response, error) {
+	target := *c.baseURL
+	target.Path += path
+	target.RawQuery = query.Encode()
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	c.authenticate(req.Header, method, req.URL.RequestURI(), payload)
+	req.Header.Set("Accept", "application/json, application/problem+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return c.http.Do(req)
+}
+
+// authenticate sets the user agent, admin token and signature headers
+func (c *Client) authenticate(header http.Header, method, uri string, payload []byte) {
+	header.Set("User-Agent", userAgent)
+	if c.adminToken != "" {
+		header.Set(adminTokenHeader, c.adminToken)
+	}
+	if c.signingSecret != nil {
+		timestamp := time.Now().Unix()
+		header.Set(signing.TimestampHeader, strconv.FormatInt(timestamp, 10))
+		header.Set(signing.SignatureHeader, signing.Sign(c.signingSecret, timestamp, method, uri, payload))
+	}
+}
+
+// retryAfter tells if an attempt failed in a way worth retrying and how long
+// to wait before
+func (c *Client) retryAfter(ctx context.Context, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= c.retries || err == nil || ctx.Err() != nil {
+		return 0, false
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		default:
+			return 0, false
+		}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+	} else if resp != nil {
+		// The response was received but could not be decoded
+		return 0, false
+	}
+
+	// Full jitter so clients failing together do not retry together
+	backoff := min(c.backoff<<attempt, c.maxBackoff)
+	return rand.N(backoff + 1), true
+}
+
+// decode reads the response into out, answers outside 2xx are an *Error
+func decode(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newError(resp.StatusCode, body)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
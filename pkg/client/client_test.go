@@ -0,0 +1,596 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JhonesBR/go-clob/internal/api"
+	"github.com/JhonesBR/go-clob/internal/api/funding"
+	"github.com/JhonesBR/go-clob/internal/api/orderbook"
+	"github.com/JhonesBR/go-clob/internal/circuitbreaker"
+	"github.com/JhonesBR/go-clob/internal/custody"
+	"github.com/JhonesBR/go-clob/internal/helper"
+	"github.com/JhonesBR/go-clob/internal/idempotency"
+	"github.com/JhonesBR/go-clob/internal/marketdata"
+	"github.com/JhonesBR/go-clob/internal/ratelimit"
+	"github.com/JhonesBR/go-clob/internal/reconcile"
+	"github.com/JhonesBR/go-clob/internal/risk"
+	"github.com/JhonesBR/go-clob/internal/shutdown"
+	"github.com/JhonesBR/go-clob/internal/storage"
+	"github.com/JhonesBR/go-clob/internal/storage/memory"
+	"github.com/JhonesBR/go-clob/pkg/client"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	adminToken    = "admin"
+	signingSecret = "secret"
+)
+
+// server serves the whole API against an in-memory store with signing on.
+// Metrics can only be registered once, so every test shares it and works
+// with its own accounts. ETH/BRL is traded by the stream test alone.
+type server struct {
+	url        string
+	instrument storage.Instrument
+	streamed   storage.Instrument
+	fundings   *funding.Service
+	rail       *custody.Fake
+}
+
+var testServer = sync.OnceValue(func() server {
+	store := memory.NewEmpty()
+	btc := store.AddAsset("BTC", "Bitcoin")
+	brl := store.AddAsset("BRL", "Brazilian Real")
+	instrument := store.AddInstrument(btc, brl)
+	streamed := store.AddInstrument(store.AddAsset("ETH", "Ether"), brl)
+
+	breakerConfig := circuitbreaker.DefaultConfig()
+	breakerConfig.ThresholdPercent = decimal.NewFromInt(1_000_000)
+	breaker := circuitbreaker.New(breakerConfig)
+	orders := orderbook.NewService(store, nil, risk.NewEngine(risk.Config{}), breaker)
+	rail := custody.NewFake()
+	fundings := funding.NewService(store, rail, map[string]decimal.Decimal{"BTC": decimal.NewFromInt(10)})
+	budget := ratelimit.Budget{Limit: 1_000_000, Window: time.Second}
+	limiter := ratelimit.New(ratelimit.Config{Orders: budget, Cancels: budget, Reads: budget})
+
+	feed, err := marketdata.New(context.Background(), store)
+	if err != nil {
+		panic(err)
+	}
+	go feed.Run(context.Background())
+
+	helper.SetAdminToken(adminToken)
+	app := fiber.New(fiber.Config{ErrorHandler: helper.ErrorHandler})
+	api.InitializeRoutes(app, store, shutdown.NewDrainer(), limiter, orders, fundings, breaker, reconcile.New(store), feed, idempotency.New(time.Hour), signingSecret)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	go app.Listener(listener, fiber.ListenConfig{DisableStartupMessage: true})
+	return server{url: "http://" + listener.Addr().String(), instrument: instrument, streamed: streamed, fundings: fundings, rail: rail}
+})
+
+func newClient(t *testing.T, baseURL string, options ...client.Option) *client.Client {
+	t.Helper()
+	options = append([]client.Option{
+		client.WithAdminToken(adminToken),
+		client.WithSigningSecret(signingSecret),
+		client.WithRetries(3, time.Millisecond, 10*time.Millisecond),
+	}, options...)
+	c, err := client.New(baseURL, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// fundedAccount creates an account holding 100 BTC, 100 ETH and 1,000,000
+// BRL
+func fundedAccount(t *testing.T, c *client.Client) client.Account {
+	t.Helper()
+	ctx := context.Background()
+	account, err := c.CreateAccount(ctx, "desk-"+uuid.NewString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Deposit(ctx, account.Id, "BTC", decimal.NewFromInt(100)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Deposit(ctx, account.Id, "ETH", decimal.NewFromInt(100)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Deposit(ctx, account.Id, "BRL", decimal.NewFromInt(1_000_000)); err != nil {
+		t.Fatal(err)
+	}
+	return account
+}
+
+func order(account client.Account, assetCode string, side client.Side, price, quantity int64) client.PlaceOrderRequest {
+	return client.PlaceOrderRequest{
+		AccountId: account.Id,
+		AssetCode: assetCode,
+		Side:      side,
+		Price:     decimal.NewFromInt(price),
+		Quantity:  decimal.NewFromInt(quantity),
+	}
+}
+
+// expectError checks err is a problem of the status and code
+func expectError(t *testing.T, err error, status int, code string) {
+	t.Helper()
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != status || apiErr.Code != code {
+		t.Fatalf("got %v, want a %d %s", err, status, code)
+	}
+}
+
+func TestAccountsAndOrders(t *testing.T) {
+	srv := testServer()
+	c := newClient(t, srv.url)
+	ctx := context.Background()
+
+	account := fundedAccount(t, c)
+	withdrawn, err := c.Withdraw(ctx, account.Id, "BTC", decimal.NewFromInt(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !withdrawn.Balance.Equal(decimal.NewFromInt(90)) {
+		t.Fatalf("balance after withdrawal is %s, want 90", withdrawn.Balance)
+	}
+	got, err := c.GetAccount(ctx, account.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != account.Name || len(got.Balances) != 3 {
+		t.Fatalf("account is %+v", got)
+	}
+
+	_, err = c.GetAccount(ctx, uuid.New())
+	expectError(t, err, http.StatusNotFound, client.CodeAccountNotFound)
+	_, err = c.Deposit(ctx, uuid.New(), "BTC", decimal.NewFromInt(1))
+	expectError(t, err, http.StatusNotFound, client.CodeAccountNotFound)
+	_, err = c.Deposit(ctx, account.Id, "XYZ", decimal.NewFromInt(1))
+	expectError(t, err, http.StatusNotFound, client.CodeAssetNotFound)
+	_, err = c.Withdraw(ctx, account.Id, "BTC", decimal.Zero)
+	expectError(t, err, http.StatusUnprocessableEntity, client.CodeValidationFailed)
+	err = c.PlaceOrder(ctx, order(account, "BTC", client.Buy, 1_000_000, 1_000_000))
+	expectError(t, err, http.StatusPaymentRequired, client.CodeInsufficientFunds)
+
+	for price := int64(100); price < 105; price++ {
+		if err := c.PlaceOrder(ctx, order(account, "BTC", client.Buy, price, 1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	filter := client.OrderFilter{AccountId: &account.Id}
+	var placed []client.Order
+	for order, err := range c.Orders(ctx, filter, client.PageOptions{Size: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		placed = append(placed, order)
+	}
+	if len(placed) != 5 {
+		t.Fatalf("iterated over %d orders, want 5", len(placed))
+	}
+	for i, order := range placed {
+		if !order.Price.Equal(decimal.NewFromInt(100+int64(i))) || order.Status != client.Open {
+			t.Errorf("order %d is %+v", i, order)
+		}
+	}
+
+	if err := c.CancelOrder(ctx, placed[0].Id); err != nil {
+		t.Fatal(err)
+	}
+	err = c.CancelOrder(ctx, placed[0].Id)
+	expectError(t, err, http.StatusConflict, client.CodeOrderNotCancelable)
+	var apiErr *client.Error
+	if errors.As(err, &apiErr); apiErr.Details["order_status"] != string(client.Canceled) || apiErr.RequestId == "" {
+		t.Fatalf("problem of the second cancel is %s", apiErr.Body)
+	}
+	detail, err := c.GetOrder(ctx, placed[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.CancelReason == nil || *detail.CancelReason != client.CancelRequested || !detail.ReservedAmount.IsZero() ||
+		!detail.RemainingQuantity.Equal(decimal.NewFromInt(1)) || detail.ReservedAssetCode == "" {
+		t.Fatalf("detail of the canceled order is %+v", detail)
+	}
+	events, err := c.OrderEvents(ctx, placed[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Type != client.OrderEventAccepted || events[1].Type != client.OrderEventCanceled {
+		t.Fatalf("timeline of the canceled order is %+v", events)
+	}
+	_, err = c.GetOrder(ctx, uuid.New())
+	expectError(t, err, http.StatusNotFound, client.CodeOrderNotFound)
+	history, err := c.ListOrderHistory(ctx, filter, client.PageOptions{})
+	if err != nil || len(history.Items) != 0 {
+		t.Fatalf("history before archiving is %+v (%v), want empty", history, err)
+	}
+
+	page, err := c.ListOrders(ctx, filter, client.PageOptions{Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Status != client.Canceled || page.NextCursor == "" {
+		t.Fatalf("first page is %+v, want the canceled order and a cursor", page)
+	}
+
+	// Filters and sorts apply to every page
+	minPrice := decimal.NewFromInt(102)
+	open := client.OrderFilter{AccountId: &account.Id, Statuses: []client.OrderStatus{client.Open}, MinPrice: &minPrice}
+	var prices []string
+	for order, err := range c.Orders(ctx, open, client.PageOptions{Size: 1, Sort: "-price"}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		prices = append(prices, order.Price.String())
+	}
+	if strings.Join(prices, ",") != "104,103,102" {
+		t.Fatalf("open orders from 102 by price descending are %v", prices)
+	}
+	page, err = c.ListOrders(ctx, client.OrderFilter{AccountId: &account.Id, CreatedTo: &placed[2].CreatedAt}, client.PageOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 2 || page.Items[1].Id != placed[1].Id || page.NextCursor != "" {
+		t.Fatalf("orders created before the third are %+v", page)
+	}
+	page, err = c.ListOrders(ctx, client.OrderFilter{AccountId: &account.Id, Side: client.Sell}, client.PageOptions{})
+	if err != nil || len(page.Items) != 0 {
+		t.Fatalf("sell orders are %+v (%v), want none", page.Items, err)
+	}
+
+	_, err = c.ListOrders(ctx, filter, client.PageOptions{Sort: "quantity"})
+	expectError(t, err, http.StatusBadRequest, client.CodeInvalidRequest)
+	_, err = c.ListOrders(ctx, filter, client.PageOptions{Cursor: "not a cursor"})
+	expectError(t, err, http.StatusBadRequest, client.CodeInvalidRequest)
+	first, err := c.ListOrders(ctx, filter, client.PageOptions{Size: 1, Sort: "price"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.ListOrders(ctx, filter, client.PageOptions{Sort: "-price", Cursor: first.NextCursor})
+	expectError(t, err, http.StatusBadRequest, client.CodeInvalidRequest)
+
+	seen := 0
+	for account, err := range c.Accounts(ctx, client.PageOptions{Size: 1}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen++; account.Id == got.Id {
+			break
+		}
+	}
+	if seen == 0 {
+		t.Fatal("iterated over no account")
+	}
+}
+
+func TestMarketDataAndAdmin(t *testing.T) {
+	srv := testServer()
+	c := newClient(t, srv.url)
+	ctx := context.Background()
+
+	instruments, err := c.ListInstruments(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instruments) != 2 {
+		t.Fatalf("instruments are %+v", instruments)
+	}
+	if _, err := c.GetInstrument(ctx, srv.instrument.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetTicker(ctx, srv.instrument.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAuction(ctx, srv.instrument.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := c.ListEvents(ctx, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events.Items) != 1 {
+		t.Fatalf("listed %d events, want 1", len(events.Items))
+	}
+	var last int64
+	for event, err := range c.Events(ctx, 0, 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event.Sequence <= last {
+			t.Fatalf("event %d after %d", event.Sequence, last)
+		}
+		last = event.Sequence
+	}
+	if _, err := c.State(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.TakeSnapshot(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := c.RunReconciliation(ctx); err != nil || !report.Balanced {
+		t.Fatalf("reconciliation is %+v, %v", report, err)
+	}
+	if _, err := c.LastReconciliation(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ready, err := c.Ready(ctx); err != nil || ready.Status != "ok" {
+		t.Fatalf("readiness is %+v, %v", ready, err)
+	}
+}
+
+func TestFundings(t *testing.T) {
+	srv := testServer()
+	c := newClient(t, srv.url)
+	ctx := context.Background()
+	account := fundedAccount(t, c)
+
+	_, err := c.RequestWithdrawal(ctx, account.Id, "BTC", decimal.NewFromInt(1000))
+	expectError(t, err, http.StatusPaymentRequired, client.CodeInsufficientFunds)
+	_, err = c.GetFunding(ctx, uuid.New())
+	expectError(t, err, http.StatusNotFound, client.CodeFundingNotFound)
+
+	// Above the BTC threshold the withdrawal waits for an operator, holding its amount
+	withdrawal, err := c.RequestWithdrawal(ctx, account.Id, "BTC", decimal.NewFromInt(20))
+	if err != nil || withdrawal.Status != client.FundingRequested {
+		t.Fatalf("withdrawal is %+v, %v", withdrawal, err)
+	}
+	_, err = c.ApproveFunding(ctx, withdrawal.Id, "ops")
+	expectError(t, err, http.StatusConflict, client.CodeInvalidStatusChange)
+	deposit, err := c.RequestDeposit(ctx, account.Id, "BTC", decimal.NewFromInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.fundings.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := c.RunReconciliation(ctx); err != nil || !report.Balanced {
+		t.Fatalf("reconciliation is %+v, %v", report, err)
+	}
+
+	if withdrawal, err = c.GetFunding(ctx, withdrawal.Id); err != nil || withdrawal.Status != client.FundingPendingApproval {
+		t.Fatalf("withdrawal is %+v, %v", withdrawal, err)
+	}
+	if deposit, err = c.GetFunding(ctx, deposit.Id); err != nil || deposit.Status != client.FundingCompleted || deposit.Reference == nil {
+		t.Fatalf("deposit is %+v, %v", deposit, err)
+	}
+	rejected, err := c.RejectFunding(ctx, withdrawal.Id, "ops", "unverified address")
+	if err != nil || rejected.Status != client.FundingRejected || *rejected.ReviewedBy != "ops" || *rejected.Reason != "unverified address" {
+		t.Fatalf("rejected withdrawal is %+v, %v", rejected, err)
+	}
+
+	// A withdrawal failing on the rail is released too
+	failed, err := c.RequestWithdrawal(ctx, account.Id, "BTC", decimal.NewFromInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.rail.Fail(failed.Id, "address rejected")
+	if err := srv.fundings.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if failed, err = c.GetFunding(ctx, failed.Id); err != nil || failed.Status != client.FundingFailed || *failed.Reason != "address rejected" {
+		t.Fatalf("failed withdrawal is %+v, %v", failed, err)
+	}
+	got, err := c.GetAccount(ctx, account.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, balance := range got.Balances {
+		if balance.AssetCode == "BTC" && !balance.Balance.Equal(decimal.NewFromInt(105)) {
+			t.Fatalf("BTC balance is %s, want 105", balance.Balance)
+		}
+	}
+
+	var listed []client.Funding
+	for funding, err := range c.Fundings(ctx, client.FundingFilter{AccountId: &account.Id}, client.PageOptions{Size: 1}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed = append(listed, funding)
+	}
+	if len(listed) != 3 || listed[0].Id != withdrawal.Id || listed[1].Id != deposit.Id || listed[2].Id != failed.Id {
+		t.Fatalf("fundings are %+v", listed)
+	}
+	page, err := c.ListFundings(ctx, client.FundingFilter{AccountId: &account.Id, Kind: client.DepositFunding, Statuses: []client.FundingStatus{client.FundingCompleted}}, client.PageOptions{})
+	if err != nil || len(page.Items) != 1 || page.Items[0].Id != deposit.Id {
+		t.Fatalf("completed deposits are %+v, %v", page, err)
+	}
+}
+
+func TestTransfers(t *testing.T) {
+	srv := testServer()
+	c := newClient(t, srv.url)
+	ctx := context.Background()
+	from := fundedAccount(t, c)
+	to, err := c.CreateAccount(ctx, "desk-"+uuid.NewString())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Transfer(ctx, from.Id, to.Id, "BTC", decimal.NewFromInt(1000), "")
+	expectError(t, err, http.StatusPaymentRequired, client.CodeInsufficientFunds)
+	_, err = c.Transfer(ctx, from.Id, from.Id, "BTC", decimal.NewFromInt(1), "")
+	expectError(t, err, http.StatusUnprocessableEntity, client.CodeValidationFailed)
+	_, err = c.Transfer(ctx, from.Id, uuid.New(), "BTC", decimal.NewFromInt(1), "")
+	expectError(t, err, http.StatusNotFound, client.CodeAccountNotFound)
+	_, err = c.GetTransfer(ctx, uuid.New())
+	expectError(t, err, http.StatusNotFound, client.CodeTransferNotFound)
+
+	// A retry with the same key returns the transfer already made
+	keyed := client.WithIdempotencyKey(ctx, uuid.NewString())
+	rent, err := c.Transfer(keyed, from.Id, to.Id, "BTC", decimal.NewFromInt(30), "rent")
+	if err != nil || rent.Memo == nil || *rent.Memo != "rent" {
+		t.Fatalf("transfer is %+v, %v", rent, err)
+	}
+	if retried, err := c.Transfer(keyed, from.Id, to.Id, "BTC", decimal.NewFromInt(30), "rent"); err != nil || retried.Id != rent.Id {
+		t.Fatalf("retried transfer is %+v, %v", retried, err)
+	}
+	back, err := c.Transfer(ctx, to.Id, from.Id, "BTC", decimal.NewFromInt(5), "")
+	if err != nil || back.Memo != nil {
+		t.Fatalf("transfer back is %+v, %v", back, err)
+	}
+	if got, err := c.GetTransfer(ctx, back.Id); err != nil || got.FromAccountId != to.Id || !got.Amount.Equal(decimal.NewFromInt(5)) {
+		t.Fatalf("transfer back is %+v, %v", got, err)
+	}
+
+	for id, want := range map[uuid.UUID]int64{from.Id: 75, to.Id: 25} {
+		got, err := c.GetAccount(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, balance := range got.Balances {
+			if balance.AssetCode == "BTC" && !balance.Balance.Equal(decimal.NewFromInt(want)) {
+				t.Fatalf("BTC balance of %s is %s, want %d", id, balance.Balance, want)
+			}
+		}
+	}
+	if report, err := c.RunReconciliation(ctx); err != nil || !report.Balanced {
+		t.Fatalf("reconciliation is %+v, %v", report, err)
+	}
+
+	var listed []client.Transfer
+	for transfer, err := range c.Transfers(ctx, &to.Id, client.PageOptions{Size: 1}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed = append(listed, transfer)
+	}
+	if len(listed) != 2 || listed[0].Id != rent.Id || listed[1].Id != back.Id {
+		t.Fatalf("transfers are %+v", listed)
+	}
+}
+
+func TestSigning(t *testing.T) {
+	srv := testServer()
+	unsigned, err := client.New(srv.url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = unsigned.ListInstruments(context.Background())
+	expectError(t, err, http.StatusUnauthorized, client.CodeInvalidSignature)
+	wrong := newClient(t, srv.url, client.WithSigningSecret("wrong"))
+	_, err = wrong.ListInstruments(context.Background())
+	expectError(t, err, http.StatusUnauthorized, client.CodeInvalidSignature)
+	// Probes are not signed
+	if _, err := unsigned.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRetry loses the response of the first order placement, the retry
+// carries the same idempotency key so the order is placed once
+func TestRetry(t *testing.T) {
+	srv := testServer()
+	target, err := url.Parse(srv.url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	var placements, replays atomic.Int32
+	lossy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/order_book" {
+			proxy.ServeHTTP(w, r)
+			return
+		}
+		recorder := httptest.NewRecorder()
+		proxy.ServeHTTP(recorder, r)
+		if recorder.Header().Get(idempotency.ReplayedHeader) != "" {
+			replays.Add(1)
+		}
+		if placements.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(recorder.Code)
+		io.Copy(w, recorder.Body)
+	}))
+	defer lossy.Close()
+
+	c := newClient(t, lossy.URL)
+	ctx := context.Background()
+	account := fundedAccount(t, c)
+	if err := c.PlaceOrder(ctx, order(account, "BTC", client.Sell, 1_000_000, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if placements.Load() != 2 || replays.Load() != 1 {
+		t.Fatalf("%d placements and %d replays, want 2 and 1", placements.Load(), replays.Load())
+	}
+	orders, err := c.ListOrders(ctx, client.OrderFilter{AccountId: &account.Id}, client.PageOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders.Items) != 1 {
+		t.Fatalf("placed %d orders, want 1", len(orders.Items))
+	}
+}
+
+func TestStreams(t *testing.T) {
+	srv := testServer()
+	c := newClient(t, srv.url)
+	ctx := context.Background()
+
+	book, err := c.SubscribeBook(ctx, srv.streamed.Id, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer book.Close()
+	trades, err := c.SubscribeTrades(ctx, srv.streamed.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer trades.Close()
+	if _, err := book.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	seller := fundedAccount(t, c)
+	buyer := fundedAccount(t, c)
+	if err := c.PlaceOrder(ctx, order(seller, "ETH", client.Sell, 10, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.PlaceOrder(ctx, order(buyer, "ETH", client.Buy, 10, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	trade, err := trades.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trade.InstrumentId != srv.streamed.Id || !trade.Price.Equal(decimal.NewFromInt(10)) || !trade.Quantity.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("trade is %+v", trade)
+	}
+	update, err := book.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update.InstrumentId != srv.streamed.Id || update.Sequence == 0 {
+		t.Fatalf("book is %+v", update)
+	}
+
+	trades.Close()
+	if _, err := trades.Recv(); err != io.EOF {
+		t.Fatalf("receiving after close: got %v, want io.EOF", err)
+	}
+
+	_, err = c.SubscribeTrades(ctx, uuid.New())
+	expectError(t, err, http.StatusNotFound, client.CodeInstrumentNotFound)
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Error is an answer of the API outside 2xx, read from its RFC 7807 problem
+// document. Code is stable, e.g. INSUFFICIENT_FUNDS, match on it rather than
+// on Message. Details are the other members of the document, e.g. the failed
+// risk checks under "reasons".
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestId  string
+	Details    map[string]any
+	Body       []byte
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("client: %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("client: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Codes of the errors, Error.Code is one of them
+const (
+	CodeInvalidRequest   = "INVALID_REQUEST"
+	CodeInvalidId        = "INVALID_ID"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeInvalidSignature = "INVALID_SIGNATURE"
+	CodeForbidden        = "FORBIDDEN"
+	CodeNotFound         = "NOT_FOUND"
+
+	CodeAccountNotFound        = "ACCOUNT_NOT_FOUND"
+	CodeAssetNotFound          = "ASSET_NOT_FOUND"
+	CodeInstrumentNotFound     = "INSTRUMENT_NOT_FOUND"
+	CodeOrderNotFound          = "ORDER_NOT_FOUND"
+	CodeReconciliationNotFound = "RECONCILIATION_NOT_FOUND"
+	CodeFundingNotFound        = "FUNDING_NOT_FOUND"
+	CodeTransferNotFound       = "TRANSFER_NOT_FOUND"
+
+	CodeInsufficientFunds     = "INSUFFICIENT_FUNDS"
+	CodeRiskCheckFailed       = "RISK_CHECK_FAILED"
+	CodeInstrumentNotTrading  = "INSTRUMENT_NOT_TRADING"
+	CodeInstrumentHalted      = "INSTRUMENT_HALTED"
+	CodeOrderNotCancelable    = "ORDER_NOT_CANCELABLE"
+	CodeQuantityBelowFilled   = "QUANTITY_BELOW_FILLED"
+	CodeInvalidStatusChange   = "INVALID_STATUS_CHANGE"
+	CodeIdempotencyKeyInvalid = "IDEMPOTENCY_KEY_INVALID"
+	CodeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyKeyPending = "IDEMPOTENCY_KEY_IN_PROGRESS"
+
+	CodeUpgradeRequired = "UPGRADE_REQUIRED"
+	CodeRateLimited     = "RATE_LIMITED"
+	CodeShuttingDown    = "SHUTTING_DOWN"
+	CodeInternal        = "INTERNAL_ERROR"
+)
+
+// problemMembers are the members of a problem document that are not details
+var problemMembers = []string{"type", "title", "status", "detail", "instance", "code", "request_id"}
+
+// newError parses a problem document, Message is the status text when the
+// body is not one
+func newError(status int, body []byte) *Error {
+	err := &Error{StatusCode: status, Message: http.StatusText(status), Body: body}
+	var problem map[string]any
+	if json.Unmarshal(body, &problem) != nil {
+		return err
+	}
+	if detail, ok := problem["detail"].(string); ok && detail != "" {
+		err.Message = detail
+	}
+	err.Code, _ = problem["code"].(string)
+	err.RequestId, _ = problem["request_id"].(string)
+	for _, member := range problemMembers {
+		delete(problem, member)
+	}
+	if len(problem) > 0 {
+		err.Details = problem
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// RequestDeposit requests a deposit of amount of the asset to the account,
+// credited once the custody rail collects it
+func (c *Client) RequestDeposit(ctx context.Context, accountId uuid.UUID, assetCode string, amount decimal.Decimal) (Funding, error) {
+	return c.requestFunding(ctx, accountId, "deposits", assetCode, amount)
+}
+
+// RequestWithdrawal requests a withdrawal of amount of the asset from the
+// account, held from its balance until it is paid out or released
+func (c *Client) RequestWithdrawal(ctx context.Context, accountId uuid.UUID, assetCode string, amount decimal.Decimal) (Funding, error) {
+	return c.requestFunding(ctx, accountId, "withdrawals", assetCode, amount)
+}
+
+func (c *Client) requestFunding(ctx context.Context, accountId uuid.UUID, kind, assetCode string, amount decimal.Decimal) (Funding, error) {
+	body := struct {
+		AssetCode string          `json:"asset_code"`
+		Amount    decimal.Decimal `json:"amount"`
+	}{assetCode, amount}
+	var funding Funding
+	err := c.do(ctx, http.MethodPost, "/v1/accounts/"+accountId.String()+"/"+kind, nil, body, &funding)
+	return funding, err
+}
+
+func (c *Client) GetFunding(ctx context.Context, id uuid.UUID) (Funding, error) {
+	var funding Funding
+	err := c.do(ctx, http.MethodGet, "/v1/fundings/"+id.String(), nil, nil, &funding)
+	return funding, err
+}
+
+// ListFundings returns a page of the deposits and withdrawals matching the
+// filter, sorted by creation time
+func (c *Client) ListFundings(ctx context.Context, filter FundingFilter, options PageOptions) (Page[Funding], error) {
+	var fundings Page[Funding]
+	err := c.do(ctx, http.MethodGet, "/v1/fundings", fundingQuery(filter, options), nil, &fundings)
+	return fundings, err
+}
+
+func fundingQuery(filter FundingFilter, options PageOptions) url.Values {
+	query := pageQuery(options)
+	if filter.AccountId != nil {
+		query.Set("account_id", filter.AccountId.String())
+	}
+	if filter.Kind != "" {
+		query.Set("kind", string(filter.Kind))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		query.Set("status", strings.Join(statuses, ","))
+	}
+	return query
+}
+
+// Fundings iterates over the fundings matching filter from the page options
+// select
+func (c *Client) Fundings(ctx context.Context, filter FundingFilter, options PageOptions) iter.Seq2[Funding, error] {
+	return paginate(options, func(options PageOptions) (Page[Funding], error) {
+		return c.ListFundings(ctx, filter, options)
+	})
+}
+
+// ApproveFunding approves a funding pending approval on behalf of the
+// operator, it needs the admin token
+func (c *Client) ApproveFunding(ctx context.Context, id uuid.UUID, operator string) (Funding, error) {
+	var funding Funding
+	err := c.do(ctx, http.MethodPost, "/v1/admin/fundings/"+id.String()+"/approve", nil, map[string]string{"operator": operator}, &funding)
+	return funding, err
+}
+
+// RejectFunding rejects a funding pending approval on behalf of the operator,
+// it needs the admin token
+func (c *Client) RejectFunding(ctx context.Context, id uuid.UUID, operator, reason string) (Funding, error) {
+	var funding Funding
+	body := map[string]string{"operator": operator, "reason": reason}
+	err := c.do(ctx, http.MethodPost, "/v1/admin/fundings/"+id.String()+"/reject", nil, body, &funding)
+	return funding, err
+}
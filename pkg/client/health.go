@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+func (c *Client) Health(ctx context.Context) (Health, error) {
+	var health Health
+	err := c.do(ctx, http.MethodGet, "/healthz", nil, nil, &health)
+	return health, err
+}
+
+// Ready returns the readiness of the server, a server that is not ready is
+// not an error, its failing checks are returned
+func (c *Client) Ready(ctx context.Context) (Readiness, error) {
+	var readiness Readiness
+	err := c.do(ctx, http.MethodGet, "/readyz", nil, nil, &readiness)
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusServiceUnavailable {
+		return readiness, json.Unmarshal(apiErr.Body, &readiness)
+	}
+	return readiness, err
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+func (c *Client) ListInstruments(ctx context.Context) ([]Instrument, error) {
+	var instruments []Instrument
+	err := c.do(ctx, http.MethodGet, "/v1/instruments", nil, nil, &instruments)
+	return instruments, err
+}
+
+func (c *Client) GetInstrument(ctx context.Context, id uuid.UUID) (Instrument, error) {
+	var instrument Instrument
+	err := c.do(ctx, http.MethodGet, "/v1/instruments/"+id.String(), nil, nil, &instrument)
+	return instrument, err
+}
+
+func (c *Client) GetTicker(ctx context.Context, instrumentId uuid.UUID) (Ticker, error) {
+	var ticker Ticker
+	err := c.do(ctx, http.MethodGet, "/v1/instruments/"+instrumentId.String()+"/ticker", nil, nil, &ticker)
+	return ticker, err
+}
+
+// GetAuction returns how the book of the instrument would uncross in an
+// auction now
+func (c *Client) GetAuction(ctx context.Context, instrumentId uuid.UUID) (Auction, error) {
+	var auction Auction
+	err := c.do(ctx, http.MethodGet, "/v1/instruments/"+instrumentId.String()+"/auction", nil, nil, &auction)
+	return auction, err
+}
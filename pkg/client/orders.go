@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlaceOrder places a limit order, it is matched right away against the
+// resting orders and rests on the book for the remaining quantity
+func (c *Client) PlaceOrder(ctx context.Context, order PlaceOrderRequest) error {
+	return c.do(ctx, http.MethodPost, "/v1/order_book", nil, order, nil)
+}
+
+// CancelOrder cancels an open or partially filled order
+func (c *Client) CancelOrder(ctx context.Context, id uuid.UUID) error {
+	return c.do(ctx, http.MethodPost, "/v1/order_book/"+id.String()+"/cancel", nil, nil, nil)
+}
+
+// GetOrder returns the order with its fills, reserved funds and cancel reason
+func (c *Client) GetOrder(ctx context.Context, id uuid.UUID) (OrderDetail, error) {
+	var order OrderDetail
+	err := c.do(ctx, http.MethodGet, "/v1/order_book/"+id.String(), nil, nil, &order)
+	return order, err
+}
+
+// OrderEvents returns the lifecycle of the order, oldest step first
+func (c *Client) OrderEvents(ctx context.Context, id uuid.UUID) ([]OrderEvent, error) {
+	var timeline struct {
+		Events []OrderEvent `json:"events"`
+	}
+	err := c.do(ctx, http.MethodGet, "/v1/order_book/"+id.String()+"/events", nil, nil, &timeline)
+	return timeline.Events, err
+}
+
+// ListOrders returns a page of the live orders matching filter sorted by
+// created_at (the default, oldest first) or price. Filled and canceled orders
+// are listed until they are archived, see ListOrderHistory.
+func (c *Client) ListOrders(ctx context.Context, filter OrderFilter, options PageOptions) (Page[Order], error) {
+	var orders Page[Order]
+	err := c.do(ctx, http.MethodGet, "/v1/order_book", orderQuery(filter, options), nil, &orders)
+	return orders, err
+}
+
+// ListOrderHistory returns a page of the archived filled and canceled orders
+// matching filter, sorted like ListOrders
+func (c *Client) ListOrderHistory(ctx context.Context, filter OrderFilter, options PageOptions) (Page[Order], error) {
+	var orders Page[Order]
+	err := c.do(ctx, http.MethodGet, "/v1/order_history", orderQuery(filter, options), nil, &orders)
+	return orders, err
+}
+
+func orderQuery(filter OrderFilter, options PageOptions) url.Values {
+	query := pageQuery(options)
+	if filter.AccountId != nil {
+		query.Set("account_id", filter.AccountId.String())
+	}
+	if filter.InstrumentId != nil {
+		query.Set("instrument_id", filter.InstrumentId.String())
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		query.Set("status", strings.Join(statuses, ","))
+	}
+	if filter.Side != "" {
+		query.Set("side", string(filter.Side))
+	}
+	if filter.MinPrice != nil {
+		query.Set("min_price", filter.MinPrice.String())
+	}
+	if filter.MaxPrice != nil {
+		query.Set("max_price", filter.MaxPrice.String())
+	}
+	if filter.CreatedFrom != nil {
+		query.Set("created_from", filter.CreatedFrom.Format(time.RFC3339Nano))
+	}
+	if filter.CreatedTo != nil {
+		query.Set("created_to", filter.CreatedTo.Format(time.RFC3339Nano))
+	}
+	return query
+}
+
+// Orders iterates over the orders matching filter from the page options
+// select
+func (c *Client) Orders(ctx context.Context, filter OrderFilter, options PageOptions) iter.Seq2[Order, error] {
+	return paginate(options, func(options PageOptions) (Page[Order], error) {
+		return c.ListOrders(ctx, filter, options)
+	})
+}
+
+// OrderHistory iterates over the archived orders matching filter from the
+// page options select
+func (c *Client) OrderHistory(ctx context.Context, filter OrderFilter, options PageOptions) iter.Seq2[Order, error] {
+	return paginate(options, func(options PageOptions) (Page[Order], error) {
+		return c.ListOrderHistory(ctx, filter, options)
+	})
+}
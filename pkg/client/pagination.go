@@ -0,0 +1,46 @@
+package client
+
+import (
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+func pageQuery(options PageOptions) url.Values {
+	query := url.Values{}
+	if options.Size > 0 {
+		query.Set("size", strconv.Itoa(options.Size))
+	}
+	if options.Sort != "" {
+		query.Set("sort", options.Sort)
+	}
+	if options.Cursor != "" {
+		query.Set("cursor", options.Cursor)
+	}
+	return query
+}
+
+// paginate iterates over the items of every page from the one options
+// select, following the cursors up to the last page. An error ends the
+// iteration.
+func paginate[T any](options PageOptions, list func(options PageOptions) (Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			page, err := list(options)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			options.Cursor = page.NextCursor
+		}
+	}
+}
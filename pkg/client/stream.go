@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/fasthttp/websocket"
+	"github.com/google/uuid"
+)
+
+// StreamError is the reason the server closed a stream. A slow subscriber is
+// closed with websocket.CloseTryAgainLater and may subscribe again.
+type StreamError struct {
+	Code   int
+	Reason string
+}
+
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("client: stream closed with %d: %s", e.Code, e.Reason)
+}
+
+// Subscription receives the messages of a stream, it is not safe for
+// concurrent use
+type Subscription[T any] struct {
+	conn   *websocket.Conn
+	closed atomic.Bool
+}
+
+// Recv blocks until the next message. It returns io.EOF once the
+// subscription is closed and a *StreamError when the server closed it.
+func (s *Subscription[T]) Recv() (T, error) {
+	var msg T
+	err := s.conn.ReadJSON(&msg)
+	if err == nil {
+		return msg, nil
+	}
+	if s.closed.Load() {
+		return msg, io.EOF
+	}
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		return msg, &StreamError{Code: closeErr.Code, Reason: closeErr.Text}
+	}
+	return msg, err
+}
+
+// Close ends the subscription, a blocked Recv returns io.EOF
+func (s *Subscription[T]) Close() error {
+	if s.closed.Swap(true) {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// SubscribeTrades streams the trades of the instrument executed after the
+// subscription
+func (c *Client) SubscribeTrades(ctx context.Context, instrumentId uuid.UUID) (*Subscription[Trade], error) {
+	return subscribe[Trade](ctx, c, "/v1/instruments/"+instrumentId.String()+"/trades/stream", nil)
+}
+
+// SubscribeBook streams the book of the instrument, with depth price levels
+// per side or all when 0, first as it is then each time it changes
+func (c *Client) SubscribeBook(ctx context.Context, instrumentId uuid.UUID, depth int) (*Subscription[Book], error) {
+	query := url.Values{}
+	if depth > 0 {
+		query.Set("depth", strconv.Itoa(depth))
+	}
+	return subscribe[Book](ctx, c, "/v1/instruments/"+instrumentId.String()+"/book/stream", query)
+}
+
+// subscribe dials the stream, ctx bounds the handshake only
+func subscribe[T any](ctx context.Context, c *Client, path string, query url.Values) (*Subscription[T], error) {
+	target := *c.baseURL
+	target.Path += path
+	target.RawQuery = query.Encode()
+	if target.Scheme == "https" {
+		target.Scheme = "wss"
+	} else {
+		target.Scheme = "ws"
+	}
+
+	header := http.Header{}
+	c.authenticate(header, http.MethodGet, target.RequestURI(), nil)
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, target.String(), header)
+	if errors.Is(err, websocket.ErrBadHandshake) && resp != nil {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newError(resp.StatusCode, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Subscription[T]{conn: conn}, nil
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Transfer moves amount of the asset from an account to another. The server
+// keeps the Idempotency-Key with the transfer, so a retry returns the
+// transfer already made rather than moving the amount twice.
+func (c *Client) Transfer(ctx context.Context, fromAccountId, toAccountId uuid.UUID, assetCode string, amount decimal.Decimal, memo string) (Transfer, error) {
+	body := struct {
+		FromAccountId uuid.UUID       `json:"from_account_id"`
+		ToAccountId   uuid.UUID       `json:"to_account_id"`
+		AssetCode     string          `json:"asset_code"`
+		Amount        decimal.Decimal `json:"amount"`
+		Memo          string          `json:"memo,omitempty"`
+	}{fromAccountId, toAccountId, assetCode, amount, memo}
+	var transfer Transfer
+	err := c.do(ctx, http.MethodPost, "/v1/transfers", nil, body, &transfer)
+	return transfer, err
+}
+
+func (c *Client) GetTransfer(ctx context.Context, id uuid.UUID) (Transfer, error) {
+	var transfer Transfer
+	err := c.do(ctx, http.MethodGet, "/v1/transfers/"+id.String(), nil, nil, &transfer)
+	return transfer, err
+}
+
+// ListTransfers returns a page of the transfers out of or into the account,
+// of every account when nil, sorted by creation time
+func (c *Client) ListTransfers(ctx context.Context, accountId *uuid.UUID, options PageOptions) (Page[Transfer], error) {
+	var transfers Page[Transfer]
+	err := c.do(ctx, http.MethodGet, "/v1/transfers", transferQuery(accountId, options), nil, &transfers)
+	return transfers, err
+}
+
+func transferQuery(accountId *uuid.UUID, options PageOptions) url.Values {
+	query := pageQuery(options)
+	if accountId != nil {
+		query.Set("account_id", accountId.String())
+	}
+	return query
+}
+
+// Transfers iterates over the transfers of the account from the page options
+// select
+func (c *Client) Transfers(ctx context.Context, accountId *uuid.UUID, options PageOptions) iter.Seq2[Transfer, error] {
+	return paginate(options, func(options PageOptions) (Page[Transfer], error) {
+		return c.ListTransfers(ctx, accountId, options)
+	})
+}
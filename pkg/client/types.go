@@ -0,0 +1,392 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Side string
+
+const (
+	Buy  Side = "buy"
+	Sell Side = "sell"
+)
+
+type OrderStatus string
+
+const (
+	Open            OrderStatus = "open"
+	PartiallyFilled OrderStatus = "partially_filled"
+	Filled          OrderStatus = "full_filled"
+	Canceled        OrderStatus = "canceled"
+)
+
+// CancelReason tells why an order was canceled
+type CancelReason string
+
+const (
+	CancelRequested        CancelReason = "requested"
+	CancelReplaced         CancelReason = "replaced"
+	CancelInstrumentClosed CancelReason = "instrument_closed"
+	CancelHalted           CancelReason = "halted"
+)
+
+// OrderEventType is a step of the lifecycle of an order
+type OrderEventType string
+
+const (
+	OrderEventAccepted        OrderEventType = "accepted"
+	OrderEventPartiallyFilled OrderEventType = "partially_filled"
+	OrderEventFilled          OrderEventType = "filled"
+	OrderEventAmended         OrderEventType = "amended"
+	OrderEventCanceled        OrderEventType = "canceled"
+)
+
+type TradingStatus string
+
+const (
+	TradingPreOpen    TradingStatus = "pre_open"
+	TradingOpen       TradingStatus = "open"
+	TradingAuction    TradingStatus = "auction"
+	TradingHalted     TradingStatus = "halted"
+	TradingCancelOnly TradingStatus = "cancel_only"
+	TradingClosed     TradingStatus = "closed"
+)
+
+// Page is a page of a paginated list, NextCursor fetches the page that
+// follows and is empty on the last one
+type Page[T any] struct {
+	Size       int    `json:"size"`
+	NextCursor string `json:"next_cursor"`
+	Items      []T    `json:"items"`
+}
+
+// PageOptions selects a page of a list. Size 0 is the default page size of
+// the server. Sort is a sort field of the list, prefixed with "-" to sort
+// descending, the list default when empty. Cursor is the NextCursor of the
+// previous page, empty for the first one, and only valid with its sort.
+type PageOptions struct {
+	Size   int
+	Sort   string
+	Cursor string
+}
+
+type Account struct {
+	Id       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Balances []Balance `json:"balances"`
+}
+
+type Balance struct {
+	AssetId   uuid.UUID       `json:"asset_id"`
+	AssetCode string          `json:"asset_code"`
+	Balance   decimal.Decimal `json:"balance"`
+}
+
+// BalanceUpdate is the balance of an asset after a deposit or withdrawal
+type BalanceUpdate struct {
+	AssetCode string          `json:"asset_code"`
+	Balance   decimal.Decimal `json:"balance"`
+}
+
+type FundingKind string
+
+const (
+	DepositFunding    FundingKind = "deposit"
+	WithdrawalFunding FundingKind = "withdrawal"
+)
+
+// FundingStatus is a step of a deposit or withdrawal: requested, screened to
+// pending approval or approved, processing on the custody rail and finally
+// completed, rejected by an operator or failed on the rail
+type FundingStatus string
+
+const (
+	FundingRequested       FundingStatus = "requested"
+	FundingPendingApproval FundingStatus = "pending_approval"
+	FundingApproved        FundingStatus = "approved"
+	FundingProcessing      FundingStatus = "processing"
+	FundingCompleted       FundingStatus = "completed"
+	FundingRejected        FundingStatus = "rejected"
+	FundingFailed          FundingStatus = "failed"
+)
+
+// Funding is a deposit or withdrawal. Reference is its id on the custody
+// rail, Reason why it was rejected or failed and ReviewedBy the operator who
+// approved or rejected it, each nil until set.
+type Funding struct {
+	Id         uuid.UUID       `json:"id"`
+	AccountId  uuid.UUID       `json:"account_id"`
+	AssetCode  string          `json:"asset_code"`
+	Kind       FundingKind     `json:"kind"`
+	Status     FundingStatus   `json:"status"`
+	Amount     decimal.Decimal `json:"amount"`
+	Reference  *string         `json:"reference"`
+	Reason     *string         `json:"reason"`
+	ReviewedBy *string         `json:"reviewed_by"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// FundingFilter selects fundings, zero fields match every funding
+type FundingFilter struct {
+	AccountId *uuid.UUID
+	Kind      FundingKind
+	Statuses  []FundingStatus
+}
+
+// Transfer is an asset moved from an account to another, Memo is nil when
+// none was given
+type Transfer struct {
+	Id            uuid.UUID       `json:"id"`
+	FromAccountId uuid.UUID       `json:"from_account_id"`
+	ToAccountId   uuid.UUID       `json:"to_account_id"`
+	AssetCode     string          `json:"asset_code"`
+	Amount        decimal.Decimal `json:"amount"`
+	Memo          *string         `json:"memo"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type Order struct {
+	Id             uuid.UUID       `json:"id"`
+	AccountId      uuid.UUID       `json:"account_id"`
+	InstrumentId   uuid.UUID       `json:"instrument_id"`
+	Side           Side            `json:"type"`
+	Status         OrderStatus     `json:"status"`
+	Price          decimal.Decimal `json:"price"`
+	TotalQuantity  decimal.Decimal `json:"total_quantity"`
+	FilledQuantity decimal.Decimal `json:"filled_quantity"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// OrderDetail is an order with its fills, the funds it holds while it works
+// and why it was canceled. AverageFillPrice is nil until the order fills and
+// CancelReason unless it is canceled.
+type OrderDetail struct {
+	Order
+	RemainingQuantity decimal.Decimal  `json:"remaining_quantity"`
+	AverageFillPrice  *decimal.Decimal `json:"average_fill_price"`
+	ReservedAmount    decimal.Decimal  `json:"reserved_amount"`
+	ReservedAssetCode string           `json:"reserved_asset_code"`
+	CancelReason      *CancelReason    `json:"cancel_reason"`
+	Fills             []Fill           `json:"fills"`
+}
+
+// Fill is a match of an order against a counter order
+type Fill struct {
+	CounterOrderId uuid.UUID       `json:"counter_order_id"`
+	Price          decimal.Decimal `json:"price"`
+	Quantity       decimal.Decimal `json:"quantity"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// OrderEvent is a step of the lifecycle of an order, its fields are set
+// depending on its type: the price and quantity of acceptances and fills,
+// the filled quantity and counter order of fills, the reason of cancels, the
+// order an acceptance replaces and the order replacing an amended one
+type OrderEvent struct {
+	Sequence       int64            `json:"sequence"`
+	Type           OrderEventType   `json:"type"`
+	CreatedAt      time.Time        `json:"created_at"`
+	Price          *decimal.Decimal `json:"price"`
+	Quantity       *decimal.Decimal `json:"quantity"`
+	FilledQuantity *decimal.Decimal `json:"filled_quantity"`
+	CounterOrderId *uuid.UUID       `json:"counter_order_id"`
+	Reason         *CancelReason    `json:"reason"`
+	Replaces       *uuid.UUID       `json:"replaces"`
+	ReplacedBy     *uuid.UUID       `json:"replaced_by"`
+}
+
+// PlaceOrderRequest is a limit order on the instrument of the base asset
+// AssetCode
+type PlaceOrderRequest struct {
+	AccountId uuid.UUID       `json:"account_id"`
+	AssetCode string          `json:"asset_code"`
+	Side      Side            `json:"order_type"`
+	Price     decimal.Decimal `json:"price"`
+	Quantity  decimal.Decimal `json:"quantity"`
+}
+
+// OrderFilter narrows the orders listed, nil and empty fields do not filter.
+// Price bounds are inclusive, CreatedTo is exclusive.
+type OrderFilter struct {
+	AccountId    *uuid.UUID
+	InstrumentId *uuid.UUID
+	Statuses     []OrderStatus
+	Side         Side
+	MinPrice     *decimal.Decimal
+	MaxPrice     *decimal.Decimal
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+}
+
+type Instrument struct {
+	Id             uuid.UUID      `json:"id"`
+	Symbol         string         `json:"symbol"`
+	BaseAssetId    uuid.UUID      `json:"base_asset_id"`
+	BaseAssetCode  string         `json:"base_asset_code"`
+	QuoteAssetId   uuid.UUID      `json:"quote_asset_id"`
+	QuoteAssetCode string         `json:"quote_asset_code"`
+	TradingStatus  TradingStatus  `json:"trading_status"`
+	CircuitBreaker CircuitBreaker `json:"circuit_breaker"`
+}
+
+// CircuitBreaker is trading or halted until HaltedUntil
+type CircuitBreaker struct {
+	State       string     `json:"state"`
+	HaltedUntil *time.Time `json:"halted_until"`
+	Reason      string     `json:"reason,omitempty"`
+}
+
+// Ticker has nil prices when there was no trade or the side is empty
+type Ticker struct {
+	InstrumentId   uuid.UUID        `json:"instrument_id"`
+	Symbol         string           `json:"symbol"`
+	LastPrice      *decimal.Decimal `json:"last_price"`
+	BestBid        *decimal.Decimal `json:"best_bid"`
+	BestAsk        *decimal.Decimal `json:"best_ask"`
+	Volume24h      decimal.Decimal  `json:"volume_24h"`
+	TradingStatus  TradingStatus    `json:"trading_status"`
+	CircuitBreaker CircuitBreaker   `json:"circuit_breaker"`
+}
+
+// Auction is the uncrossing of the book at Price, nil when nothing matches
+type Auction struct {
+	InstrumentId  uuid.UUID        `json:"instrument_id"`
+	TradingStatus TradingStatus    `json:"trading_status"`
+	Price         *decimal.Decimal `json:"price"`
+	Volume        decimal.Decimal  `json:"volume"`
+	BuyVolume     decimal.Decimal  `json:"buy_volume"`
+	SellVolume    decimal.Decimal  `json:"sell_volume"`
+	Imbalance     decimal.Decimal  `json:"imbalance"`
+	Fills         int              `json:"fills"`
+}
+
+type TradingStatusUpdate struct {
+	Status              TradingStatus `json:"status"`
+	CancelRestingOrders bool          `json:"cancel_resting_orders"`
+}
+
+// TradingStatusChange is the instrument after the change, with the auction
+// run when it left an auction
+type TradingStatusChange struct {
+	Instrument
+	CanceledOrders int      `json:"canceled_orders"`
+	Auction        *Auction `json:"auction"`
+}
+
+type Book struct {
+	InstrumentId uuid.UUID    `json:"instrument_id"`
+	Bids         []PriceLevel `json:"bids"`
+	Asks         []PriceLevel `json:"asks"`
+	// Sequence is the last event of the log when the book was read
+	Sequence int64 `json:"sequence"`
+}
+
+type PriceLevel struct {
+	Price    decimal.Decimal `json:"price"`
+	Quantity decimal.Decimal `json:"quantity"`
+	Orders   int             `json:"orders"`
+}
+
+type Trade struct {
+	Sequence     int64           `json:"sequence"`
+	InstrumentId uuid.UUID       `json:"instrument_id"`
+	BuyOrderId   uuid.UUID       `json:"buy_order_id"`
+	SellOrderId  uuid.UUID       `json:"sell_order_id"`
+	Price        decimal.Decimal `json:"price"`
+	Quantity     decimal.Decimal `json:"quantity"`
+	ExecutedAt   time.Time       `json:"executed_at"`
+}
+
+type AuditEntry struct {
+	Id         int64           `json:"id"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityId   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	RequestId  string          `json:"request_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditFilter narrows the audit entries listed, empty fields do not filter
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityId   string
+}
+
+type Event struct {
+	Sequence    int64           `json:"sequence"`
+	Type        string          `json:"type"`
+	AggregateId uuid.UUID       `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+type EventList struct {
+	After int64   `json:"after"`
+	Limit int     `json:"limit"`
+	Items []Event `json:"items"`
+}
+
+// State is the working book and the balances, per account and asset,
+// rebuilt from the event log
+type State struct {
+	Sequence int64                                       `json:"sequence"`
+	Orders   map[uuid.UUID]OrderState                    `json:"orders"`
+	Balances map[uuid.UUID]map[uuid.UUID]decimal.Decimal `json:"balances"`
+}
+
+type OrderState struct {
+	OrderId        uuid.UUID       `json:"order_id"`
+	AccountId      uuid.UUID       `json:"account_id"`
+	InstrumentId   uuid.UUID       `json:"instrument_id"`
+	Side           Side            `json:"side"`
+	Price          decimal.Decimal `json:"price"`
+	Quantity       decimal.Decimal `json:"quantity"`
+	FilledQuantity decimal.Decimal `json:"filled_quantity"`
+	Status         OrderStatus     `json:"status"`
+}
+
+type Reconciliation struct {
+	RanAt         time.Time     `json:"ran_at"`
+	Balanced      bool          `json:"balanced"`
+	Assets        []AssetReport `json:"assets"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+type AssetReport struct {
+	AssetId     uuid.UUID       `json:"asset_id"`
+	AssetCode   string          `json:"asset_code"`
+	Balances    decimal.Decimal `json:"balances"`
+	Reserved    decimal.Decimal `json:"reserved"`
+	Held        decimal.Decimal `json:"held"`
+	NetDeposits decimal.Decimal `json:"net_deposits"`
+	Difference  decimal.Decimal `json:"difference"`
+}
+
+type Discrepancy struct {
+	AccountId  uuid.UUID       `json:"account_id"`
+	AssetId    uuid.UUID       `json:"asset_id"`
+	AssetCode  string          `json:"asset_code"`
+	Expected   decimal.Decimal `json:"expected"`
+	Actual     decimal.Decimal `json:"actual"`
+	Difference decimal.Decimal `json:"difference"`
+}
+
+type Health struct {
+	Status string `json:"status"`
+}
+
+// Readiness has the error of each failing check, "ok" for the others
+type Readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
@@ -0,0 +1,2214 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: clob/v1/clob.proto
+
+package clobv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Side int32
+
+const (
+	Side_SIDE_UNSPECIFIED Side = 0
+	Side_SIDE_BUY         Side = 1
+	Side_SIDE_SELL        Side = 2
+)
+
+// Enum value maps for Side.
+var (
+	Side_name = map[int32]string{
+		0: "SIDE_UNSPECIFIED",
+		1: "SIDE_BUY",
+		2: "SIDE_SELL",
+	}
+	Side_value = map[string]int32{
+		"SIDE_UNSPECIFIED": 0,
+		"SIDE_BUY":         1,
+		"SIDE_SELL":        2,
+	}
+)
+
+func (x Side) Enum() *Side {
+	p := new(Side)
+	*p = x
+	return p
+}
+
+func (x Side) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Side) Descriptor() protoreflect.EnumDescriptor {
+	return file_clob_v1_clob_proto_enumTypes[0].Descriptor()
+}
+
+func (Side) Type() protoreflect.EnumType {
+	return &file_clob_v1_clob_proto_enumTypes[0]
+}
+
+func (x Side) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Side.Descriptor instead.
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED      OrderStatus = 0
+	OrderStatus_ORDER_STATUS_OPEN             OrderStatus = 1
+	OrderStatus_ORDER_STATUS_PARTIALLY_FILLED OrderStatus = 2
+	OrderStatus_ORDER_STATUS_FILLED           OrderStatus = 3
+	OrderStatus_ORDER_STATUS_CANCELED         OrderStatus = 4
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_OPEN",
+		2: "ORDER_STATUS_PARTIALLY_FILLED",
+		3: "ORDER_STATUS_FILLED",
+		4: "ORDER_STATUS_CANCELED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED":      0,
+		"ORDER_STATUS_OPEN":             1,
+		"ORDER_STATUS_PARTIALLY_FILLED": 2,
+		"ORDER_STATUS_FILLED":           3,
+		"ORDER_STATUS_CANCELED":         4,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_clob_v1_clob_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_clob_v1_clob_proto_enumTypes[1]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{1}
+}
+
+type AccountBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	AssetCode     string                 `protobuf:"bytes,2,opt,name=asset_code,json=assetCode,proto3" json:"asset_code,omitempty"`
+	Balance       string                 `protobuf:"bytes,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountBalance) Reset() {
+	*x = AccountBalance{}
+	mi := &file_clob_v1_clob_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountBalance) ProtoMessage() {}
+
+func (x *AccountBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountBalance.ProtoReflect.Descriptor instead.
+func (*AccountBalance) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AccountBalance) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *AccountBalance) GetAssetCode() string {
+	if x != nil {
+		return x.AssetCode
+	}
+	return ""
+}
+
+func (x *AccountBalance) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+type Account struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Balances      []*AccountBalance      `protobuf:"bytes,3,rep,name=balances,proto3" json:"balances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_clob_v1_clob_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Account) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Account) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Account) GetBalances() []*AccountBalance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+type CreateAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountRequest) ProtoMessage() {}
+
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateAccountRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAccountRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Lists are paginated with cursors: the next_cursor of a page fetches the
+// page that follows, it is empty on the last one. A size of 0 is the default
+// page size.
+type ListAccountsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Size   int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Cursor string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// id (default) or name, prefixed with - to sort descending
+	Sort          string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsRequest) Reset() {
+	*x = ListAccountsRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsRequest) ProtoMessage() {}
+
+func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAccountsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListAccountsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListAccountsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Accounts      []*Account             `protobuf:"bytes,4,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
+	mi := &file_clob_v1_clob_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsResponse) ProtoMessage() {}
+
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAccountsResponse) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListAccountsResponse) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *ListAccountsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type UpdateBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AssetCode     string                 `protobuf:"bytes,2,opt,name=asset_code,json=assetCode,proto3" json:"asset_code,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBalanceRequest) Reset() {
+	*x = UpdateBalanceRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBalanceRequest) ProtoMessage() {}
+
+func (x *UpdateBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBalanceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateBalanceRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *UpdateBalanceRequest) GetAssetCode() string {
+	if x != nil {
+		return x.AssetCode
+	}
+	return ""
+}
+
+func (x *UpdateBalanceRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+type Balance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetCode     string                 `protobuf:"bytes,1,opt,name=asset_code,json=assetCode,proto3" json:"asset_code,omitempty"`
+	Balance       string                 `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	mi := &file_clob_v1_clob_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Balance) GetAssetCode() string {
+	if x != nil {
+		return x.AssetCode
+	}
+	return ""
+}
+
+func (x *Balance) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+type Order struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId      string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	InstrumentId   string                 `protobuf:"bytes,3,opt,name=instrument_id,json=instrumentId,proto3" json:"instrument_id,omitempty"`
+	Side           Side                   `protobuf:"varint,4,opt,name=side,proto3,enum=clob.v1.Side" json:"side,omitempty"`
+	Status         OrderStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=clob.v1.OrderStatus" json:"status,omitempty"`
+	Price          string                 `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	TotalQuantity  string                 `protobuf:"bytes,7,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`
+	FilledQuantity string                 `protobuf:"bytes,8,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_clob_v1_clob_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Order) GetInstrumentId() string {
+	if x != nil {
+		return x.InstrumentId
+	}
+	return ""
+}
+
+func (x *Order) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *Order) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Order) GetTotalQuantity() string {
+	if x != nil {
+		return x.TotalQuantity
+	}
+	return ""
+}
+
+func (x *Order) GetFilledQuantity() string {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return ""
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type PlaceOrderRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Base asset code of the instrument, e.g. BTC
+	AssetCode     string `protobuf:"bytes,2,opt,name=asset_code,json=assetCode,proto3" json:"asset_code,omitempty"`
+	Side          Side   `protobuf:"varint,3,opt,name=side,proto3,enum=clob.v1.Side" json:"side,omitempty"`
+	Price         string `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string `protobuf:"bytes,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderRequest) Reset() {
+	*x = PlaceOrderRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderRequest) ProtoMessage() {}
+
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PlaceOrderRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetAssetCode() string {
+	if x != nil {
+		return x.AssetCode
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *PlaceOrderRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+type Fill struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The resting order matched
+	OrderId       string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Price         string `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fill) Reset() {
+	*x = Fill{}
+	mi := &file_clob_v1_clob_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fill) ProtoMessage() {}
+
+func (x *Fill) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fill.ProtoReflect.Descriptor instead.
+func (*Fill) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Fill) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Fill) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Fill) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+type PlaceOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The order as left by matching
+	Order         *Order  `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Fills         []*Fill `protobuf:"bytes,2,rep,name=fills,proto3" json:"fills,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderResponse) Reset() {
+	*x = PlaceOrderResponse{}
+	mi := &file_clob_v1_clob_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderResponse) ProtoMessage() {}
+
+func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PlaceOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *PlaceOrderResponse) GetFills() []*Fill {
+	if x != nil {
+		return x.Fills
+	}
+	return nil
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Paginated like ListAccountsRequest, empty filters match every order
+type ListOrdersRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Size         int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	AccountId    string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	InstrumentId string                 `protobuf:"bytes,4,opt,name=instrument_id,json=instrumentId,proto3" json:"instrument_id,omitempty"`
+	Cursor       string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// created_at (default) or price, prefixed with - to sort descending
+	Sort     string        `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	Statuses []OrderStatus `protobuf:"varint,7,rep,packed,name=statuses,proto3,enum=clob.v1.OrderStatus" json:"statuses,omitempty"`
+	Side     Side          `protobuf:"varint,8,opt,name=side,proto3,enum=clob.v1.Side" json:"side,omitempty"`
+	// Inclusive price bounds
+	MinPrice string `protobuf:"bytes,9,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice string `protobuf:"bytes,10,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	// Creation time range, created_to is exclusive
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListOrdersRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetInstrumentId() string {
+	if x != nil {
+		return x.InstrumentId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *ListOrdersRequest) GetMinPrice() string {
+	if x != nil {
+		return x.MinPrice
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetMaxPrice() string {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Orders        []*Order               `protobuf:"bytes,4,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_clob_v1_clob_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListOrdersResponse) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type CircuitBreaker struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// trading or halted
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	HaltedUntil   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=halted_until,json=haltedUntil,proto3" json:"halted_until,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CircuitBreaker) Reset() {
+	*x = CircuitBreaker{}
+	mi := &file_clob_v1_clob_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CircuitBreaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CircuitBreaker) ProtoMessage() {}
+
+func (x *CircuitBreaker) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CircuitBreaker.ProtoReflect.Descriptor instead.
+func (*CircuitBreaker) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CircuitBreaker) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CircuitBreaker) GetHaltedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HaltedUntil
+	}
+	return nil
+}
+
+func (x *CircuitBreaker) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type Instrument struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol         string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	BaseAssetId    string                 `protobuf:"bytes,3,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"`
+	BaseAssetCode  string                 `protobuf:"bytes,4,opt,name=base_asset_code,json=baseAssetCode,proto3" json:"base_asset_code,omitempty"`
+	QuoteAssetId   string                 `protobuf:"bytes,5,opt,name=quote_asset_id,json=quoteAssetId,proto3" json:"quote_asset_id,omitempty"`
+	QuoteAssetCode string                 `protobuf:"bytes,6,opt,name=quote_asset_code,json=quoteAssetCode,proto3" json:"quote_asset_code,omitempty"`
+	// pre_open, open, auction, halted, cancel_only or closed
+	TradingStatus  string          `protobuf:"bytes,7,opt,name=trading_status,json=tradingStatus,proto3" json:"trading_status,omitempty"`
+	CircuitBreaker *CircuitBreaker `protobuf:"bytes,8,opt,name=circuit_breaker,json=circuitBreaker,proto3" json:"circuit_breaker,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Instrument) Reset() {
+	*x = Instrument{}
+	mi := &file_clob_v1_clob_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Instrument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instrument) ProtoMessage() {}
+
+func (x *Instrument) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instrument.ProtoReflect.Descriptor instead.
+func (*Instrument) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Instrument) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Instrument) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Instrument) GetBaseAssetId() string {
+	if x != nil {
+		return x.BaseAssetId
+	}
+	return ""
+}
+
+func (x *Instrument) GetBaseAssetCode() string {
+	if x != nil {
+		return x.BaseAssetCode
+	}
+	return ""
+}
+
+func (x *Instrument) GetQuoteAssetId() string {
+	if x != nil {
+		return x.QuoteAssetId
+	}
+	return ""
+}
+
+func (x *Instrument) GetQuoteAssetCode() string {
+	if x != nil {
+		return x.QuoteAssetCode
+	}
+	return ""
+}
+
+func (x *Instrument) GetTradingStatus() string {
+	if x != nil {
+		return x.TradingStatus
+	}
+	return ""
+}
+
+func (x *Instrument) GetCircuitBreaker() *CircuitBreaker {
+	if x != nil {
+		return x.CircuitBreaker
+	}
+	return nil
+}
+
+type ListInstrumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstrumentsRequest) Reset() {
+	*x = ListInstrumentsRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstrumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstrumentsRequest) ProtoMessage() {}
+
+func (x *ListInstrumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstrumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListInstrumentsRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{18}
+}
+
+type ListInstrumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instruments   []*Instrument          `protobuf:"bytes,1,rep,name=instruments,proto3" json:"instruments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstrumentsResponse) Reset() {
+	*x = ListInstrumentsResponse{}
+	mi := &file_clob_v1_clob_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstrumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstrumentsResponse) ProtoMessage() {}
+
+func (x *ListInstrumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstrumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListInstrumentsResponse) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListInstrumentsResponse) GetInstruments() []*Instrument {
+	if x != nil {
+		return x.Instruments
+	}
+	return nil
+}
+
+type GetTickerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstrumentId  string                 `protobuf:"bytes,1,opt,name=instrument_id,json=instrumentId,proto3" json:"instrument_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTickerRequest) Reset() {
+	*x = GetTickerRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTickerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTickerRequest) ProtoMessage() {}
+
+func (x *GetTickerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTickerRequest.ProtoReflect.Descriptor instead.
+func (*GetTickerRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetTickerRequest) GetInstrumentId() string {
+	if x != nil {
+		return x.InstrumentId
+	}
+	return ""
+}
+
+// Prices are empty when unknown
+type Ticker struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	InstrumentId   string                 `protobuf:"bytes,1,opt,name=instrument_id,json=instrumentId,proto3" json:"instrument_id,omitempty"`
+	Symbol         string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	LastPrice      string                 `protobuf:"bytes,3,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
+	BestBid        string                 `protobuf:"bytes,4,opt,name=best_bid,json=bestBid,proto3" json:"best_bid,omitempty"`
+	BestAsk        string                 `protobuf:"bytes,5,opt,name=best_ask,json=bestAsk,proto3" json:"best_ask,omitempty"`
+	Volume_24H     string                 `protobuf:"bytes,6,opt,name=volume_24h,json=volume24h,proto3" json:"volume_24h,omitempty"`
+	TradingStatus  string                 `protobuf:"bytes,7,opt,name=trading_status,json=tradingStatus,proto3" json:"trading_status,omitempty"`
+	CircuitBreaker *CircuitBreaker        `protobuf:"bytes,8,opt,name=circuit_breaker,json=circuitBreaker,proto3" json:"circuit_breaker,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Ticker) Reset() {
+	*x = Ticker{}
+	mi := &file_clob_v1_clob_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ticker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ticker) ProtoMessage() {}
+
+func (x *Ticker) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ticker.ProtoReflect.Descriptor instead.
+func (*Ticker) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Ticker) GetInstrumentId() string {
+	if x != nil {
+		return x.InstrumentId
+	}
+	return ""
+}
+
+func (x *Ticker) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Ticker) GetLastPrice() string {
+	if x != nil {
+		return x.LastPrice
+	}
+	return ""
+}
+
+func (x *Ticker) GetBestBid() string {
+	if x != nil {
+		return x.BestBid
+	}
+	return ""
+}
+
+func (x *Ticker) GetBestAsk() string {
+	if x != nil {
+		return x.BestAsk
+	}
+	return ""
+}
+
+func (x *Ticker) GetVolume_24H() string {
+	if x != nil {
+		return x.Volume_24H
+	}
+	return ""
+}
+
+func (x *Ticker) GetTradingStatus() string {
+	if x != nil {
+		return x.TradingStatus
+	}
+	return ""
+}
+
+func (x *Ticker) GetCircuitBreaker() *CircuitBreaker {
+	if x != nil {
+		return x.CircuitBreaker
+	}
+	return nil
+}
+
+type PriceLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         string                 `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Orders        int32                  `protobuf:"varint,3,opt,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceLevel) Reset() {
+	*x = PriceLevel{}
+	mi := &file_clob_v1_clob_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceLevel) ProtoMessage() {}
+
+func (x *PriceLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceLevel.ProtoReflect.Descriptor instead.
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PriceLevel) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *PriceLevel) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *PriceLevel) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+type Book struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	InstrumentId string                 `protobuf:"bytes,1,opt,name=instrument_id,json=instrumentId,proto3" json:"instrument_id,omitempty"`
+	// Best first: highest bids and lowest asks
+	Bids []*PriceLevel `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks []*PriceLevel `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"`
+	// Sequence of the last event of the log when the book was read
+	Sequence      int64 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_clob_v1_clob_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Book) GetInstrumentId() string {
+	if x != nil {
+		return x.InstrumentId
+	}
+	return ""
+}
+
+func (x *Book) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *Book) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *Book) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// A depth of 0 returns every level
+type GetBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstrumentId  string                 `protobuf:"bytes,1,opt,name=instrument_id,json=instrumentId,proto3" json:"instrument_id,omitempty"`
+	Depth         int32                  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetBookRequest) GetInstrumentId() string {
+	if x != nil {
+		return x.InstrumentId
+	}
+	return ""
+}
+
+func (x *GetBookRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type StreamBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstrumentId  string                 `protobuf:"bytes,1,opt,name=instrument_id,json=instrumentId,proto3" json:"instrument_id,omitempty"`
+	Depth         int32                  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamBookRequest) Reset() {
+	*x = StreamBookRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBookRequest) ProtoMessage() {}
+
+func (x *StreamBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBookRequest.ProtoReflect.Descriptor instead.
+func (*StreamBookRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *StreamBookRequest) GetInstrumentId() string {
+	if x != nil {
+		return x.InstrumentId
+	}
+	return ""
+}
+
+func (x *StreamBookRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type StreamTradesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstrumentId  string                 `protobuf:"bytes,1,opt,name=instrument_id,json=instrumentId,proto3" json:"instrument_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTradesRequest) Reset() {
+	*x = StreamTradesRequest{}
+	mi := &file_clob_v1_clob_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTradesRequest) ProtoMessage() {}
+
+func (x *StreamTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTradesRequest.ProtoReflect.Descriptor instead.
+func (*StreamTradesRequest) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StreamTradesRequest) GetInstrumentId() string {
+	if x != nil {
+		return x.InstrumentId
+	}
+	return ""
+}
+
+type Trade struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sequence of the event reporting the trade
+	Sequence      int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	InstrumentId  string                 `protobuf:"bytes,2,opt,name=instrument_id,json=instrumentId,proto3" json:"instrument_id,omitempty"`
+	BuyOrderId    string                 `protobuf:"bytes,3,opt,name=buy_order_id,json=buyOrderId,proto3" json:"buy_order_id,omitempty"`
+	SellOrderId   string                 `protobuf:"bytes,4,opt,name=sell_order_id,json=sellOrderId,proto3" json:"sell_order_id,omitempty"`
+	Price         string                 `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ExecutedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_clob_v1_clob_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_clob_v1_clob_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_clob_v1_clob_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Trade) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Trade) GetInstrumentId() string {
+	if x != nil {
+		return x.InstrumentId
+	}
+	return ""
+}
+
+func (x *Trade) GetBuyOrderId() string {
+	if x != nil {
+		return x.BuyOrderId
+	}
+	return ""
+}
+
+func (x *Trade) GetSellOrderId() string {
+	if x != nil {
+		return x.SellOrderId
+	}
+	return ""
+}
+
+func (x *Trade) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Trade) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *Trade) GetExecutedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return nil
+}
+
+var File_clob_v1_clob_proto protoreflect.FileDescriptor
+
+const file_clob_v1_clob_proto_rawDesc = "" +
+	"\n" +
+	"\x12clob/v1/clob.proto\x12\aclob.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"d\n" +
+	"\x0eAccountBalance\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x1d\n" +
+	"\n" +
+	"asset_code\x18\x02 \x01(\tR\tassetCode\x12\x18\n" +
+	"\abalance\x18\x03 \x01(\tR\abalance\"b\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x123\n" +
+	"\bbalances\x18\x03 \x03(\v2\x17.clob.v1.AccountBalanceR\bbalances\"*\n" +
+	"\x14CreateAccountRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"#\n" +
+	"\x11GetAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"[\n" +
+	"\x13ListAccountsRequest\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sortJ\x04\b\x01\x10\x02\"\x85\x01\n" +
+	"\x14ListAccountsResponse\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12,\n" +
+	"\baccounts\x18\x04 \x03(\v2\x10.clob.v1.AccountR\baccounts\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursorJ\x04\b\x01\x10\x02J\x04\b\x03\x10\x04\"l\n" +
+	"\x14UpdateBalanceRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1d\n" +
+	"\n" +
+	"asset_code\x18\x02 \x01(\tR\tassetCode\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\"B\n" +
+	"\aBalance\x12\x1d\n" +
+	"\n" +
+	"asset_code\x18\x01 \x01(\tR\tassetCode\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\"\xcd\x02\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12#\n" +
+	"\rinstrument_id\x18\x03 \x01(\tR\finstrumentId\x12!\n" +
+	"\x04side\x18\x04 \x01(\x0e2\r.clob.v1.SideR\x04side\x12,\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x14.clob.v1.OrderStatusR\x06status\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\tR\x05price\x12%\n" +
+	"\x0etotal_quantity\x18\a \x01(\tR\rtotalQuantity\x12'\n" +
+	"\x0ffilled_quantity\x18\b \x01(\tR\x0efilledQuantity\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa6\x01\n" +
+	"\x11PlaceOrderRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1d\n" +
+	"\n" +
+	"asset_code\x18\x02 \x01(\tR\tassetCode\x12!\n" +
+	"\x04side\x18\x03 \x01(\x0e2\r.clob.v1.SideR\x04side\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\tR\bquantity\"S\n" +
+	"\x04Fill\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\tR\bquantity\"_\n" +
+	"\x12PlaceOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.clob.v1.OrderR\x05order\x12#\n" +
+	"\x05fills\x18\x02 \x03(\v2\r.clob.v1.FillR\x05fills\"$\n" +
+	"\x12CancelOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"!\n" +
+	"\x0fGetOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa6\x03\n" +
+	"\x11ListOrdersRequest\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12#\n" +
+	"\rinstrument_id\x18\x04 \x01(\tR\finstrumentId\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\x120\n" +
+	"\bstatuses\x18\a \x03(\x0e2\x14.clob.v1.OrderStatusR\bstatuses\x12!\n" +
+	"\x04side\x18\b \x01(\x0e2\r.clob.v1.SideR\x04side\x12\x1b\n" +
+	"\tmin_price\x18\t \x01(\tR\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\n" +
+	" \x01(\tR\bmaxPrice\x12=\n" +
+	"\fcreated_from\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedToJ\x04\b\x01\x10\x02\"}\n" +
+	"\x12ListOrdersResponse\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12&\n" +
+	"\x06orders\x18\x04 \x03(\v2\x0e.clob.v1.OrderR\x06orders\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursorJ\x04\b\x01\x10\x02J\x04\b\x03\x10\x04\"}\n" +
+	"\x0eCircuitBreaker\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12=\n" +
+	"\fhalted_until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vhaltedUntil\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xb9\x02\n" +
+	"\n" +
+	"Instrument\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\"\n" +
+	"\rbase_asset_id\x18\x03 \x01(\tR\vbaseAssetId\x12&\n" +
+	"\x0fbase_asset_code\x18\x04 \x01(\tR\rbaseAssetCode\x12$\n" +
+	"\x0equote_asset_id\x18\x05 \x01(\tR\fquoteAssetId\x12(\n" +
+	"\x10quote_asset_code\x18\x06 \x01(\tR\x0equoteAssetCode\x12%\n" +
+	"\x0etrading_status\x18\a \x01(\tR\rtradingStatus\x12@\n" +
+	"\x0fcircuit_breaker\x18\b \x01(\v2\x17.clob.v1.CircuitBreakerR\x0ecircuitBreaker\"\x18\n" +
+	"\x16ListInstrumentsRequest\"P\n" +
+	"\x17ListInstrumentsResponse\x125\n" +
+	"\vinstruments\x18\x01 \x03(\v2\x13.clob.v1.InstrumentR\vinstruments\"7\n" +
+	"\x10GetTickerRequest\x12#\n" +
+	"\rinstrument_id\x18\x01 \x01(\tR\finstrumentId\"\xa2\x02\n" +
+	"\x06Ticker\x12#\n" +
+	"\rinstrument_id\x18\x01 \x01(\tR\finstrumentId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1d\n" +
+	"\n" +
+	"last_price\x18\x03 \x01(\tR\tlastPrice\x12\x19\n" +
+	"\bbest_bid\x18\x04 \x01(\tR\abestBid\x12\x19\n" +
+	"\bbest_ask\x18\x05 \x01(\tR\abestAsk\x12\x1d\n" +
+	"\n" +
+	"volume_24h\x18\x06 \x01(\tR\tvolume24h\x12%\n" +
+	"\x0etrading_status\x18\a \x01(\tR\rtradingStatus\x12@\n" +
+	"\x0fcircuit_breaker\x18\b \x01(\v2\x17.clob.v1.CircuitBreakerR\x0ecircuitBreaker\"V\n" +
+	"\n" +
+	"PriceLevel\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\tR\bquantity\x12\x16\n" +
+	"\x06orders\x18\x03 \x01(\x05R\x06orders\"\x99\x01\n" +
+	"\x04Book\x12#\n" +
+	"\rinstrument_id\x18\x01 \x01(\tR\finstrumentId\x12'\n" +
+	"\x04bids\x18\x02 \x03(\v2\x13.clob.v1.PriceLevelR\x04bids\x12'\n" +
+	"\x04asks\x18\x03 \x03(\v2\x13.clob.v1.PriceLevelR\x04asks\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x03R\bsequence\"K\n" +
+	"\x0eGetBookRequest\x12#\n" +
+	"\rinstrument_id\x18\x01 \x01(\tR\finstrumentId\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\"N\n" +
+	"\x11StreamBookRequest\x12#\n" +
+	"\rinstrument_id\x18\x01 \x01(\tR\finstrumentId\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\":\n" +
+	"\x13StreamTradesRequest\x12#\n" +
+	"\rinstrument_id\x18\x01 \x01(\tR\finstrumentId\"\xfd\x01\n" +
+	"\x05Trade\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12#\n" +
+	"\rinstrument_id\x18\x02 \x01(\tR\finstrumentId\x12 \n" +
+	"\fbuy_order_id\x18\x03 \x01(\tR\n" +
+	"buyOrderId\x12\"\n" +
+	"\rsell_order_id\x18\x04 \x01(\tR\vsellOrderId\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\tR\bquantity\x12;\n" +
+	"\vexecuted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"executedAt*9\n" +
+	"\x04Side\x12\x14\n" +
+	"\x10SIDE_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bSIDE_BUY\x10\x01\x12\r\n" +
+	"\tSIDE_SELL\x10\x02*\x99\x01\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11ORDER_STATUS_OPEN\x10\x01\x12!\n" +
+	"\x1dORDER_STATUS_PARTIALLY_FILLED\x10\x02\x12\x17\n" +
+	"\x13ORDER_STATUS_FILLED\x10\x03\x12\x19\n" +
+	"\x15ORDER_STATUS_CANCELED\x10\x042\xd4\x02\n" +
+	"\x0eAccountService\x12@\n" +
+	"\rCreateAccount\x12\x1d.clob.v1.CreateAccountRequest\x1a\x10.clob.v1.Account\x12:\n" +
+	"\n" +
+	"GetAccount\x12\x1a.clob.v1.GetAccountRequest\x1a\x10.clob.v1.Account\x12K\n" +
+	"\fListAccounts\x12\x1c.clob.v1.ListAccountsRequest\x1a\x1d.clob.v1.ListAccountsResponse\x12:\n" +
+	"\aDeposit\x12\x1d.clob.v1.UpdateBalanceRequest\x1a\x10.clob.v1.Balance\x12;\n" +
+	"\bWithdraw\x12\x1d.clob.v1.UpdateBalanceRequest\x1a\x10.clob.v1.Balance2\xdb\x02\n" +
+	"\fOrderService\x12E\n" +
+	"\n" +
+	"PlaceOrder\x12\x1a.clob.v1.PlaceOrderRequest\x1a\x1b.clob.v1.PlaceOrderResponse\x12:\n" +
+	"\vCancelOrder\x12\x1b.clob.v1.CancelOrderRequest\x1a\x0e.clob.v1.Order\x124\n" +
+	"\bGetOrder\x12\x18.clob.v1.GetOrderRequest\x1a\x0e.clob.v1.Order\x12E\n" +
+	"\n" +
+	"ListOrders\x12\x1a.clob.v1.ListOrdersRequest\x1a\x1b.clob.v1.ListOrdersResponse\x12K\n" +
+	"\x10ListOrderHistory\x12\x1a.clob.v1.ListOrdersRequest\x1a\x1b.clob.v1.ListOrdersResponse2\xd0\x02\n" +
+	"\x11MarketDataService\x12T\n" +
+	"\x0fListInstruments\x12\x1f.clob.v1.ListInstrumentsRequest\x1a .clob.v1.ListInstrumentsResponse\x127\n" +
+	"\tGetTicker\x12\x19.clob.v1.GetTickerRequest\x1a\x0f.clob.v1.Ticker\x121\n" +
+	"\aGetBook\x12\x17.clob.v1.GetBookRequest\x1a\r.clob.v1.Book\x12>\n" +
+	"\fStreamTrades\x12\x1c.clob.v1.StreamTradesRequest\x1a\x0e.clob.v1.Trade0\x01\x129\n" +
+	"\n" +
+	"StreamBook\x12\x1a.clob.v1.StreamBookRequest\x1a\r.clob.v1.Book0\x01B2Z0github.com/JhonesBR/go-clob/proto/clob/v1;clobv1b\x06proto3"
+
+var (
+	file_clob_v1_clob_proto_rawDescOnce sync.Once
+	file_clob_v1_clob_proto_rawDescData []byte
+)
+
+func file_clob_v1_clob_proto_rawDescGZIP() []byte {
+	file_clob_v1_clob_proto_rawDescOnce.Do(func() {
+		file_clob_v1_clob_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_clob_v1_clob_proto_rawDesc), len(file_clob_v1_clob_proto_rawDesc)))
+	})
+	return file_clob_v1_clob_proto_rawDescData
+}
+
+var file_clob_v1_clob_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_clob_v1_clob_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_clob_v1_clob_proto_goTypes = []any{
+	(Side)(0),                       // 0: clob.v1.Side
+	(OrderStatus)(0),                // 1: clob.v1.OrderStatus
+	(*AccountBalance)(nil),          // 2: clob.v1.AccountBalance
+	(*Account)(nil),                 // 3: clob.v1.Account
+	(*CreateAccountRequest)(nil),    // 4: clob.v1.CreateAccountRequest
+	(*GetAccountRequest)(nil),       // 5: clob.v1.GetAccountRequest
+	(*ListAccountsRequest)(nil),     // 6: clob.v1.ListAccountsRequest
+	(*ListAccountsResponse)(nil),    // 7: clob.v1.ListAccountsResponse
+	(*UpdateBalanceRequest)(nil),    // 8: clob.v1.UpdateBalanceRequest
+	(*Balance)(nil),                 // 9: clob.v1.Balance
+	(*Order)(nil),                   // 10: clob.v1.Order
+	(*PlaceOrderRequest)(nil),       // 11: clob.v1.PlaceOrderRequest
+	(*Fill)(nil),                    // 12: clob.v1.Fill
+	(*PlaceOrderResponse)(nil),      // 13: clob.v1.PlaceOrderResponse
+	(*CancelOrderRequest)(nil),      // 14: clob.v1.CancelOrderRequest
+	(*GetOrderRequest)(nil),         // 15: clob.v1.GetOrderRequest
+	(*ListOrdersRequest)(nil),       // 16: clob.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),      // 17: clob.v1.ListOrdersResponse
+	(*CircuitBreaker)(nil),          // 18: clob.v1.CircuitBreaker
+	(*Instrument)(nil),              // 19: clob.v1.Instrument
+	(*ListInstrumentsRequest)(nil),  // 20: clob.v1.ListInstrumentsRequest
+	(*ListInstrumentsResponse)(nil), // 21: clob.v1.ListInstrumentsResponse
+	(*GetTickerRequest)(nil),        // 22: clob.v1.GetTickerRequest
+	(*Ticker)(nil),                  // 23: clob.v1.Ticker
+	(*PriceLevel)(nil),              // 24: clob.v1.PriceLevel
+	(*Book)(nil),                    // 25: clob.v1.Book
+	(*GetBookRequest)(nil),          // 26: clob.v1.GetBookRequest
+	(*StreamBookRequest)(nil),       // 27: clob.v1.StreamBookRequest
+	(*StreamTradesRequest)(nil),     // 28: clob.v1.StreamTradesRequest
+	(*Trade)(nil),                   // 29: clob.v1.Trade
+	(*timestamppb.Timestamp)(nil),   // 30: google.protobuf.Timestamp
+}
+var file_clob_v1_clob_proto_depIdxs = []int32{
+	2,  // 0: clob.v1.Account.balances:type_name -> clob.v1.AccountBalance
+	3,  // 1: clob.v1.ListAccountsResponse.accounts:type_name -> clob.v1.Account
+	0,  // 2: clob.v1.Order.side:type_name -> clob.v1.Side
+	1,  // 3: clob.v1.Order.status:type_name -> clob.v1.OrderStatus
+	30, // 4: clob.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: clob.v1.PlaceOrderRequest.side:type_name -> clob.v1.Side
+	10, // 6: clob.v1.PlaceOrderResponse.order:type_name -> clob.v1.Order
+	12, // 7: clob.v1.PlaceOrderResponse.fills:type_name -> clob.v1.Fill
+	1,  // 8: clob.v1.ListOrdersRequest.statuses:type_name -> clob.v1.OrderStatus
+	0,  // 9: clob.v1.ListOrdersRequest.side:type_name -> clob.v1.Side
+	30, // 10: clob.v1.ListOrdersRequest.created_from:type_name -> google.protobuf.Timestamp
+	30, // 11: clob.v1.ListOrdersRequest.created_to:type_name -> google.protobuf.Timestamp
+	10, // 12: clob.v1.ListOrdersResponse.orders:type_name -> clob.v1.Order
+	30, // 13: clob.v1.CircuitBreaker.halted_until:type_name -> google.protobuf.Timestamp
+	18, // 14: clob.v1.Instrument.circuit_breaker:type_name -> clob.v1.CircuitBreaker
+	19, // 15: clob.v1.ListInstrumentsResponse.instruments:type_name -> clob.v1.Instrument
+	18, // 16: clob.v1.Ticker.circuit_breaker:type_name -> clob.v1.CircuitBreaker
+	24, // 17: clob.v1.Book.bids:type_name -> clob.v1.PriceLevel
+	24, // 18: clob.v1.Book.asks:type_name -> clob.v1.PriceLevel
+	30, // 19: clob.v1.Trade.executed_at:type_name -> google.protobuf.Timestamp
+	4,  // 20: clob.v1.AccountService.CreateAccount:input_type -> clob.v1.CreateAccountRequest
+	5,  // 21: clob.v1.AccountService.GetAccount:input_type -> clob.v1.GetAccountRequest
+	6,  // 22: clob.v1.AccountService.ListAccounts:input_type -> clob.v1.ListAccountsRequest
+	8,  // 23: clob.v1.AccountService.Deposit:input_type -> clob.v1.UpdateBalanceRequest
+	8,  // 24: clob.v1.AccountService.Withdraw:input_type -> clob.v1.UpdateBalanceRequest
+	11, // 25: clob.v1.OrderService.PlaceOrder:input_type -> clob.v1.PlaceOrderRequest
+	14, // 26: clob.v1.OrderService.CancelOrder:input_type -> clob.v1.CancelOrderRequest
+	15, // 27: clob.v1.OrderService.GetOrder:input_type -> clob.v1.GetOrderRequest
+	16, // 28: clob.v1.OrderService.ListOrders:input_type -> clob.v1.ListOrdersRequest
+	16, // 29: clob.v1.OrderService.ListOrderHistory:input_type -> clob.v1.ListOrdersRequest
+	20, // 30: clob.v1.MarketDataService.ListInstruments:input_type -> clob.v1.ListInstrumentsRequest
+	22, // 31: clob.v1.MarketDataService.GetTicker:input_type -> clob.v1.GetTickerRequest
+	26, // 32: clob.v1.MarketDataService.GetBook:input_type -> clob.v1.GetBookRequest
+	28, // 33: clob.v1.MarketDataService.StreamTrades:input_type -> clob.v1.StreamTradesRequest
+	27, // 34: clob.v1.MarketDataService.StreamBook:input_type -> clob.v1.StreamBookRequest
+	3,  // 35: clob.v1.AccountService.CreateAccount:output_type -> clob.v1.Account
+	3,  // 36: clob.v1.AccountService.GetAccount:output_type -> clob.v1.Account
+	7,  // 37: clob.v1.AccountService.ListAccounts:output_type -> clob.v1.ListAccountsResponse
+	9,  // 38: clob.v1.AccountService.Deposit:output_type -> clob.v1.Balance
+	9,  // 39: clob.v1.AccountService.Withdraw:output_type -> clob.v1.Balance
+	13, // 40: clob.v1.OrderService.PlaceOrder:output_type -> clob.v1.PlaceOrderResponse
+	10, // 41: clob.v1.OrderService.CancelOrder:output_type -> clob.v1.Order
+	10, // 42: clob.v1.OrderService.GetOrder:output_type -> clob.v1.Order
+	17, // 43: clob.v1.OrderService.ListOrders:output_type -> clob.v1.ListOrdersResponse
+	17, // 44: clob.v1.OrderService.ListOrderHistory:output_type -> clob.v1.ListOrdersResponse
+	21, // 45: clob.v1.MarketDataService.ListInstruments:output_type -> clob.v1.ListInstrumentsResponse
+	23, // 46: clob.v1.MarketDataService.GetTicker:output_type -> clob.v1.Ticker
+	25, // 47: clob.v1.MarketDataService.GetBook:output_type -> clob.v1.Book
+	29, // 48: clob.v1.MarketDataService.StreamTrades:output_type -> clob.v1.Trade
+	25, // 49: clob.v1.MarketDataService.StreamBook:output_type -> clob.v1.Book
+	35, // [35:50] is the sub-list for method output_type
+	20, // [20:35] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_clob_v1_clob_proto_init() }
+func file_clob_v1_clob_proto_init() {
+	if File_clob_v1_clob_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_clob_v1_clob_proto_rawDesc), len(file_clob_v1_clob_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   28,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_clob_v1_clob_proto_goTypes,
+		DependencyIndexes: file_clob_v1_clob_proto_depIdxs,
+		EnumInfos:         file_clob_v1_clob_proto_enumTypes,
+		MessageInfos:      file_clob_v1_clob_proto_msgTypes,
+	}.Build()
+	File_clob_v1_clob_proto = out.File
+	file_clob_v1_clob_proto_goTypes = nil
+	file_clob_v1_clob_proto_depIdxs = nil
+}
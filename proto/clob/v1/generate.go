@@ -0,0 +1,4 @@
+// Package clobv1 is the gRPC API, generated from clob.proto
+package clobv1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative clob/v1/clob.proto